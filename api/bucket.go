@@ -19,6 +19,7 @@ import (
 	"github.com/banzaicloud/pipeline/pkg/common"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	pkgProviders "github.com/banzaicloud/pipeline/pkg/providers"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 	"github.com/banzaicloud/pipeline/secret"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -153,6 +154,19 @@ func CreateBucket(c *gin.Context) {
 
 	case pkgProviders.Oracle:
 		objectStoreCtx.Location = createBucketRequest.Properties.Oracle.Location
+		objectStoreCtx.LifecycleRules = createBucketRequest.Properties.Oracle.LifecycleRules
+
+		if err := oci.ValidateObjectLifecycleRules(objectStoreCtx.LifecycleRules); err != nil {
+			logger.Errorf("lifecycle rules validation failed: %s", err.Error())
+
+			c.JSON(http.StatusBadRequest, common.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid lifecycle rules",
+				Error:   err.Error(),
+			})
+
+			return
+		}
 	}
 
 	objectStore, err := providers.NewObjectStore(objectStoreCtx, logger)
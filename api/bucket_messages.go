@@ -1,5 +1,9 @@
 package api
 
+import (
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
 // CreateBucketRequest to create bucket
 type CreateBucketRequest struct {
 	SecretId   string `json:"secretId" binding:"required"`
@@ -39,7 +43,8 @@ type CreateGoogleObjectStoreBucketProperties struct {
 
 // CreateObjectStoreBucketProperties describes Oracle Object Store Bucket creation request
 type CreateObjectStoreBucketProperties struct {
-	Location string `json:"location" binding:"required"`
+	Location       string                    `json:"location" binding:"required"`
+	LifecycleRules []oci.ObjectLifecycleRule `json:"lifecycleRules,omitempty"`
 }
 
 // CreateBucketResponse describes a storage bucket creation response
@@ -207,6 +207,16 @@ func CreateCluster(
 
 		logger.Info("create profile response")
 		profileResponse := profile.GetProfile()
+		if inheriting, ok := profile.(defaults.InheritingClusterProfile); ok {
+			profileResponse, err = inheriting.GetResolvedProfile()
+			if err != nil {
+				return nil, &pkgCommon.ErrorResponse{
+					Code:    http.StatusBadRequest,
+					Message: "error resolving profile",
+					Error:   err.Error(),
+				}
+			}
+		}
 
 		logger.Info("create cluster request from profile")
 		newRequest, err := profileResponse.CreateClusterRequest(createClusterRequest)
@@ -447,7 +457,10 @@ func UpdateCluster(c *gin.Context) {
 
 	userId := auth.GetCurrentUser(c.Request).ID
 
-	go postUpdateCluster(commonCluster, updateRequest, userId)
+	ctx := ginutils.Context(serverContext, c)
+	goAsyncOperation(func() {
+		postUpdateCluster(ctx, commonCluster, updateRequest, userId)
+	})
 
 	c.JSON(http.StatusAccepted, pkgCluster.UpdateClusterResponse{
 		Status: http.StatusAccepted,
@@ -455,9 +468,9 @@ func UpdateCluster(c *gin.Context) {
 }
 
 // postUpdateCluster updates a cluster (ASYNC)
-func postUpdateCluster(commonCluster cluster.CommonCluster, updateRequest *pkgCluster.UpdateClusterRequest, userId uint) error {
+func postUpdateCluster(ctx context.Context, commonCluster cluster.CommonCluster, updateRequest *pkgCluster.UpdateClusterRequest, userId uint) error {
 
-	err := commonCluster.UpdateCluster(updateRequest, userId)
+	err := commonCluster.UpdateCluster(ctx, updateRequest, userId)
 	if err != nil {
 		// validation failed
 		log.Errorf("Update failed: %s", err.Error())
@@ -496,7 +509,87 @@ func DeleteCluster(c *gin.Context) {
 		force = false
 	}
 
-	go postDeleteCluster(commonCluster, force)
+	if quiesceParam := c.Query("quiesce"); quiesceParam != "" {
+		gracePeriod, err := time.ParseDuration(quiesceParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid quiesce grace period",
+				Error:   err.Error(),
+			})
+			return
+		}
+		okeCluster, ok := commonCluster.(*cluster.OKECluster)
+		if !ok {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Quiesce is not supported by the cloud provider of the cluster",
+				Error:   fmt.Sprintf("quiesce is not supported for %s clusters", commonCluster.GetCloud()),
+			})
+			return
+		}
+		okeCluster.SetQuiesceOnDelete(gracePeriod)
+	}
+
+	if deleteSecretsParam := c.Query("deleteSecrets"); deleteSecretsParam != "" {
+		deleteSecrets, err := strconv.ParseBool(deleteSecretsParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid deleteSecrets parameter",
+				Error:   err.Error(),
+			})
+			return
+		}
+		if okeCluster, ok := commonCluster.(*cluster.OKECluster); ok {
+			okeCluster.SetDeleteSecretsOnDelete(deleteSecrets)
+		}
+	}
+
+	if policy := c.Query("dependentResources"); policy != "" {
+		if okeCluster, ok := commonCluster.(*cluster.OKECluster); ok {
+			if err := okeCluster.SetDependentResourcesPolicy(policy); err != nil {
+				c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+					Code:    http.StatusBadRequest,
+					Message: "Invalid dependentResources parameter",
+					Error:   err.Error(),
+				})
+				return
+			}
+		}
+	}
+
+	// the delete is refused before anything is deleted, the dependent resources are listed in the response,
+	// a forced delete goes on and leaves them behind
+	if okeCluster, ok := commonCluster.(*cluster.OKECluster); ok {
+		if err := okeCluster.CheckDependentResources(); err != nil {
+			if dependentErr, ok := err.(*cluster.DependentResourcesError); ok && !force {
+				c.JSON(http.StatusConflict, DependentResourcesResponse{
+					ErrorResponse: pkgCommon.ErrorResponse{
+						Code:    http.StatusConflict,
+						Message: "Cluster has resources which would be orphaned",
+						Error:   dependentErr.Error(),
+					},
+					Resources: dependentErr.Resources,
+				})
+				return
+			}
+			if !force {
+				c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "Error checking dependent resources",
+					Error:   err.Error(),
+				})
+				return
+			}
+			log.Warnf("Error checking dependent resources, forcing delete: %s", err.Error())
+		}
+	}
+
+	ctx := ginutils.Context(serverContext, c)
+	goAsyncOperation(func() {
+		postDeleteCluster(ctx, commonCluster, force)
+	})
 
 	deleteName := commonCluster.GetName()
 	deleteId := commonCluster.GetID()
@@ -508,8 +601,22 @@ func DeleteCluster(c *gin.Context) {
 	})
 }
 
+// DeleteOKEClusters deletes the given OKE clusters in batch, each cluster is deleted like by DeleteCluster
+func DeleteOKEClusters(ctx context.Context, clusters []*cluster.OKECluster, opts cluster.DeleteClustersOptions) map[uint]error {
+
+	return cluster.DeleteClusters(clusters, opts, func(commonCluster cluster.CommonCluster) error {
+		return postDeleteCluster(ctx, commonCluster, false)
+	})
+}
+
+// DependentResourcesResponse describes the resources which would be orphaned by deleting a cluster
+type DependentResourcesResponse struct {
+	pkgCommon.ErrorResponse
+	Resources []cluster.DependentResource `json:"resources"`
+}
+
 // postDeleteCluster deletes a cluster (ASYNC)
-func postDeleteCluster(commonCluster cluster.CommonCluster, force bool) error {
+func postDeleteCluster(ctx context.Context, commonCluster cluster.CommonCluster, force bool) error {
 
 	err := commonCluster.UpdateStatus(pkgCluster.Deleting, pkgCluster.DeletingMessage)
 	if err != nil {
@@ -517,6 +624,19 @@ func postDeleteCluster(commonCluster cluster.CommonCluster, force bool) error {
 		return err
 	}
 
+	// the workloads are quiesced and their OCI resources deleted while the deployments still exist
+	if okeCluster, ok := commonCluster.(*cluster.OKECluster); ok {
+		err = okeCluster.PrepareDelete(ctx)
+		if err != nil && !force {
+			log.Errorf("Error during preparing delete: %s", err.Error())
+			commonCluster.UpdateStatus(pkgCluster.Error, err.Error())
+			return err
+		}
+		if err != nil {
+			log.Warnf("Error during preparing delete, forcing delete: %s", err.Error())
+		}
+	}
+
 	// get kubeconfig
 	c, err := commonCluster.GetK8sConfig()
 	if err != nil && !force {
@@ -531,24 +651,33 @@ func postDeleteCluster(commonCluster cluster.CommonCluster, force bool) error {
 		log.Errorf("Problem deleting deployment: %s", err)
 	}
 
-	// delete cluster
-	err = commonCluster.DeleteCluster()
-	if err != nil && !force {
-		log.Errorf(errors.Wrap(err, "Error during delete cluster").Error())
-		commonCluster.UpdateStatus(pkgCluster.Error, err.Error())
-		return err
-	}
+	deleteName := commonCluster.GetName()
 
-	// delete from proxy from kubeProxyCache if any
-	kubeProxyCache.Delete(GetGlobalClusterID(commonCluster))
+	if okeCluster, ok := commonCluster.(*cluster.OKECluster); ok && force {
+		// the OKE cluster is removed from the database even if some of its resources are left behind
+		kubeProxyCache.Delete(GetGlobalClusterID(commonCluster))
+		if err := okeCluster.ForceDelete(ctx); err != nil {
+			log.Errorf(errors.Wrap(err, "Error during force delete cluster").Error())
+		}
+	} else {
+		// delete cluster
+		err = commonCluster.DeleteCluster(ctx)
+		if err != nil && !force {
+			log.Errorf(errors.Wrap(err, "Error during delete cluster").Error())
+			commonCluster.UpdateStatus(pkgCluster.Error, err.Error())
+			return err
+		}
 
-	// delete cluster from database
-	deleteName := commonCluster.GetName()
-	err = commonCluster.DeleteFromDatabase()
-	if err != nil && !force {
-		log.Errorf(errors.Wrap(err, "Error during delete cluster from database").Error())
-		commonCluster.UpdateStatus(pkgCluster.Error, err.Error())
-		return err
+		// delete from proxy from kubeProxyCache if any
+		kubeProxyCache.Delete(GetGlobalClusterID(commonCluster))
+
+		// delete cluster from database
+		err = commonCluster.DeleteFromDatabase()
+		if err != nil && !force {
+			log.Errorf(errors.Wrap(err, "Error during delete cluster from database").Error())
+			commonCluster.UpdateStatus(pkgCluster.Error, err.Error())
+			return err
+		}
 	}
 
 	// Asyncron update prometheus
@@ -740,6 +869,29 @@ func GetClusterDetails(c *gin.Context) {
 		return
 	}
 	log.Info("getting cluster details")
+
+	progressEvents, err := parseProgressEventsParam(c.Query("progressEvents"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid progressEvents parameter",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if progressEvents > 0 {
+		okeCluster, ok := commonCluster.(*cluster.OKECluster)
+		if !ok {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Progress events are not supported by the cloud provider of the cluster",
+				Error:   fmt.Sprintf("progress events are not supported for %s clusters", commonCluster.GetCloud()),
+			})
+			return
+		}
+		okeCluster.SetDetailsProgressEvents(progressEvents)
+	}
+
 	details, err := commonCluster.GetClusterDetails()
 	if err != nil {
 		log.Errorf("Error getting cluster: %s", err.Error())
@@ -780,6 +932,25 @@ func GetClusterDetails(c *gin.Context) {
 	c.JSON(http.StatusOK, details)
 }
 
+// maxDetailsProgressEvents is the maximum number of progress events included in the cluster details
+const maxDetailsProgressEvents = 100
+
+// parseProgressEventsParam parses the number of the latest progress events included in the cluster details,
+// zero if the parameter is not set
+func parseProgressEventsParam(value string) (int, error) {
+
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 || limit > maxDetailsProgressEvents {
+		return 0, fmt.Errorf("progressEvents must be a number between 0 and %d", maxDetailsProgressEvents)
+	}
+
+	return limit, nil
+}
+
 // addResourceSummaryToDetails adds resource summary to all node in each pool
 func addResourceSummaryToDetails(commonCluster cluster.CommonCluster, details *pkgCluster.DetailsResponse) error {
 
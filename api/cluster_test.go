@@ -0,0 +1,36 @@
+package api
+
+import "testing"
+
+func TestParseProgressEventsParam(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		value    string
+		expected int
+		err      bool
+	}{
+		{"not set", "", 0, false},
+		{"zero", "0", 0, false},
+		{"limit", "10", 10, false},
+		{"maximum", "100", 100, false},
+		{"over maximum", "101", 0, true},
+		{"negative", "-1", 0, true},
+		{"not a number", "all", 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := parseProgressEventsParam(tc.value)
+			if tc.err && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("Expected no error, got: %s", err.Error())
+			}
+			if limit != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// serverContext is the parent context of the asynchronous cluster operations started by the handlers
+var serverContext = context.Background()
+
+// asyncOperations tracks the asynchronous cluster operations started by the handlers
+var asyncOperations sync.WaitGroup
+
+// SetServerContext sets the parent context of the asynchronous cluster operations, it should be cancelled when the server stops
+func SetServerContext(ctx context.Context) {
+	serverContext = ctx
+}
+
+// WaitAsyncOperations blocks until the asynchronous cluster operations started by the handlers are finished
+func WaitAsyncOperations() {
+	asyncOperations.Wait()
+}
+
+// goAsyncOperation runs an asynchronous cluster operation in a new goroutine and tracks it until it returns
+func goAsyncOperation(operation func()) {
+	asyncOperations.Add(1)
+	go func() {
+		defer asyncOperations.Done()
+		operation()
+	}()
+}
@@ -138,7 +138,9 @@ func convertRequestToProfile(request *pkgCluster.ClusterProfileRequest) (default
 		return &gkeProfile, nil
 	case pkgCluster.Oracle:
 		var okeProfile oracle.Profile
-		okeProfile.UpdateProfile(request, false)
+		if err := okeProfile.UpdateProfile(request, false); err != nil {
+			return nil, err
+		}
 		return &okeProfile, nil
 	default:
 		return nil, pkgErrors.ErrorNotSupportedCloudType
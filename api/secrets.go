@@ -78,6 +78,34 @@ func validateSecret(c *gin.Context, createSecretRequest *secret.CreateSecretRequ
 	return
 }
 
+// checkSecretTagPolicy validates the tags against the tag policy of the organization, aborts the request if they
+// don't conform
+func checkSecretTagPolicy(c *gin.Context, tags []string) bool {
+
+	policy, err := secret.GetTagPolicy(auth.GetCurrentOrganization(c.Request).Name)
+	if err != nil {
+		log.Errorf("Error getting secret tag policy: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, common.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error getting secret tag policy",
+			Error:   err.Error(),
+		})
+		return false
+	}
+
+	if err := policy.Validate(tags); err != nil {
+		log.Errorf("Tag policy violation: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Tag policy violation",
+			Error:   err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
 // AddSecrets saves the given secret to vault
 func AddSecrets(c *gin.Context) {
 
@@ -115,6 +143,10 @@ func AddSecrets(c *gin.Context) {
 
 	log.Info("Binding request succeeded")
 
+	if !checkSecretTagPolicy(c, createSecretRequest.Tags) {
+		return
+	}
+
 	var validationError error
 	var ok bool
 	if ok, validationError = validateSecret(c, &createSecretRequest, validate); !ok {
@@ -215,6 +247,10 @@ func UpdateSecrets(c *gin.Context) {
 
 	log.Info("Binding request succeeded")
 
+	if !checkSecretTagPolicy(c, createSecretRequest.Tags) {
+		return
+	}
+
 	var validationError error
 	var ok bool
 	if ok, validationError = validateSecret(c, &createSecretRequest, validate); !ok {
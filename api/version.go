@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion is the version of the API served by Pipeline
+const APIVersion = "0.3.0"
+
+// NewVersionHandler gives back a handler which responds with the version of the Pipeline server
+func NewVersionHandler(version, gitRev, buildDate string) gin.HandlerFunc {
+
+	response := pkgCommon.VersionResponse{
+		Version:    version,
+		GitRev:     gitRev,
+		BuildDate:  buildDate,
+		APIVersion: APIVersion,
+	}
+
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, response)
+	}
+}
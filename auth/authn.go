@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	bauth "github.com/banzaicloud/bank-vaults/auth"
 	"github.com/banzaicloud/pipeline/config"
@@ -250,8 +251,9 @@ func GenerateToken(c *gin.Context) {
 	}
 
 	tokenRequest := struct {
-		Name        string `json:"name,omitempty"`
-		VirtualUser string `json:"virtualUser,omitempty"`
+		Name        string    `json:"name,omitempty"`
+		VirtualUser string    `json:"virtualUser,omitempty"`
+		ExpiresAt   time.Time `json:"expiresAt,omitempty"` // the zero time means the token does not expire
 	}{Name: "generated"}
 
 	if c.Request.Method == http.MethodPost && c.Request.ContentLength > 0 {
@@ -262,6 +264,16 @@ func GenerateToken(c *gin.Context) {
 		}
 	}
 
+	var expiresAt *time.Time
+	if !tokenRequest.ExpiresAt.IsZero() {
+		if !tokenRequest.ExpiresAt.After(jwt.TimeFunc()) {
+			err := c.AbortWithError(http.StatusBadRequest, fmt.Errorf("Token expiration must be in the future"))
+			log.Info(c.ClientIP(), " ", err.Error())
+			return
+		}
+		expiresAt = &tokenRequest.ExpiresAt
+	}
+
 	isForVirtualUser := tokenRequest.VirtualUser != ""
 
 	userID := currentUser.IDString()
@@ -273,7 +285,7 @@ func GenerateToken(c *gin.Context) {
 		tokenType = DroneHookTokenType
 	}
 
-	tokenID, signedToken, err := createAndStoreAPIToken(userID, userLogin, tokenType, tokenRequest.Name)
+	tokenID, signedToken, err := createAndStoreAPIToken(userID, userLogin, tokenType, tokenRequest.Name, expiresAt)
 
 	if err != nil {
 		err = c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s", err))
@@ -282,12 +294,7 @@ func GenerateToken(c *gin.Context) {
 	}
 
 	if isForVirtualUser {
-		orgName := GetOrgNameFromVirtualUser(tokenRequest.VirtualUser)
-		organization := Organization{Name: orgName}
-		err = Auth.GetDB(c.Request).
-			Model(currentUser).
-			Where(&organization).
-			Related(&organization, "Organizations").Error
+		organization, err := getVirtualUserOrganization(c.Request, currentUser, tokenRequest.VirtualUser)
 		if err != nil {
 			statusCode := GormErrorToStatusCode(err)
 			err = c.AbortWithError(statusCode, err)
@@ -299,19 +306,30 @@ func GenerateToken(c *gin.Context) {
 		AddOrgRoleForUser(userID, organization.ID)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"id": tokenID, "token": signedToken})
+	response := gin.H{"id": tokenID, "token": signedToken}
+	if expiresAt != nil {
+		response["expiresAt"] = expiresAt.UTC().Truncate(time.Second)
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
-func createAPIToken(userID string, userLogin string, tokenType bauth.TokenType) (string, string, error) {
+// createAPIToken creates a signed API token, the token does not expire if expiresAt is nil
+func createAPIToken(userID string, userLogin string, tokenType bauth.TokenType, expiresAt *time.Time) (string, string, error) {
 	tokenID := uuid.NewV4().String()
 
+	var expiresAtUnix int64
+	if expiresAt != nil {
+		expiresAtUnix = expiresAt.Unix()
+	}
+
 	// Create the Claims
 	claims := &bauth.ScopedClaims{
 		StandardClaims: jwt.StandardClaims{
 			Issuer:    JwtIssuer,
 			Audience:  JwtAudience,
 			IssuedAt:  jwt.TimeFunc().Unix(),
-			ExpiresAt: 0,
+			ExpiresAt: expiresAtUnix,
 			Subject:   userID,
 			Id:        tokenID,
 		},
@@ -329,8 +347,8 @@ func createAPIToken(userID string, userLogin string, tokenType bauth.TokenType)
 	return tokenID, signedToken, nil
 }
 
-func createAndStoreAPIToken(userID string, userLogin string, tokenType bauth.TokenType, tokenName string) (string, string, error) {
-	tokenID, signedToken, err := createAPIToken(userID, userLogin, tokenType)
+func createAndStoreAPIToken(userID string, userLogin string, tokenType bauth.TokenType, tokenName string, expiresAt *time.Time) (string, string, error) {
+	tokenID, signedToken, err := createAPIToken(userID, userLogin, tokenType, expiresAt)
 	if err != nil {
 		return "", "", err
 	}
@@ -355,7 +373,19 @@ func GetTokens(c *gin.Context) {
 	tokenID := c.Param("id")
 
 	if tokenID == "" {
-		tokens, err := TokenStore.List(currentUser.IDString())
+		// the tokens of a virtual user are stored for the virtual user
+		userID := currentUser.IDString()
+		if virtualUser := c.Query("virtualUser"); virtualUser != "" {
+			if _, err := getVirtualUserOrganization(c.Request, currentUser, virtualUser); err != nil {
+				statusCode := GormErrorToStatusCode(err)
+				err = c.AbortWithError(statusCode, err)
+				log.Info(c.ClientIP(), " ", err.Error())
+				return
+			}
+			userID = virtualUser
+		}
+
+		tokens, err := TokenStore.List(userID)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
 		} else {
@@ -421,7 +451,7 @@ func (sessionStorer *BanzaiSessionStorer) Update(w http.ResponseWriter, req *htt
 
 	// Drone tokens have to stored in Vault, because they act as Pipeline API tokens as well
 	// TODO We need GC them somehow
-	_, droneToken, err := createAndStoreAPIToken(claims.UserID, currentUser.Login, DroneUserTokenType, "Drone session token")
+	_, droneToken, err := createAndStoreAPIToken(claims.UserID, currentUser.Login, DroneUserTokenType, "Drone session token", nil)
 	if err != nil {
 		log.Info(req.RemoteAddr, err.Error())
 		return err
@@ -531,3 +561,14 @@ func BanzaiDeregisterHandler(context *auth.Context) {
 func GetOrgNameFromVirtualUser(virtualUser string) string {
 	return strings.Split(virtualUser, "/")[0]
 }
+
+// getVirtualUserOrganization gives back the organization of the virtual user if the user is a member of it
+func getVirtualUserOrganization(r *http.Request, user *User, virtualUser string) (Organization, error) {
+	organization := Organization{Name: GetOrgNameFromVirtualUser(virtualUser)}
+	err := Auth.GetDB(r).
+		Model(user).
+		Where(&organization).
+		Related(&organization, "Organizations").Error
+
+	return organization, err
+}
@@ -13,6 +13,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"github.com/antihax/optional"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -225,9 +226,16 @@ func (a *AuthApiService) DeleteToken(ctx context.Context, tokenId string) (*http
 AuthApiService List all API tokens
 List all API tokens
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param optional nil or *ListTokensOpts - Optional Parameters:
+ * @param "VirtualUser" (optional.String) -  List the tokens of the virtual user instead of the tokens of the current user
 @return []TokenListResponseItem
 */
-func (a *AuthApiService) ListTokens(ctx context.Context) ([]TokenListResponseItem, *http.Response, error) {
+
+type ListTokensOpts struct {
+	VirtualUser optional.String
+}
+
+func (a *AuthApiService) ListTokens(ctx context.Context, localVarOptionals *ListTokensOpts) ([]TokenListResponseItem, *http.Response, error) {
 	var (
 		localVarHttpMethod   = strings.ToUpper("Get")
 		localVarPostBody     interface{}
@@ -244,6 +252,9 @@ func (a *AuthApiService) ListTokens(ctx context.Context) ([]TokenListResponseIte
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if localVarOptionals != nil && localVarOptionals.VirtualUser.IsSet() {
+		localVarQueryParams.Add("virtualUser", parameterToString(localVarOptionals.VirtualUser.Value(), ""))
+	}
 	// to determine the Content-Type header
 	localVarHttpContentTypes := []string{}
 
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ghodss/yaml"
+)
+
+// CreateClusterFromSpec parses a YAML cluster spec into a CreateClusterRequest and creates the cluster
+func (a *ClustersApiService) CreateClusterFromSpec(ctx context.Context, orgId int32, specYAML []byte) (CreateClusterResponse202, *http.Response, error) {
+
+	var request CreateClusterRequest
+	if err := yaml.Unmarshal(specYAML, &request); err != nil {
+		return CreateClusterResponse202{}, nil, fmt.Errorf("error parsing cluster spec: %s", err.Error())
+	}
+
+	if request.Name == "" || request.Cloud == "" {
+		return CreateClusterResponse202{}, nil, fmt.Errorf("cluster spec must contain name and cloud")
+	}
+
+	return a.CreateCluster(ctx, orgId, request)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Cluster statuses WaitForClusterReady ends on
+const (
+	ClusterStatusRunning = "RUNNING"
+	ClusterStatusError   = "ERROR"
+)
+
+// Defaults of WaitForClusterReadyOpts
+const (
+	DefaultClusterReadyPollInterval    = 10 * time.Second
+	DefaultClusterReadyMaxPollInterval = time.Minute
+	DefaultClusterReadyTimeout         = 30 * time.Minute
+)
+
+// WaitForClusterReadyOpts sets the polling of WaitForClusterReady, the defaults are used for the zero values:
+// the polling interval is doubled after every poll up to MaxPollInterval
+type WaitForClusterReadyOpts struct {
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	Timeout         time.Duration
+}
+
+// WaitForClusterReady polls the cluster until its status is RUNNING and gives back its last status, an error is
+// returned if the status is ERROR, the timeout expires or the context is done
+func (a *ClustersApiService) WaitForClusterReady(ctx context.Context, orgId int32, id int32, localVarOptionals *WaitForClusterReadyOpts) (GetClusterStatusResponse, *http.Response, error) {
+
+	opts := WaitForClusterReadyOpts{}
+	if localVarOptionals != nil {
+		opts = *localVarOptionals
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultClusterReadyPollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = DefaultClusterReadyMaxPollInterval
+	}
+	if opts.MaxPollInterval < opts.PollInterval {
+		opts.MaxPollInterval = opts.PollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultClusterReadyTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.PollInterval
+	for {
+		status, localVarHttpResponse, err := a.GetCluster(ctx, orgId, id)
+		if err != nil {
+			return status, localVarHttpResponse, err
+		}
+
+		switch status.Status {
+		case ClusterStatusRunning:
+			return status, localVarHttpResponse, nil
+		case ClusterStatusError:
+			return status, localVarHttpResponse, fmt.Errorf("cluster %d is in %s status: %s", id, status.Status, status.StatusMessage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, localVarHttpResponse, fmt.Errorf("cluster %d is not ready, last status %s: %s", id, status.Status, ctx.Err().Error())
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/antihax/optional"
+)
+
+// UpdateSecretRequest describes the new values of a secret
+type UpdateSecretRequest struct {
+	Values map[string]interface{} `json:"values"`
+}
+
+// UpdateSecretOpts sets the optional parameters of UpdateSecret
+type UpdateSecretOpts struct {
+	Validate optional.Bool
+}
+
+// UpdateSecret replaces the values of the secret in place: the id, the name, the type and the tags are preserved, so
+// the clusters referencing the secret get the new values. The values are validated against the type of the secret
+// like by AddSecrets. The update fails if the secret is modified after it is read.
+func (a *SecretsApiService) UpdateSecret(ctx context.Context, orgId int32, secretId string, request UpdateSecretRequest, localVarOptionals *UpdateSecretOpts) (CreateSecretResponse, *http.Response, error) {
+
+	current, localVarHttpResponse, err := a.GetSecret(ctx, orgId, secretId)
+	if err != nil {
+		return CreateSecretResponse{}, localVarHttpResponse, err
+	}
+
+	updateOpts := &UpdateSecretsOpts{}
+	if localVarOptionals != nil {
+		updateOpts.Validate = localVarOptionals.Validate
+	}
+
+	return a.UpdateSecrets(ctx, orgId, secretId, CreateSecretRequest{
+		Name:    current.Name,
+		Type:    current.Type,
+		Tags:    current.Tags,
+		Version: current.Version,
+		Values:  request.Values,
+	}, updateOpts)
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SupportedAPIVersion is the version of the Pipeline API this client was generated for
+const SupportedAPIVersion = "0.3.0"
+
+// VersionInfo describes the version of the Pipeline server and the API it serves
+type VersionInfo struct {
+	Version    string `json:"version"`
+	GitRev     string `json:"gitRev,omitempty"`
+	BuildDate  string `json:"buildDate,omitempty"`
+	APIVersion string `json:"apiVersion"`
+}
+
+// GetVersion gives back the version of the Pipeline server
+func (a *ClustersApiService) GetVersion(ctx context.Context) (*VersionInfo, error) {
+
+	localVarPath := a.client.cfg.BasePath + "/api/v1/version"
+
+	localVarHeaderParams := map[string]string{
+		"Accept": "application/json",
+	}
+
+	r, err := a.client.prepareRequest(ctx, localVarPath, http.MethodGet, nil, localVarHeaderParams, url.Values{}, url.Values{}, "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHttpResponse, err := a.client.callAPI(r)
+	if err != nil || localVarHttpResponse == nil {
+		return nil, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHttpResponse.Body)
+	localVarHttpResponse.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if localVarHttpResponse.StatusCode >= 300 {
+		return nil, GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHttpResponse.Status,
+		}
+	}
+
+	var version VersionInfo
+	err = a.client.decode(&version, localVarBody, localVarHttpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// CheckVersion checks that the API version of the Pipeline server is compatible with the client,
+// the major and minor versions must match
+func (a *ClustersApiService) CheckVersion(ctx context.Context) error {
+
+	version, err := a.GetVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting pipeline version: %s", err.Error())
+	}
+
+	return CheckAPIVersion(version.APIVersion)
+}
+
+// CheckAPIVersion checks that the given server API version is compatible with SupportedAPIVersion
+func CheckAPIVersion(serverAPIVersion string) error {
+
+	server, err := parseAPIVersion(serverAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	supported, err := parseAPIVersion(SupportedAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	for i := range supported {
+		if supported[i] < server[i] {
+			return fmt.Errorf("client too old: pipeline serves API version %s, client supports %s", serverAPIVersion, SupportedAPIVersion)
+		}
+		if supported[i] > server[i] {
+			return fmt.Errorf("client too new: pipeline serves API version %s, client supports %s", serverAPIVersion, SupportedAPIVersion)
+		}
+	}
+
+	return nil
+}
+
+// parseAPIVersion gives back the major and minor version of the given API version
+func parseAPIVersion(version string) ([2]int, error) {
+
+	var parsed [2]int
+
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return parsed, fmt.Errorf("invalid API version: %q", version)
+	}
+
+	for i := range parsed {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return parsed, fmt.Errorf("invalid API version: %q", version)
+		}
+		parsed[i] = n
+	}
+
+	return parsed, nil
+}
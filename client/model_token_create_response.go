@@ -10,8 +10,13 @@
 
 package client
 
+import (
+	"time"
+)
+
 type TokenCreateResponse struct {
-	Id    string `json:"id"`
-	Token string `json:"token"`
-	Name  string `json:"name"`
+	Id        string    `json:"id"`
+	Token     string    `json:"token"`
+	Name      string    `json:"name"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
 }
@@ -3,6 +3,7 @@ package cluster
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -208,7 +209,7 @@ func CreateACSKClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgI
 	return &cluster, nil
 }
 
-func (c *ACSKCluster) CreateCluster() error {
+func (c *ACSKCluster) CreateCluster(ctx context.Context) error {
 	log.Info("Start create cluster (Alibaba)")
 
 	// TODO: create method for this
@@ -528,7 +529,7 @@ func (c *ACSKCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error)
 	}, nil
 }
 
-func (c *ACSKCluster) DeleteCluster() error {
+func (c *ACSKCluster) DeleteCluster(ctx context.Context) error {
 	log.Info("Start deleting cluster (alibaba)")
 
 	client, err := c.GetAlibabaCSClient(nil)
@@ -559,7 +560,7 @@ func (c *ACSKCluster) DeleteCluster() error {
 	return nil
 }
 
-func (c *ACSKCluster) UpdateCluster(request *pkgCluster.UpdateClusterRequest, userId uint) error {
+func (c *ACSKCluster) UpdateCluster(ctx context.Context, request *pkgCluster.UpdateClusterRequest, userId uint) error {
 	log.Info("Start updating cluster (alibaba)")
 
 	client, err := c.GetAlibabaCSClient(nil)
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-04-01/compute"
@@ -112,7 +113,7 @@ func (c *AKSCluster) GetAPIEndpoint() (string, error) {
 }
 
 //CreateCluster creates a new cluster
-func (c *AKSCluster) CreateCluster() error {
+func (c *AKSCluster) CreateCluster(ctx context.Context) error {
 
 	// create profiles model for the request
 	var profiles []containerservice.AgentPoolProfile
@@ -259,7 +260,7 @@ func (c *AKSCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 }
 
 // DeleteCluster deletes cluster from aks
-func (c *AKSCluster) DeleteCluster() error {
+func (c *AKSCluster) DeleteCluster(ctx context.Context) error {
 	client, err := c.GetAKSClient()
 	if err != nil {
 		return err
@@ -281,7 +282,7 @@ func (c *AKSCluster) DeleteCluster() error {
 }
 
 // UpdateCluster updates AKS cluster in cloud
-func (c *AKSCluster) UpdateCluster(request *pkgCluster.UpdateClusterRequest, userId uint) error {
+func (c *AKSCluster) UpdateCluster(ctx context.Context, request *pkgCluster.UpdateClusterRequest, userId uint) error {
 	client, err := c.GetAKSClient()
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
@@ -9,15 +10,18 @@ import (
 
 	"github.com/banzaicloud/pipeline/auth"
 	"github.com/banzaicloud/pipeline/config"
+	"github.com/banzaicloud/pipeline/helm"
 	"github.com/banzaicloud/pipeline/internal/platform/database"
 	"github.com/banzaicloud/pipeline/model"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
+	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
 	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
 	pkgSecret "github.com/banzaicloud/pipeline/pkg/secret"
 	"github.com/banzaicloud/pipeline/secret"
 	"github.com/banzaicloud/pipeline/utils"
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -47,12 +51,12 @@ type CommonCluster interface {
 	DeleteFromDatabase() error
 
 	// Cluster management
-	CreateCluster() error
+	CreateCluster(context.Context) error
 	ValidateCreationFields(r *pkgCluster.CreateClusterRequest) error
-	UpdateCluster(*pkgCluster.UpdateClusterRequest, uint) error
+	UpdateCluster(context.Context, *pkgCluster.UpdateClusterRequest, uint) error
 	CheckEqualityToUpdate(*pkgCluster.UpdateClusterRequest) error
 	AddDefaultsToUpdate(*pkgCluster.UpdateClusterRequest)
-	DeleteCluster() error
+	DeleteCluster(context.Context) error
 
 	// Kubernetes
 	DownloadK8sConfig() ([]byte, error)
@@ -137,6 +141,42 @@ func (c *CommonClusterBase) getConfig(cluster CommonCluster) ([]byte, error) {
 	return c.config, nil
 }
 
+// listHelmReleases lists the Helm releases installed into the cluster, the releases of all namespaces are listed
+// if the namespace is empty
+func (c *CommonClusterBase) listHelmReleases(cluster CommonCluster, namespace string) ([]pkgHelm.ListDeploymentResponse, error) {
+
+	kubeConfig, err := cluster.GetK8sConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s config")
+	}
+
+	response, err := helm.ListReleases(namespace, kubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing helm releases")
+	}
+
+	releases := make([]pkgHelm.ListDeploymentResponse, 0)
+	if response == nil {
+		return releases, nil
+	}
+
+	for _, r := range response.Releases {
+		releases = append(releases, pkgHelm.ListDeploymentResponse{
+			Name:         r.GetName(),
+			Chart:        helm.GetVersionedChartName(r.GetChart().GetMetadata().GetName(), r.GetChart().GetMetadata().GetVersion()),
+			ChartName:    r.GetChart().GetMetadata().GetName(),
+			ChartVersion: r.GetChart().GetMetadata().GetVersion(),
+			Version:      r.GetVersion(),
+			Updated:      utils.ConvertSecondsToTime(time.Unix(r.GetInfo().GetLastDeployed().GetSeconds(), 0)),
+			Status:       r.GetInfo().GetStatus().GetCode().String(),
+			Namespace:    r.GetNamespace(),
+			CreatedAt:    utils.ConvertSecondsToTime(time.Unix(r.GetInfo().GetFirstDeployed().GetSeconds(), 0)),
+		})
+	}
+
+	return releases, nil
+}
+
 // StoreKubernetesConfig stores the given K8S config in vault
 func StoreKubernetesConfig(cluster CommonCluster, config []byte) error {
 
@@ -322,7 +362,7 @@ func GetCommonClusterFromModel(modelCluster *model.ClusterModel) (CommonCluster,
 		}
 
 		log.Info("Load Oracle props from database")
-		err = db.Where(modelOracle.Cluster{ClusterModelID: okeCluster.modelCluster.ID}).Preload("NodePools.Subnets").Preload("NodePools.Labels").First(&okeCluster.modelCluster.OKE).Error
+		err = db.Where(modelOracle.Cluster{ClusterModelID: okeCluster.modelCluster.ID}).Preload("NodePools.Subnets").Preload("NodePools.Labels").Preload("NodePools.StartupTaints").First(&okeCluster.modelCluster.OKE).Error
 
 		return okeCluster, err
 	}
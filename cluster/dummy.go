@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"context"
+
 	"github.com/banzaicloud/pipeline/model"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
@@ -36,7 +38,7 @@ func CreateDummyClusterFromRequest(request *pkgCluster.CreateClusterRequest, org
 }
 
 //CreateCluster creates a new cluster
-func (c *DummyCluster) CreateCluster() error {
+func (c *DummyCluster) CreateCluster(ctx context.Context) error {
 	return nil
 }
 
@@ -83,12 +85,12 @@ func (c *DummyCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error)
 }
 
 // DeleteCluster deletes cluster
-func (c *DummyCluster) DeleteCluster() error {
+func (c *DummyCluster) DeleteCluster(ctx context.Context) error {
 	return nil
 }
 
 // UpdateCluster updates the dummy cluster
-func (c *DummyCluster) UpdateCluster(r *pkgCluster.UpdateClusterRequest, _ uint) error {
+func (c *DummyCluster) UpdateCluster(ctx context.Context, r *pkgCluster.UpdateClusterRequest, _ uint) error {
 	c.modelCluster.Dummy.KubernetesVersion = r.Dummy.Node.KubernetesVersion
 	c.modelCluster.Dummy.NodeCount = r.Dummy.Node.Count
 	return nil
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -193,7 +194,7 @@ func (c *EC2Cluster) Persist(status, statusMessage string) error {
 }
 
 //CreateCluster creates a new cluster
-func (c *EC2Cluster) CreateCluster() error {
+func (c *EC2Cluster) CreateCluster(ctx context.Context) error {
 
 	// Set up credentials TODO simplify
 	runtimeParam := pkg.RuntimeParameters{
@@ -559,7 +560,7 @@ func (c *EC2Cluster) getExistingNodePoolByName(name string) *model.AmazonNodePoo
 }
 
 // UpdateCluster updates Amazon cluster in cloud
-func (c *EC2Cluster) UpdateCluster(request *pkgCluster.UpdateClusterRequest, userId uint) error {
+func (c *EC2Cluster) UpdateCluster(ctx context.Context, request *pkgCluster.UpdateClusterRequest, userId uint) error {
 
 	kubicornLogger.Level = getKubicornLogLevel()
 
@@ -793,7 +794,7 @@ func ReadCluster(modelCluster *model.ClusterModel) (*kcluster.Cluster, error) {
 }
 
 // DeleteCluster deletes cluster from ec2
-func (c *EC2Cluster) DeleteCluster() error {
+func (c *EC2Cluster) DeleteCluster(ctx context.Context) error {
 
 	kubicornLogger.Level = getKubicornLogLevel()
 
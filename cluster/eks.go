@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -133,7 +134,7 @@ func (c *EKSCluster) createAWSCredentialsFromSecret() (*credentials.Credentials,
 }
 
 // CreateCluster creates an EKS cluster with cloudformation templates.
-func (c *EKSCluster) CreateCluster() error {
+func (c *EKSCluster) CreateCluster(ctx context.Context) error {
 	c.log.Info("Start creating EKS cluster")
 
 	awsCred, err := c.createAWSCredentialsFromSecret()
@@ -290,7 +291,7 @@ func (c *EKSCluster) GetDistribution() string {
 }
 
 // DeleteCluster deletes cluster from EKS
-func (c *EKSCluster) DeleteCluster() error {
+func (c *EKSCluster) DeleteCluster(ctx context.Context) error {
 	c.log.Info("Start delete EKS cluster")
 
 	awsCred, err := c.createAWSCredentialsFromSecret()
@@ -413,7 +414,7 @@ func (c *EKSCluster) createNodePoolsFromUpdateRequest(requestedNodePools map[str
 }
 
 // UpdateCluster updates EKS cluster in cloud
-func (c *EKSCluster) UpdateCluster(updateRequest *pkgCluster.UpdateClusterRequest, updatedBy uint) error {
+func (c *EKSCluster) UpdateCluster(ctx context.Context, updateRequest *pkgCluster.UpdateClusterRequest, updatedBy uint) error {
 	c.log.Info("Start updating EKS cluster")
 
 	awsCred, err := c.createAWSCredentialsFromSecret()
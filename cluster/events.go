@@ -0,0 +1,253 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/banzaicloud/pipeline/auth"
+	pipConfig "github.com/banzaicloud/pipeline/config"
+)
+
+// Types of the cluster events
+const (
+	EventClusterCreated = "ClusterCreated"
+	EventClusterUpdated = "ClusterUpdated"
+	EventClusterDeleted = "ClusterDeleted"
+	EventStatusChanged  = "StatusChanged"
+	EventNodePoolScaled = "NodePoolScaled"
+	EventNodePoolRolled = "NodePoolRolled"
+	EventNodeReplaced   = "NodeReplaced"
+	EventDriftDetected  = "DriftDetected"
+)
+
+// webhookEventSinkTimeout is the timeout of posting an event to a webhook
+const webhookEventSinkTimeout = 10 * time.Second
+
+// eventQueueSize is the number of events buffered for the sinks, the events are dropped while the queue is full
+const eventQueueSize = 1000
+
+// Event describes a lifecycle or node event of a cluster, Error is set if the operation failed
+type Event struct {
+	Type           string            `json:"type"`
+	OrganizationID uint              `json:"organizationId"`
+	ClusterID      uint              `json:"clusterId"`
+	ClusterName    string            `json:"clusterName"`
+	Cloud          string            `json:"cloud"`
+	NodePool       string            `json:"nodePool,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	Details        map[string]string `json:"details,omitempty"`
+	Timestamp      time.Time         `json:"timestamp"`
+}
+
+// EventSink receives the cluster events, e.g. to forward them to a message queue
+type EventSink interface {
+	Emit(event Event) error
+}
+
+// EventSinkFunc is an EventSink calling the function with the events
+type EventSinkFunc func(event Event) error
+
+// Emit calls the function with the event
+func (f EventSinkFunc) Emit(event Event) error {
+	return f(event)
+}
+
+// NoopEventSink drops the events, it is used when no sink is configured for the organization
+type NoopEventSink struct{}
+
+// Emit drops the event
+func (NoopEventSink) Emit(event Event) error {
+	return nil
+}
+
+// WebhookEventSink posts the events as JSON to an URL
+type WebhookEventSink struct {
+	URL string
+
+	client *http.Client
+}
+
+// NewWebhookEventSink creates a WebhookEventSink posting to the given URL
+func NewWebhookEventSink(url string) *WebhookEventSink {
+
+	return &WebhookEventSink{
+		URL:    url,
+		client: &http.Client{Timeout: webhookEventSinkTimeout},
+	}
+}
+
+// Emit posts the event to the webhook
+func (s *WebhookEventSink) Emit(event Event) error {
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling event")
+	}
+
+	client := s.client
+	if client == nil {
+		client = &http.Client{Timeout: webhookEventSinkTimeout}
+	}
+
+	resp, err := client.Post(s.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error posting event")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("event webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// eventSinks holds the sinks of the organizations, the resolved webhook sinks of the organizations (nil if the
+// organization has no webhook) and the sink of the organizations without one
+var eventSinks = struct {
+	sync.RWMutex
+	defaultSink EventSink
+	sinks       map[uint]EventSink
+	webhooks    map[uint]EventSink
+}{
+	defaultSink: NoopEventSink{},
+	sinks:       make(map[uint]EventSink),
+	webhooks:    make(map[uint]EventSink),
+}
+
+// eventQueue buffers the events for the dispatcher which emits them to the sinks one after the other
+var eventQueue = struct {
+	once   sync.Once
+	events chan Event
+}{
+	events: make(chan Event, eventQueueSize),
+}
+
+// getOrganizationName gives back the name of the organization, the webhooks are configured by organization name
+var getOrganizationName = func(orgID uint) (string, error) {
+
+	org, err := auth.GetOrganizationById(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	return org.Name, nil
+}
+
+// SetEventSink sets the sink of the events of the clusters of the organization, nil restores the configured one
+func SetEventSink(orgID uint, sink EventSink) {
+
+	eventSinks.Lock()
+	defer eventSinks.Unlock()
+
+	if sink == nil {
+		delete(eventSinks.sinks, orgID)
+		return
+	}
+
+	eventSinks.sinks[orgID] = sink
+}
+
+// SetDefaultEventSink sets the sink of the events of the organizations which have no sink, nil restores the no-op sink
+func SetDefaultEventSink(sink EventSink) {
+
+	eventSinks.Lock()
+	defer eventSinks.Unlock()
+
+	if sink == nil {
+		sink = NoopEventSink{}
+	}
+
+	eventSinks.defaultSink = sink
+}
+
+// GetEventSink gives back the sink of the organization: the one set by SetEventSink, the configured webhook
+// of the organization or the default sink; the webhook of an organization is resolved once
+func GetEventSink(orgID uint) EventSink {
+
+	eventSinks.RLock()
+	sink, ok := eventSinks.sinks[orgID]
+	webhook, resolved := eventSinks.webhooks[orgID]
+	defaultSink := eventSinks.defaultSink
+	eventSinks.RUnlock()
+
+	if ok {
+		return sink
+	}
+
+	if !resolved {
+		var err error
+		webhook, err = resolveWebhookEventSink(orgID)
+		if err != nil {
+			log.Warnf("error resolving event webhook of organization %d: %s", orgID, err.Error())
+			return defaultSink
+		}
+
+		eventSinks.Lock()
+		eventSinks.webhooks[orgID] = webhook
+		eventSinks.Unlock()
+	}
+
+	if webhook != nil {
+		return webhook
+	}
+
+	return defaultSink
+}
+
+// resolveWebhookEventSink gives back the sink of the configured webhook of the organization, nil if it has none
+func resolveWebhookEventSink(orgID uint) (EventSink, error) {
+
+	webhooks := viper.GetStringMapString(pipConfig.EventSinkWebhooks)
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	name, err := getOrganizationName(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// viper lowercases the keys
+	if url, ok := webhooks[strings.ToLower(name)]; ok && url != "" {
+		return NewWebhookEventSink(url), nil
+	}
+
+	return nil, nil
+}
+
+// emitEvent queues the event for the sink of its organization, so slow sinks don't hold up the cluster operations,
+// errors of the sink are only logged
+func emitEvent(event Event) {
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	eventQueue.once.Do(func() {
+		go dispatchEvents(eventQueue.events)
+	})
+
+	select {
+	case eventQueue.events <- event:
+	default:
+		log.Warnf("event queue is full, dropping %s event of cluster %s", event.Type, event.ClusterName)
+	}
+}
+
+// dispatchEvents emits the queued events to the sinks of their organizations
+func dispatchEvents(events <-chan Event) {
+
+	for event := range events {
+		if err := GetEventSink(event.OrganizationID).Emit(event); err != nil {
+			log.Warnf("error emitting %s event of cluster %s: %s", event.Type, event.ClusterName, err.Error())
+		}
+	}
+}
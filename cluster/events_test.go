@@ -0,0 +1,151 @@
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+)
+
+type recordingEventSink struct {
+	events chan Event
+}
+
+func (s *recordingEventSink) Emit(event Event) error {
+
+	s.events <- event
+	return nil
+}
+
+func resetEventSinks() {
+
+	eventSinks.Lock()
+	defer eventSinks.Unlock()
+
+	eventSinks.defaultSink = NoopEventSink{}
+	eventSinks.sinks = make(map[uint]EventSink)
+	eventSinks.webhooks = make(map[uint]EventSink)
+}
+
+func TestGetEventSink(t *testing.T) {
+
+	resetEventSinks()
+	defer resetEventSinks()
+
+	lookups := 0
+	getOrganizationName = func(orgID uint) (string, error) {
+		lookups++
+		return map[uint]string{1: "Acme", 2: "Other"}[orgID], nil
+	}
+	viper.Set(pipConfig.EventSinkWebhooks, map[string]string{"acme": "http://events.acme.example"})
+	defer viper.Set(pipConfig.EventSinkWebhooks, map[string]string{})
+
+	defaultSink := &recordingEventSink{}
+	SetDefaultEventSink(defaultSink)
+
+	webhook, ok := GetEventSink(1).(*WebhookEventSink)
+	if !ok || webhook.URL != "http://events.acme.example" {
+		t.Errorf("Expected the webhook sink of the organization, got %#v", GetEventSink(1))
+	}
+	if sink := GetEventSink(2); sink != defaultSink {
+		t.Errorf("Expected the default sink for an organization without webhook, got %#v", sink)
+	}
+
+	GetEventSink(1)
+	GetEventSink(2)
+	if lookups != 2 {
+		t.Errorf("Expected the webhooks to be resolved once per organization, got %d lookups", lookups)
+	}
+
+	custom := &recordingEventSink{}
+	SetEventSink(1, custom)
+	if sink := GetEventSink(1); sink != custom {
+		t.Errorf("Expected the sink set for the organization, got %#v", sink)
+	}
+
+	SetEventSink(1, nil)
+	if _, ok := GetEventSink(1).(*WebhookEventSink); !ok {
+		t.Errorf("Expected the webhook sink after removing the sink of the organization, got %#v", GetEventSink(1))
+	}
+}
+
+func TestWebhookEventSink(t *testing.T) {
+
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Expected a JSON event, got error: %s", err.Error())
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", contentType)
+		}
+		received <- event
+		if event.Type == EventClusterDeleted {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookEventSink(server.URL)
+
+	err := sink.Emit(Event{Type: EventClusterCreated, ClusterID: 1, ClusterName: "test", Error: "failed"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err.Error())
+	}
+	event := <-received
+	if event.Type != EventClusterCreated || event.ClusterID != 1 || event.ClusterName != "test" || event.Error != "failed" {
+		t.Errorf("Unexpected event received: %#v", event)
+	}
+
+	if err := sink.Emit(Event{Type: EventClusterDeleted}); err == nil {
+		t.Error("Expected error for an error response of the webhook, got nil")
+	}
+	<-received
+}
+
+func TestEmitEventIsAsync(t *testing.T) {
+
+	resetEventSinks()
+	defer resetEventSinks()
+
+	release := make(chan struct{})
+	sink := &recordingEventSink{events: make(chan Event, 2)}
+	SetEventSink(1, EventSinkFunc(func(event Event) error {
+		<-release
+		return sink.Emit(event)
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		emitEvent(Event{Type: EventClusterCreated, OrganizationID: 1})
+		emitEvent(Event{Type: EventClusterDeleted, OrganizationID: 1})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected emitEvent not to wait for the sink")
+	}
+
+	close(release)
+	for _, expected := range []string{EventClusterCreated, EventClusterDeleted} {
+		select {
+		case event := <-sink.events:
+			if event.Type != expected {
+				t.Errorf("Expected %s event, got %s", expected, event.Type)
+			}
+			if event.Timestamp.IsZero() {
+				t.Error("Expected the timestamp of the event to be set")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %s event to be emitted", expected)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
@@ -182,7 +183,7 @@ func (c *GKECluster) GetAPIEndpoint() (string, error) {
 }
 
 //CreateCluster creates a new cluster
-func (c *GKECluster) CreateCluster() error {
+func (c *GKECluster) CreateCluster(ctx context.Context) error {
 
 	log.Info("Start create cluster (Google)")
 
@@ -341,7 +342,7 @@ func (c *GKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 }
 
 // DeleteCluster deletes cluster from google
-func (c *GKECluster) DeleteCluster() error {
+func (c *GKECluster) DeleteCluster(ctx context.Context) error {
 
 	if err := c.waitForResourcesDelete(); err != nil {
 		return err
@@ -533,7 +534,7 @@ func checkResources(checkers resourceCheckers, maxAttempts, sleepSeconds int) er
 }
 
 // UpdateCluster updates GKE cluster in cloud
-func (c *GKECluster) UpdateCluster(updateRequest *pkgCluster.UpdateClusterRequest, userId uint) error {
+func (c *GKECluster) UpdateCluster(ctx context.Context, updateRequest *pkgCluster.UpdateClusterRequest, userId uint) error {
 
 	log.Info("Start updating cluster (gke)")
 
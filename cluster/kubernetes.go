@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/base64"
 
 	"github.com/banzaicloud/pipeline/config"
@@ -43,7 +44,7 @@ type KubeCluster struct {
 }
 
 // CreateCluster creates a new cluster
-func (c *KubeCluster) CreateCluster() error {
+func (c *KubeCluster) CreateCluster(ctx context.Context) error {
 
 	// check secret type
 	_, err := c.GetSecretWithValidation()
@@ -108,12 +109,12 @@ func (c *KubeCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error)
 }
 
 // DeleteCluster deletes cluster from cloud, in this case no delete function
-func (c *KubeCluster) DeleteCluster() error {
+func (c *KubeCluster) DeleteCluster(ctx context.Context) error {
 	return nil
 }
 
 // UpdateCluster updates cluster in cloud, in this case no update function
-func (c *KubeCluster) UpdateCluster(*pkgCluster.UpdateClusterRequest, uint) error {
+func (c *KubeCluster) UpdateCluster(context.Context, *pkgCluster.UpdateClusterRequest, uint) error {
 	return nil
 }
 
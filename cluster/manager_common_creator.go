@@ -31,5 +31,5 @@ func (c *commonCreator) Prepare(ctx context.Context) (CommonCluster, error) {
 
 // Create implements the clusterCreator interface.
 func (c *commonCreator) Create(ctx context.Context) error {
-	return c.cluster.CreateCluster()
+	return c.cluster.CreateCluster(ctx)
 }
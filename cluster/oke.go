@@ -1,18 +1,29 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/containerengine"
+	"github.com/oracle/oci-go-sdk/core"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"k8s.io/api/core/v1"
 	"k8s.io/api/rbac/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/banzaicloud/pipeline/helm"
+	pipConfig "github.com/banzaicloud/pipeline/config"
 	"github.com/banzaicloud/pipeline/model"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
+	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
 	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
 	oracleClusterManager "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster/manager"
 	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
@@ -22,11 +33,45 @@ import (
 	"github.com/banzaicloud/pipeline/secret"
 )
 
+const clusterCreatorAdminRight = "cluster-creator-admin-right"
+
+// maxWorkerSubnetCount is the number of worker node subnets of the preconfigured VCN
+const maxWorkerSubnetCount = 3
+
+// the backoff between the polls of the Kubernetes API endpoint of a new cluster
+const (
+	apiEndpointInitialBackoff = 5 * time.Second
+	apiEndpointMaxBackoff     = 30 * time.Second
+)
+
 // OKECluster struct for OKE cluster
 type OKECluster struct {
 	modelCluster *model.ClusterModel
 	APIEndpoint  string
 	CommonClusterBase
+
+	// resource quotas applied on create
+	resourceQuotas map[string]*oracle.ResourceQuota
+	// network policies applied on create
+	networkPolicies *oracle.NetworkPolicies
+	// grace period of quiescing the cluster before delete, no quiesce if zero
+	quiesceGracePeriod time.Duration
+	// config and ssh secrets are deleted with the cluster if not used by other clusters
+	deleteSecrets bool
+	// handling of the load balancers and volumes created by the workloads on delete, ignored if empty
+	dependentResourcesPolicy string
+	// number of progress events included in the cluster details
+	detailsProgressEvents int
+	// OCI of the secret and the location of the cluster, created on first use
+	ociCache okeOCICache
+}
+
+// okeOCICache holds an oci.OCI with the secret id and region it was created with
+type okeOCICache struct {
+	sync.Mutex
+	oci      *oci.OCI
+	secretID string
+	region   string
 }
 
 // CreateOKEClusterFromModel creates ClusterModel struct from model
@@ -38,8 +83,9 @@ func CreateOKEClusterFromModel(clusterModel *model.ClusterModel) (*OKECluster, e
 	return &okeCluster, nil
 }
 
-// CreateOKEClusterFromRequest creates ClusterModel struct from the request
-func CreateOKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId, userId uint) (*OKECluster, error) {
+// CreateOKEClusterFromRequest creates ClusterModel struct from the request, the preconfigured VCN is deleted if the
+// request cannot be processed after the VCN is created
+func CreateOKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId, userId uint) (_ *OKECluster, err error) {
 	log.Debug("Create ClusterModel struct from the request")
 
 	var oke OKECluster
@@ -54,7 +100,14 @@ func CreateOKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId
 		Distribution:   pkgCluster.OKE,
 	}
 
-	VCNID, err := oke.CreatePreconfiguredVCN(request.Name)
+	VCNID, err := oke.CreatePreconfiguredVCN(request.Name, request.Properties.CreateClusterOKE.VCNCIDR, request.Properties.CreateClusterOKE.PrivateEndpoint, request.Properties.CreateClusterOKE.Tags)
+	if VCNID != "" {
+		defer func() {
+			if err != nil {
+				err = oke.rollbackPreconfiguredVCN(VCNID, err)
+			}
+		}()
+	}
 	if err != nil {
 		return &oke, err
 	}
@@ -65,41 +118,179 @@ func CreateOKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId
 	}
 	request.Properties.CreateClusterOKE = properties
 
+	imageOCIDs, err := oke.resolveNodeImages(request.Properties.CreateClusterOKE)
+	if err != nil {
+		return &oke, err
+	}
+
 	Model, err := modelOracle.CreateModelFromCreateRequest(request, userId)
 	if err != nil {
 		return &oke, err
 	}
+	setNodePoolImageOCIDs(&Model, imageOCIDs)
 
 	oke.modelCluster.OKE = Model
+	oke.resourceQuotas = request.Properties.CreateClusterOKE.ResourceQuotas
+	oke.networkPolicies = request.Properties.CreateClusterOKE.NetworkPolicies
 
 	return &oke, nil
 }
 
-// CreateCluster creates a new cluster
-func (o *OKECluster) CreateCluster() error {
+// CreateCluster creates a new cluster, cancelling the context aborts the pending OCI API calls
+func (o *OKECluster) CreateCluster(ctx context.Context) (err error) {
+
+	defer func() { o.emitEvent(EventClusterCreated, "", err, nil) }()
+
+	log := o.getOperationLogger("create")
 
 	log.Info("Start creating Oracle cluster")
 
+	defer func() {
+		if err != nil {
+			o.addProgressEvent(ProgressPhaseFailed, "cluster creation failed: %s", err.Error())
+		}
+	}()
+
+	o.addProgressEvent(ProgressPhaseNetwork, "using VCN %s", o.modelCluster.OKE.VCNID)
+
 	cm, err := o.GetClusterManager()
 	if err != nil {
 		return err
 	}
 
-	err = cm.ManageOKECluster(&o.modelCluster.OKE)
+	o.addProgressEvent(ProgressPhaseControlPlane, "provisioning control plane and %d node pool(s)", len(o.modelCluster.OKE.NodePools))
+
+	err = cm.ManageOKECluster(ctx, &o.modelCluster.OKE)
+	if ctx.Err() != nil {
+		// the cluster may have been created at OCI before the context was cancelled
+		o.lookupCreatedClusterOCID()
+		return ctx.Err()
+	}
+	if err != nil {
+		err = errors.Wrap(o.diagnoseAuthorizationError(err), "error creating cluster")
+
+		// the node pools which were created are kept in the model, so they are deleted with the cluster,
+		// the VCN is deleted right away if the cluster was not created at all
+		if o.modelCluster.OKE.OCID != "" {
+			if saveErr := o.modelCluster.Save(); saveErr != nil {
+				log.Warnf("error saving the state of the failed cluster: %s", saveErr.Error())
+			}
+		} else if o.modelCluster.OKE.VCNID != "" {
+			err = o.rollbackPreconfiguredVCN(o.modelCluster.OKE.VCNID, err)
+		}
+		return err
+	}
+
+	o.addProgressEvent(ProgressPhaseControlPlane, "control plane %s is active", o.modelCluster.OKE.OCID)
+	o.addProgressEvent(ProgressPhaseConfigure, "configuring cluster access and network policies")
+
+	err = o.waitForAPIEndpoint(ctx)
 	if err != nil {
-		return errors.Wrap(err, "error creating cluster")
+		return err
 	}
 
-	err = o.setClusterAdminRights("cluster-creator-admin-right")
+	err = o.setClusterAdminRights(clusterCreatorAdminRight)
 	if err != nil {
 		return errors.WithMessage(err, "error get/create clusterrolebinding")
 	}
 
+	err = o.applyNetworkPolicies(o.networkPolicies)
+	if err != nil {
+		return errors.WithMessage(err, "error applying network policies")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err = o.waitForNodePools(o.modelCluster.OKE.NodePools)
+	if err != nil {
+		return err
+	}
+
+	err = o.applyPriorityClasses(o.modelCluster.OKE.NodePools)
+	if err != nil {
+		return errors.WithMessage(err, "error applying priority classes")
+	}
+
+	err = o.applyResourceQuotas(o.resourceQuotas)
+	if err != nil {
+		return errors.WithMessage(err, "error applying resource quotas")
+	}
+
+	o.addProgressEvent(ProgressPhaseCompleted, "cluster is ready")
+
 	return nil
 }
 
+// lookupCreatedClusterOCID stores the OCID of the cluster in the model if its creation was interrupted after the
+// cluster was created at OCI, so the cluster can be deleted later
+func (o *OKECluster) lookupCreatedClusterOCID() {
+
+	if o.modelCluster.OKE.OCID != "" {
+		return
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		o.getLogger().Warnf("error looking up interrupted cluster: %s", err.Error())
+		return
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		o.getLogger().Warnf("error looking up interrupted cluster: %s", err.Error())
+		return
+	}
+
+	clusters, err := ce.GetClustersByName(context.Background(), o.modelCluster.OKE.Name)
+	if err != nil {
+		o.getLogger().Warnf("error looking up interrupted cluster: %s", err.Error())
+		return
+	}
+
+	clusters = ce.FilterClustersByNotInState(clusters, containerengine.ClusterSummaryLifecycleStateDeleted)
+	if len(clusters) == 1 {
+		o.modelCluster.OKE.OCID = *clusters[0].Id
+	}
+}
+
 // UpdateCluster updates the cluster
-func (o *OKECluster) UpdateCluster(r *pkgCluster.UpdateClusterRequest, userId uint) error {
+func (o *OKECluster) UpdateCluster(ctx context.Context, r *pkgCluster.UpdateClusterRequest, userId uint) (err error) {
+
+	defer func() { o.emitEvent(EventClusterUpdated, "", err, nil) }()
+
+	log := o.getOperationLogger("update")
+
+	log.Info("Start updating Oracle cluster")
+
+	// POD and service CIDRs are immutable after the cluster is created
+	if r.UpdateProperties.OKE.PodCIDR != "" && r.UpdateProperties.OKE.PodCIDR != o.modelCluster.OKE.PodCIDR {
+		return fmt.Errorf("POD CIDR cannot be changed")
+	}
+	if r.UpdateProperties.OKE.ServiceCIDR != "" && r.UpdateProperties.OKE.ServiceCIDR != o.modelCluster.OKE.ServiceCIDR {
+		return fmt.Errorf("Service CIDR cannot be changed")
+	}
+	// the load balancer subnets are set when the cluster is created
+	if r.UpdateProperties.OKE.LBSubnetCount != 0 && r.UpdateProperties.OKE.LBSubnetCount != o.modelCluster.OKE.GetLBSubnetCount() {
+		return fmt.Errorf("Load balancer subnet count cannot be changed")
+	}
+	r.UpdateProperties.OKE.LBSubnetCount = o.modelCluster.OKE.GetLBSubnetCount()
+
+	// the node pools are placed into the availability domains of the region, the tenancy may have been
+	// unsubscribed from it since the cluster was created
+	_, err = o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	// checked before the network values are added to the request
+	versionOnly := o.isVersionOnlyUpdate(r)
+
+	imageOCIDs, err := o.resolveNodeImages(r.UpdateProperties.OKE)
+	if err != nil {
+		return err
+	}
 
 	updated, err := o.PopulateNetworkValues(r.UpdateProperties.OKE, o.modelCluster.OKE.VCNID)
 	if err != nil {
@@ -111,17 +302,84 @@ func (o *OKECluster) UpdateCluster(r *pkgCluster.UpdateClusterRequest, userId ui
 	if err != nil {
 		return err
 	}
+	setNodePoolImageOCIDs(&model, imageOCIDs)
+
+	nodePoolVersions := make(map[string]string)
+	for _, np := range model.NodePools {
+		if !np.Delete {
+			nodePoolVersions[np.Name] = np.Version
+		}
+	}
+
+	err = oracle.ValidateVersionSkew(model.Version, nodePoolVersions)
+	if err != nil {
+		return err
+	}
 
 	cm, err := o.GetClusterManager()
 	if err != nil {
 		return err
 	}
 
-	err = cm.ManageOKECluster(&model)
+	// the stored model is kept until the update succeeds
+	if versionOnly {
+		log.Infof("Upgrading control plane to %s", model.Version)
+
+		err = cm.UpgradeMaster(ctx, &model)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		o.modelCluster.OKE = model
+
+		return nil
+	}
+
+	// the workloads are moved off the node pools before they are removed, the drained node pools are
+	// uncordoned if the update fails
+	drained := make([]string, 0)
+	rollback := func() {
+		for _, name := range drained {
+			if err := o.UncordonNodePool(name); err != nil {
+				log.WithField("nodePool", name).Warnf("error uncordoning node pool: %s", err.Error())
+			}
+		}
+	}
+
+	for _, np := range model.NodePools {
+		if np.Delete && np.ID != 0 {
+			log.WithField("nodePool", np.Name).Info("Draining node pool before removing it")
+			if err := o.DrainNodePool(ctx, np.Name); err != nil {
+				rollback()
+				return errors.WithMessage(err, fmt.Sprintf("error draining node pool %s", np.Name))
+			}
+			drained = append(drained, np.Name)
+		}
+	}
+
+	err = cm.ManageOKECluster(ctx, &model)
+	if ctx.Err() != nil {
+		rollback()
+		return ctx.Err()
+	}
+	if err != nil {
+		rollback()
+		return err
+	}
+
+	err = o.waitForNodePools(model.NodePools)
 	if err != nil {
 		return err
 	}
 
+	err = o.applyPriorityClasses(model.NodePools)
+	if err != nil {
+		return errors.WithMessage(err, "error applying priority classes")
+	}
+
 	// remove node pools from model which are marked for deleting
 	nodePools := make([]*modelOracle.NodePool, 0)
 	for _, np := range model.NodePools {
@@ -133,11 +391,19 @@ func (o *OKECluster) UpdateCluster(r *pkgCluster.UpdateClusterRequest, userId ui
 	model.NodePools = nodePools
 	o.modelCluster.OKE = model
 
+	log.Info("Oracle cluster updated")
+
 	return err
 }
 
 // DeleteCluster deletes cluster
-func (o *OKECluster) DeleteCluster() error {
+func (o *OKECluster) DeleteCluster(ctx context.Context) (err error) {
+
+	defer func() { o.emitEvent(EventClusterDeleted, "", err, nil) }()
+
+	log := o.getOperationLogger("delete")
+
+	log.Info("Start deleting Oracle cluster")
 
 	// mark cluster model to deleting
 	o.modelCluster.OKE.Delete = true
@@ -147,12 +413,45 @@ func (o *OKECluster) DeleteCluster() error {
 		return err
 	}
 
-	err = cm.ManageOKECluster(&o.modelCluster.OKE)
+	err = cm.ManageOKECluster(ctx, &o.modelCluster.OKE)
+	if ctx.Err() != nil {
+		o.modelCluster.OKE.Delete = false
+		return ctx.Err()
+	}
 	if err != nil {
 		return err
 	}
 
-	err = o.DeletePreconfiguredVCN(o.modelCluster.OKE.VCNID)
+	o.invalidateK8sConfig()
+
+	// the VCN is already deleted if the cluster creation was rolled back
+	if o.modelCluster.OKE.VCNID != "" {
+		log.WithField("vcn", o.modelCluster.OKE.VCNID).Info("Deleting preconfigured VCN")
+
+		err = o.DeletePreconfiguredVCN(o.modelCluster.OKE.VCNID)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("Oracle cluster deleted")
+
+	return nil
+}
+
+// PrepareDelete quiesces the cluster and deletes its dependent resources according to the delete settings, it is
+// called before the deployments of the cluster are deleted
+func (o *OKECluster) PrepareDelete(ctx context.Context) error {
+
+	if o.quiesceGracePeriod > 0 {
+		err := o.QuiesceCluster(ctx, o.quiesceGracePeriod)
+		if err != nil {
+			return errors.WithMessage(err, "error quiescing cluster")
+		}
+	}
+
+	// volumes in use are not deleted, so the cleanup comes after the workloads are evicted
+	err := o.CleanupDependentResources()
 	if err != nil {
 		return err
 	}
@@ -163,12 +462,28 @@ func (o *OKECluster) DeleteCluster() error {
 //Persist save the cluster model
 func (o *OKECluster) Persist(status, statusMessage string) error {
 
-	return o.modelCluster.UpdateStatus(status, statusMessage)
+	return o.UpdateStatus(status, statusMessage)
 }
 
-// DownloadK8sConfig downloads the kubeconfig file from cloud
+// DownloadK8sConfig downloads the kubeconfig file from cloud, the kubeconfig is cached
 func (o *OKECluster) DownloadK8sConfig() ([]byte, error) {
 
+	if config, ok := o.getCachedK8sConfig(); ok {
+		return config, nil
+	}
+
+	config, err := o.downloadK8sConfig()
+	if err != nil {
+		return nil, err
+	}
+	o.cacheK8sConfig(config)
+
+	return config, nil
+}
+
+// downloadK8sConfig downloads the kubeconfig file from cloud bypassing the cache
+func (o *OKECluster) downloadK8sConfig() ([]byte, error) {
+
 	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
 	if err != nil {
 		return nil, err
@@ -179,7 +494,7 @@ func (o *OKECluster) DownloadK8sConfig() ([]byte, error) {
 		return nil, err
 	}
 
-	return ce.GetK8SConfig(o.modelCluster.OKE.OCID)
+	return ce.GetK8SConfig(context.Background(), o.modelCluster.OKE.OCID)
 }
 
 //GetName returns the name of the cluster
@@ -200,23 +515,50 @@ func (o *OKECluster) GetDistribution() string {
 //GetStatus gets cluster status
 func (o *OKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 
+	var nodeStatus nodePoolNodeStatus
+	if o.modelCluster.Status == pkgCluster.Running {
+		nodeStatus = o.getCachedNodePoolNodeStatus()
+	}
+	readyCounts, drift, warmPools := nodeStatus.readyCounts, nodeStatus.drift, nodeStatus.warmPools
+
+	spreadWarnings := o.CheckNodePoolSubnetSpread()
+
 	nodePools := make(map[string]*pkgCluster.NodePoolStatus)
 	for _, np := range o.modelCluster.OKE.NodePools {
 		if np != nil {
-			count := getNodeCount(np)
+			minCount, maxCount := np.GetNodeCountBounds()
 			nodePools[np.Name] = &pkgCluster.NodePoolStatus{
-				Count:        count,
-				Autoscaling:  false,
-				MinCount:     count,
-				MaxCount:     count,
-				InstanceType: np.Shape,
-				Image:        np.Image,
-				Version:      np.Version,
+				// stored with the node pool, no OCI call is needed
+				CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
+				Count:             int(np.GetActiveNodeCount()),
+				Autoscaling:       np.Autoscaling,
+				MinCount:          minCount,
+				MaxCount:          maxCount,
+				InstanceType:      np.Shape,
+				Image:             np.Image,
+				ImageOCID:         np.ImageOCID,
+				Version:           np.Version,
+				ReadyCount:        readyCounts[np.Name],
+				WarmPool:          warmPools[np.Name],
+			}
+			if nodes := drift[np.Name]; len(nodes) > 0 {
+				nodePools[np.Name].Warnings = append(nodePools[np.Name].Warnings, pkgCluster.Warning{
+					Code:     pkgCluster.WarningConfigDrift,
+					Message:  fmt.Sprintf("%d node(s) run an outdated instance configuration and are pending a roll: %s", len(nodes), strings.Join(nodes, ", ")),
+					NodePool: np.Name,
+				})
+			}
+			if message, ok := spreadWarnings[np.Name]; ok {
+				nodePools[np.Name].Warnings = append(nodePools[np.Name].Warnings, pkgCluster.Warning{
+					Code:     pkgCluster.WarningUnevenSubnetSpread,
+					Message:  message,
+					NodePool: np.Name,
+				})
 			}
 		}
 	}
 
-	return &pkgCluster.GetClusterStatusResponse{
+	response := &pkgCluster.GetClusterStatusResponse{
 		Status:            o.modelCluster.Status,
 		StatusMessage:     o.modelCluster.StatusMessage,
 		Name:              o.modelCluster.Name,
@@ -227,13 +569,47 @@ func (o *OKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		ResourceID:        o.GetID(),
 		CreatorBaseFields: *NewCreatorBaseFields(o.modelCluster.CreatedAt, o.modelCluster.CreatedBy),
 		NodePools:         nodePools,
-	}, nil
+	}
+
+	if next, ok := o.GetNextStatusRefresh(); ok {
+		response.NextStatusRefresh = &next
+	}
+
+	return response, nil
 }
 
 func getNodeCount(np *modelOracle.NodePool) int {
 	return int(np.QuantityPerSubnet) * len(np.Subnets)
 }
 
+// CheckNodePoolSubnetSpread gives back a warning for every node pool which occupies
+// fewer subnets (availability domains) than recommended for its size
+func (o *OKECluster) CheckNodePoolSubnetSpread() map[string]string {
+
+	warnings := make(map[string]string)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil {
+			continue
+		}
+		recommended := getRecommendedSubnetCount(getNodeCount(np))
+		if len(np.Subnets) < recommended {
+			warnings[np.Name] = fmt.Sprintf("node pool spans %d subnet(s) instead of the recommended %d, it is not highly available; use a node count divisible by 2 or 3", len(np.Subnets), recommended)
+		}
+	}
+
+	return warnings
+}
+
+// getRecommendedSubnetCount gives back the number of subnets a node pool with the given size should span
+func getRecommendedSubnetCount(count int) int {
+
+	if count > maxWorkerSubnetCount {
+		return maxWorkerSubnetCount
+	}
+
+	return count
+}
+
 //GetID returns the specified cluster id
 func (o *OKECluster) GetID() uint {
 	return o.modelCluster.ID
@@ -255,45 +631,140 @@ func (o *OKECluster) CheckEqualityToUpdate(r *pkgCluster.UpdateClusterRequest) e
 
 	log.Info("Check stored & updated cluster equals")
 
+	if o.isVersionOnlyUpdate(r) {
+		log.Infof("Only the control plane version changed to %s, node pools are kept", r.OKE.Version)
+	}
+
 	return isDifferent(r.OKE, cluster)
 }
 
+// isVersionOnlyUpdate returns true if the update request changes only the control plane version,
+// in which case the node pools are not touched
+func (o *OKECluster) isVersionOnlyUpdate(r *pkgCluster.UpdateClusterRequest) bool {
+
+	return oracle.IsVersionOnlyChange(o.modelCluster.OKE.GetClusterRequestFromModel(), r.OKE)
+}
+
 //AddDefaultsToUpdate adds defaults to update request
 func (o *OKECluster) AddDefaultsToUpdate(r *pkgCluster.UpdateClusterRequest) {
 
 	r.UpdateProperties.OKE.AddDefaults()
+
+	// the images are resolved again by UpdateCluster, which fails on errors
+	if _, err := o.resolveNodeImages(r.UpdateProperties.OKE); err != nil {
+		o.getLogger().Warnf("error resolving node images: %s", err.Error())
+	}
 }
 
-//GetAPIEndpoint returns the Kubernetes Api endpoint
+//GetAPIEndpoint returns the Kubernetes Api endpoint,
+// the endpoint is not published for a while after the cluster is created, APIEndpointNotReadyError is returned
+// until then; the public endpoint is preferred, the private endpoint is used if there is no public one
 func (o *OKECluster) GetAPIEndpoint() (string, error) {
 
-	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
 	if err != nil {
 		return o.APIEndpoint, err
 	}
 
-	ce, err := oci.NewContainerEngineClient()
+	ce, err := OCI.NewContainerEngineClient()
 	if err != nil {
 		return o.APIEndpoint, err
 	}
 
-	cluster, err := ce.GetCluster(&o.modelCluster.OKE.OCID)
+	endpoints, state, err := ce.GetClusterEndpoints(context.Background(), o.modelCluster.OKE.OCID)
 	if err != nil {
 		return o.APIEndpoint, err
 	}
 
-	o.APIEndpoint = fmt.Sprintf("https://%s", *cluster.Endpoints.Kubernetes)
+	endpoint, private := endpoints.GetAPIEndpoint()
+	if endpoint == "" {
+		if state == string(containerengine.ClusterLifecycleStateActive) {
+			return o.APIEndpoint, &oci.APIEndpointNotAvailableError{ClusterOCID: o.modelCluster.OKE.OCID}
+		}
+		return o.APIEndpoint, &oci.APIEndpointNotReadyError{ClusterOCID: o.modelCluster.OKE.OCID}
+	}
+
+	if private != o.modelCluster.OKE.PrivateAPIEndpoint {
+		if err := o.modelCluster.OKE.SetPrivateAPIEndpoint(private); err != nil {
+			return o.APIEndpoint, errors.Wrap(err, "error saving API endpoint type")
+		}
+	}
+	o.APIEndpoint = fmt.Sprintf("https://%s", endpoint)
 
 	return o.APIEndpoint, nil
 }
 
+// waitForAPIEndpoint polls the Kubernetes API endpoint of the cluster until it is published, the endpoint may be
+// published some time after the cluster is created
+func (o *OKECluster) waitForAPIEndpoint(ctx context.Context) error {
+
+	timeout := time.Duration(viper.GetInt(pipConfig.OKEAPIEndpointTimeoutSeconds)) * time.Second
+	deadline := time.Now().Add(timeout)
+	backoff := apiEndpointInitialBackoff
+
+	for {
+		_, err := o.GetAPIEndpoint()
+		if !oci.IsAPIEndpointNotReadyError(err) {
+			return err
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return &oci.APIEndpointNotReadyError{ClusterOCID: o.modelCluster.OKE.OCID, Waited: timeout}
+		}
+
+		o.getLogger().Debugf("Kubernetes API endpoint is not ready yet, retrying in %s", backoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > apiEndpointMaxBackoff {
+			backoff = apiEndpointMaxBackoff
+		}
+	}
+}
+
 // DeleteFromDatabase deletes model from the database
 func (o *OKECluster) DeleteFromDatabase() error {
+	o.modelCluster.KeepSecrets = !o.deleteSecrets
 	err := o.modelCluster.Delete()
 	if err != nil {
 		return err
 	}
 
+	flags, err := modelOracle.GetClusterFeatureFlags(o.modelCluster.OKE.ID)
+	if err != nil {
+		return err
+	}
+
+	err = flags.Delete()
+	if err != nil {
+		return err
+	}
+
+	err = o.modelCluster.OKE.RemoveClusterTags()
+	if err != nil {
+		return err
+	}
+
+	err = modelOracle.DeleteNodePoolMetricSamples(o.modelCluster.OKE.ID)
+	if err != nil {
+		return err
+	}
+
+	err = modelOracle.DeleteDriftReports(o.modelCluster.OKE.ID)
+	if err != nil {
+		return err
+	}
+
+	err = modelOracle.DeleteProgressEvents(o.modelCluster.OKE.ID)
+	if err != nil {
+		return err
+	}
+
 	err = o.modelCluster.OKE.Cleanup()
 	if err != nil {
 		return err
@@ -308,93 +779,340 @@ func (o *OKECluster) GetOrganizationId() uint {
 	return o.modelCluster.OrganizationId
 }
 
-// GetLocation gets where the cluster is.
-func (o *OKECluster) GetLocation() string {
-	return o.modelCluster.Location
-}
-
-//GetSecretId retrieves the secret id
-func (o *OKECluster) GetSecretId() string {
-	return o.modelCluster.SecretId
-}
-
-//GetSshSecretId retrieves the ssh secret id
-func (o *OKECluster) GetSshSecretId() string {
-	return o.modelCluster.SshSecretId
-}
-
-// SaveSshSecretId saves the ssh secret id to database
-func (o *OKECluster) SaveSshSecretId(sshSecretId string) error {
-	return o.modelCluster.UpdateSshSecret(sshSecretId)
-}
-
-// UpdateStatus updates cluster status in database
-func (o *OKECluster) UpdateStatus(status, statusMessage string) error {
-	return o.modelCluster.UpdateStatus(status, statusMessage)
-}
+// TransferOwnership moves the cluster to another organization using a secret owned by that organization
+func (o *OKECluster) TransferOwnership(newOrgId uint, newSecretId string) error {
 
-// GetClusterDetails gets cluster details from cloud
-func (o *OKECluster) GetClusterDetails() (*pkgCluster.DetailsResponse, error) {
+	log := log.WithFields(logrus.Fields{"cluster": o.modelCluster.Name, "organization": newOrgId})
 
-	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	s, err := secret.Store.Get(newOrgId, newSecretId)
 	if err != nil {
-		return nil, err
+		return errors.Wrapf(err, "error getting secret %s of organization %d", newSecretId, newOrgId)
 	}
 
-	ce, err := oci.NewContainerEngineClient()
+	err = s.ValidateSecretType(pkgCluster.Oracle)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	cluster, err := ce.GetCluster(&o.modelCluster.OKE.OCID)
+	// the new secret must be able to reach the cluster
+	OCI, err := oci.NewOCI(secretOracle.CreateOCICredential(s.Values))
 	if err != nil {
-		return nil, err
+		return err
 	}
+	OCI.SetLogger(o.getLogger())
 
-	if cluster.LifecycleState != "ACTIVE" {
-		return nil, pkgErrors.ErrorClusterNotReady
+	err = OCI.ChangeRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
 	}
 
-	status, err := o.GetStatus()
+	ce, err := OCI.NewContainerEngineClient()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if _, err = ce.GetCluster(context.Background(), &o.modelCluster.OKE.OCID); err != nil {
+		return errors.Wrap(err, "cluster is not accessible with the new secret")
+	}
+
+	kubeConfig, err := ce.GetK8SConfig(context.Background(), o.modelCluster.OKE.OCID)
+	if err != nil {
+		return errors.Wrap(err, "error downloading k8s config")
+	}
+
+	log.Info("transferring cluster ownership")
+
+	oldOrgId, oldSecretId, oldConfigSecretId := o.modelCluster.OrganizationId, o.modelCluster.SecretId, o.modelCluster.ConfigSecretId
+	o.modelCluster.OrganizationId = newOrgId
+	o.modelCluster.SecretId = newSecretId
+	o.CommonClusterBase.secret = nil
+	o.CommonClusterBase.config = nil
+
+	// the kubeconfig is stored in the new organization first, the cluster is saved with the new owner only if
+	// storing it succeeded
+	err = StoreKubernetesConfig(o, kubeConfig)
+	if err != nil {
+		if err := secret.Store.DeleteByClusterUID(newOrgId, o.modelCluster.UID); err != nil {
+			log.Errorf("error deleting cluster secrets of the new organization: %s", err.Error())
+		}
+		o.modelCluster.OrganizationId = oldOrgId
+		o.modelCluster.SecretId = oldSecretId
+		o.modelCluster.ConfigSecretId = oldConfigSecretId
+		o.CommonClusterBase.secret = nil
+		o.CommonClusterBase.config = nil
+		return errors.Wrap(err, "error storing k8s config")
+	}
+
+	if err := secret.Store.DeleteByClusterUID(oldOrgId, o.modelCluster.UID); err != nil {
+		log.Errorf("error deleting cluster secrets of the previous organization: %s", err.Error())
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.RbacV1beta1().ClusterRoleBindings().Delete(clusterCreatorAdminRight, &metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting cluster role binding failed")
+	}
+
+	err = o.setClusterAdminRights(clusterCreatorAdminRight)
+	if err != nil {
+		return errors.WithMessage(err, "error get/create clusterrolebinding")
+	}
+
+	return nil
+}
+
+// GetLocation gets where the cluster is.
+func (o *OKECluster) GetLocation() string {
+	return o.modelCluster.Location
+}
+
+//GetSecretId retrieves the secret id
+func (o *OKECluster) GetSecretId() string {
+	return o.modelCluster.SecretId
+}
+
+//GetSshSecretId retrieves the ssh secret id
+func (o *OKECluster) GetSshSecretId() string {
+	return o.modelCluster.SshSecretId
+}
+
+// SaveSshSecretId saves the ssh secret id to database
+func (o *OKECluster) SaveSshSecretId(sshSecretId string) error {
+	return o.modelCluster.UpdateSshSecret(sshSecretId)
+}
+
+// UpdateStatus updates cluster status in database
+func (o *OKECluster) UpdateStatus(status, statusMessage string) error {
+
+	previous := o.modelCluster.Status
+
+	err := o.modelCluster.UpdateStatus(status, statusMessage)
+	if err == nil && status != previous {
+		o.emitEvent(EventStatusChanged, "", nil, map[string]string{
+			"previousStatus": previous,
+			"status":         status,
+			"statusMessage":  statusMessage,
+		})
+	}
+
+	return err
+}
+
+// GetClusterDetails gets cluster details from cloud
+func (o *OKECluster) GetClusterDetails() (*pkgCluster.DetailsResponse, error) {
+
+	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	ce, err := oci.NewContainerEngineClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := ce.GetCluster(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.LifecycleState != "ACTIVE" {
+		return nil, pkgErrors.ErrorClusterNotReady
+	}
+
+	status, err := o.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := modelOracle.GetClusterFeatureFlags(o.modelCluster.OKE.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := o.modelCluster.OKE.GetClusterTags()
+	if err != nil {
+		return nil, err
 	}
 
 	nodePools := make(map[string]*pkgCluster.NodeDetails)
 	for _, np := range o.modelCluster.OKE.NodePools {
 		if np != nil {
-			count := getNodeCount(np)
+			minCount, maxCount := np.GetNodeCountBounds()
 			nodePools[np.Name] = &pkgCluster.NodeDetails{
 				CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
 				Version:           np.Version,
-				Count:             count,
-				MinCount:          count,
-				MaxCount:          count,
+				Count:             int(np.GetActiveNodeCount()),
+				MinCount:          minCount,
+				MaxCount:          maxCount,
+				InstanceType:      np.Shape,
+				VolumesEncrypted:  np.VolumeKMSKeyID != "",
+				VolumeKMSKeyID:    np.VolumeKMSKeyID,
+
+				BootVolumeVPUsPerGB: np.BootVolumeVPUsPerGB,
+				UserData:            np.UserData != "",
 			}
 		}
 	}
 
-	// todo needs to add other fields
+	podCIDR, serviceCIDR := o.modelCluster.OKE.PodCIDR, o.modelCluster.OKE.ServiceCIDR
+	if cluster.Options != nil && cluster.Options.KubernetesNetworkConfig != nil {
+		if cluster.Options.KubernetesNetworkConfig.PodsCidr != nil {
+			podCIDR = *cluster.Options.KubernetesNetworkConfig.PodsCidr
+		}
+		if cluster.Options.KubernetesNetworkConfig.ServicesCidr != nil {
+			serviceCIDR = *cluster.Options.KubernetesNetworkConfig.ServicesCidr
+		}
+	}
+
+	// the cluster is active so the endpoint is already published
+	endpoint, err := o.GetAPIEndpoint()
+	if err != nil {
+		o.getLogger().Warnf("error getting Kubernetes API endpoint: %s", err.Error())
+	}
+
+	var progressEvents []pkgCluster.ProgressEvent
+	if o.detailsProgressEvents > 0 {
+		progressEvents, err = o.GetProgressEvents(o.detailsProgressEvents)
+		if err != nil {
+			o.getLogger().Warnf("error getting progress events: %s", err.Error())
+		}
+	}
+
 	return &pkgCluster.DetailsResponse{
 		CreatorBaseFields: *NewCreatorBaseFields(o.modelCluster.CreatedAt, o.modelCluster.CreatedBy),
 		Name:              status.Name,
 		Id:                status.ResourceID,
 		Location:          status.Location,
+		Region:            o.modelCluster.Location,
 		MasterVersion:     o.modelCluster.OKE.Version,
+		Endpoint:          endpoint,
 		NodePools:         nodePools,
+		VCNID:             o.modelCluster.OKE.VCNID,
 		Status:            o.modelCluster.Status,
+		PodCIDR:           podCIDR,
+		ServiceCIDR:       serviceCIDR,
+		FeatureFlags:      flags.ToMap(),
+		Tags:              tags,
+		ProgressEvents:    progressEvents,
+		Warnings:          o.GetWarnings(),
 	}, nil
 }
 
+// GetFailureReason gives back a human readable explanation of why the cluster is in FAILED state
+func (o *OKECluster) GetFailureReason() (string, error) {
+
+	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return "", err
+	}
+
+	ce, err := oci.NewContainerEngineClient()
+	if err != nil {
+		return "", err
+	}
+
+	cluster, err := ce.GetCluster(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return "", err
+	}
+
+	if cluster.LifecycleState != containerengine.ClusterLifecycleStateFailed {
+		return "", fmt.Errorf("Cluster is not in FAILED state: %s", cluster.LifecycleState)
+	}
+
+	reasons := make([]string, 0)
+	if cluster.LifecycleDetails != nil && *cluster.LifecycleDetails != "" {
+		reasons = append(reasons, *cluster.LifecycleDetails)
+	}
+
+	workRequestErrors, err := ce.GetFailedWorkRequestErrors(context.Background(), cluster.Id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range workRequestErrors {
+		switch {
+		case e.Code != nil && e.Message != nil:
+			reasons = append(reasons, fmt.Sprintf("%s: %s", *e.Code, *e.Message))
+		case e.Message != nil:
+			reasons = append(reasons, *e.Message)
+		case e.Code != nil:
+			reasons = append(reasons, *e.Code)
+		}
+	}
+
+	if len(reasons) == 0 {
+		return "unknown reason", nil
+	}
+
+	return strings.Join(reasons, "; "), nil
+}
+
 // ValidateCreationFields validates all field
 func (o *OKECluster) ValidateCreationFields(r *pkgCluster.CreateClusterRequest) error {
 
+	// the user of the secret gets the cluster admin rights after the cluster is provisioned
+	secret, err := o.GetSecretWithValidation()
+	if err != nil {
+		return err
+	}
+
+	err = secretOracle.ValidateUserOCID(secret.ID, secret.Values)
+	if err != nil {
+		return err
+	}
+
 	cm, err := o.GetClusterManager()
 	if err != nil {
 		return err
 	}
 
-	return cm.ValidateModel(&o.modelCluster.OKE)
+	err = cm.ValidateModel(&o.modelCluster.OKE)
+	if err != nil {
+		return err
+	}
+
+	err = o.validateNodePoolLabels()
+	if err != nil {
+		return err
+	}
+
+	err = o.validateIPCapacity()
+	if err != nil {
+		return err
+	}
+
+	err = o.validateShapeAvailability()
+	if err != nil {
+		return err
+	}
+
+	return o.validateCompartmentQuotas()
+}
+
+// validateNodePoolLabels checks that the user labels of the node pools do not collide with the labels pipeline uses
+// to map the nodes to their node pools
+func (o *OKECluster) validateNodePoolLabels() error {
+
+	for _, np := range o.modelCluster.OKE.NodePools {
+		labels := make(map[string]string, len(np.Labels))
+		for _, l := range np.Labels {
+			if l.Name != modelOracle.InstanceConfigHashLabelKey {
+				labels[l.Name] = l.Value
+			}
+		}
+
+		if err := oracle.ValidateNodePoolLabels(np.Name, np.PriorityClassName, labels); err != nil {
+			return fmt.Errorf("NodePool[%s]: %s", np.Name, err.Error())
+		}
+	}
+
+	return nil
 }
 
 // GetSecretWithValidation returns secret from vault
@@ -412,9 +1130,29 @@ func (o *OKECluster) GetConfigSecretId() string {
 	return o.modelCluster.ConfigSecretId
 }
 
-// GetK8sConfig returns the Kubernetes config
+// GetK8sConfig returns the Kubernetes config, the config loaded from vault is cached
 func (o *OKECluster) GetK8sConfig() ([]byte, error) {
-	return o.CommonClusterBase.getConfig(o)
+
+	if config, ok := o.getCachedK8sConfig(); ok {
+		return config, nil
+	}
+
+	// the config loaded earlier by this instance may be expired as well
+	o.CommonClusterBase.config = nil
+	config, err := o.CommonClusterBase.getConfig(o)
+	if err != nil {
+		return nil, err
+	}
+	o.cacheK8sConfig(config)
+
+	return config, nil
+}
+
+// ListHelmReleases lists the name, chart, version and status of the Helm releases installed into the cluster,
+// the releases of all namespaces are listed if the namespace is metav1.NamespaceAll
+func (o *OKECluster) ListHelmReleases(namespace string) ([]pkgHelm.ListDeploymentResponse, error) {
+
+	return o.CommonClusterBase.listHelmReleases(o, namespace)
 }
 
 // GetClusterManager creates a new oracleClusterManager.ClusterManager
@@ -425,7 +1163,10 @@ func (o *OKECluster) GetClusterManager() (manager *oracleClusterManager.ClusterM
 		return manager, err
 	}
 
-	return oracleClusterManager.NewClusterManager(oci), nil
+	manager = oracleClusterManager.NewClusterManager(oci)
+	manager.SetNodePoolConcurrency(viper.GetInt(pipConfig.OKENodePoolConcurrency))
+
+	return manager, nil
 }
 
 // GetOCI creates a new oci.OCI
@@ -441,26 +1182,78 @@ func (o *OKECluster) GetOCI() (OCI *oci.OCI, err error) {
 		return OCI, err
 	}
 
-	OCI.SetLogger(log)
+	OCI.SetLogger(o.getLogger())
+	OCI.SetRetryPolicy(getOCIRetryPolicy())
 
 	return OCI, err
 }
 
-// GetOCIWithRegion creates a new oci.OCI with the given region
+// getOCIRetryPolicy gives back the retry policy of the OCI API calls from the configuration
+func getOCIRetryPolicy() oci.RetryPolicy {
+
+	policy := oci.RetryPolicy{
+		MaxAttempts: uint(viper.GetInt(pipConfig.OKERetryMaxAttempts)),
+		Backoff:     time.Duration(viper.GetInt(pipConfig.OKERetryBackoffSeconds)) * time.Second,
+		MaxBackoff:  time.Duration(viper.GetInt(pipConfig.OKERetryMaxBackoffSeconds)) * time.Second,
+	}
+
+	for _, code := range viper.GetStringSlice(pipConfig.OKERetryStatusCodes) {
+		statusCode, err := strconv.Atoi(code)
+		if err != nil {
+			log.Warnf("invalid OCI retry status code: %s", code)
+			continue
+		}
+		policy.StatusCodes = append(policy.StatusCodes, statusCode)
+	}
+
+	return policy
+}
+
+// GetOCIWithRegion gives back an oci.OCI with the given region, it is created once and reused until the secret or
+// the region changes, so it must not be modified by the callers; oci.RegionNotSubscribedError is returned if the
+// tenancy is not subscribed to the region
 func (o *OKECluster) GetOCIWithRegion(region string) (OCI *oci.OCI, err error) {
 
+	o.ociCache.Lock()
+	defer o.ociCache.Unlock()
+
+	secretID := o.GetSecretId()
+	if o.ociCache.oci != nil && o.ociCache.secretID == secretID && o.ociCache.region == region {
+		return o.ociCache.oci, nil
+	}
+
 	OCI, err = o.GetOCI()
 	if err != nil {
 		return OCI, err
 	}
 
 	err = OCI.ChangeRegion(region)
+	if err != nil {
+		return OCI, err
+	}
 
-	return OCI, err
+	o.ociCache.oci = OCI
+	o.ociCache.secretID = secretID
+	o.ociCache.region = region
+
+	return OCI, nil
 }
 
-// CreatePreconfiguredVCN creates a preconfigured VCN with the given name
-func (o *OKECluster) CreatePreconfiguredVCN(name string) (VCNID string, err error) {
+// setCachedOCI sets the oci.OCI used by the cluster in the given region, for sharing it between clusters using the
+// same secret and region
+func (o *OKECluster) setCachedOCI(OCI *oci.OCI, region string) {
+
+	o.ociCache.Lock()
+	defer o.ociCache.Unlock()
+
+	o.ociCache.oci = OCI
+	o.ociCache.secretID = o.GetSecretId()
+	o.ociCache.region = region
+}
+
+// CreatePreconfiguredVCN creates a preconfigured VCN with the given name and CIDR block (the default if empty),
+// with an endpoint subnet if it is for a private endpoint cluster, a new VCN is tagged with the given tags
+func (o *OKECluster) CreatePreconfiguredVCN(name string, CIDR string, privateEndpoint bool, tags map[string]string) (VCNID string, err error) {
 
 	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
 	if err != nil {
@@ -468,11 +1261,30 @@ func (o *OKECluster) CreatePreconfiguredVCN(name string) (VCNID string, err erro
 	}
 
 	m := network.NewVCNManager(oci)
-	vcn, err := m.Create(fmt.Sprintf("p-%s", name))
+
+	vcnName := getPreconfiguredVCNName(name)
+	vcn, found, err := findReusableVCN(m, vcnName, privateEndpoint)
 	if err != nil {
 		return
 	}
 
+	if found && !isVCNCIDR(vcn, CIDR) {
+		return VCNID, fmt.Errorf("VCN %s created by pipeline exists with CIDR block %s, requested: %s", vcnName, *vcn.CidrBlock, CIDR)
+	}
+
+	if found {
+		o.getLogger().Infof("Reusing VCN %s created by pipeline: %s", vcnName, *vcn.Id)
+	} else {
+		vcn, err = m.Create(vcnName, CIDR, privateEndpoint, tags)
+		if err != nil {
+			// the VCN is given back if it was created, so it can be deleted
+			if vcn.Id != nil {
+				VCNID = *vcn.Id
+			}
+			return
+		}
+	}
+
 	if vcn.Id == nil {
 		return VCNID, fmt.Errorf("Invalid VCN!")
 	}
@@ -482,6 +1294,61 @@ func (o *OKECluster) CreatePreconfiguredVCN(name string) (VCNID string, err erro
 	return
 }
 
+// getPreconfiguredVCNName gives back the name of the preconfigured VCN of the cluster
+func getPreconfiguredVCNName(clusterName string) string {
+	return fmt.Sprintf("p-%s", clusterName)
+}
+
+// isVCNCIDR checks whether the VCN has the given CIDR block, the empty CIDR is the default block
+func isVCNCIDR(vcn core.Vcn, CIDR string) bool {
+
+	if CIDR == "" {
+		CIDR = network.PreconfiguredVCNCIDR
+	}
+
+	return vcn.CidrBlock != nil && *vcn.CidrBlock == CIDR
+}
+
+// findReusableVCN looks for a reusable preconfigured VCN which does not belong to another cluster
+func findReusableVCN(m *network.VCNManager, vcnName string, privateEndpoint bool) (vcn core.Vcn, found bool, err error) {
+
+	vcn, found, err = m.FindReusableVCN(vcnName, privateEndpoint)
+	if err != nil || !found {
+		return
+	}
+
+	// the VCN must not belong to another cluster
+	var count int
+	err = pipConfig.DB().Model(&modelOracle.Cluster{}).Where(modelOracle.Cluster{VCNID: *vcn.Id}).Count(&count).Error
+	if err != nil {
+		return vcn, false, err
+	}
+	if count > 0 {
+		return vcn, false, &oci.VCNNameConflictError{Name: vcnName, VCNID: *vcn.Id, PipelineOwned: true}
+	}
+
+	return vcn, true, nil
+}
+
+// rollbackPreconfiguredVCN deletes the preconfigured VCN of a cluster which failed to be created, the returned error
+// tells whether the VCN was deleted besides the error of the creation
+func (o *OKECluster) rollbackPreconfiguredVCN(VCNID string, err error) error {
+
+	log := o.getLogger().WithField("vcn", VCNID)
+	log.Info("Rolling back preconfigured VCN of the failed cluster")
+
+	if rollbackErr := o.DeletePreconfiguredVCN(VCNID); rollbackErr != nil {
+		log.Errorf("error rolling back preconfigured VCN: %s", rollbackErr.Error())
+		return errors.WithMessage(err, fmt.Sprintf("deleting VCN %s failed: %s", VCNID, rollbackErr.Error()))
+	}
+
+	if o.modelCluster.OKE.VCNID == VCNID {
+		o.modelCluster.OKE.VCNID = ""
+	}
+
+	return errors.WithMessage(err, fmt.Sprintf("VCN %s was deleted", VCNID))
+}
+
 // DeletePreconfiguredVCN deletes a preconfigured VCN by id
 func (o *OKECluster) DeletePreconfiguredVCN(VCNID string) (err error) {
 
@@ -503,20 +1370,57 @@ func (o *OKECluster) PopulateNetworkValues(r *oracle.Cluster, VCNID string) (*or
 	}
 
 	m := network.NewVCNManager(oci)
+
+	// the VCN may come from outside of pipeline, it must be in the scope of the cluster's secret
+	err = m.ValidateVCNAccess(VCNID)
+	if err != nil {
+		return r, err
+	}
+
 	networkValues, err := m.GetNetworkValues(VCNID)
 	if err != nil {
 		return r, err
 	}
 
+	return o.applyNetworkValues(r, VCNID, networkValues)
+}
+
+// applyNetworkValues sets the given network values in the request object
+func (o *OKECluster) applyNetworkValues(r *oracle.Cluster, VCNID string, networkValues network.NetworkValues) (*oracle.Cluster, error) {
+
+	if err := network.ValidateSubnetCIDRs(networkValues); err != nil {
+		return r, err
+	}
+
 	r.SetVCNID(VCNID)
-	if len(networkValues.LBSubnetIDs) != 2 {
-		return r, fmt.Errorf("Invalid network config: there must be 2 loadbalancer subnets!")
+	required := int(r.GetLBSubnetCount())
+	if len(networkValues.LBSubnetIDs) < required {
+		return r, fmt.Errorf("Invalid network config: %d loadbalancer subnets found, %d required!", len(networkValues.LBSubnetIDs), required)
 	}
 	r.SetLBSubnetID1(networkValues.LBSubnetIDs[0])
-	r.SetLBSubnetID2(networkValues.LBSubnetIDs[1])
+	r.SetLBSubnetID2("")
+	if required > 1 {
+		r.SetLBSubnetID2(networkValues.LBSubnetIDs[1])
+	}
 
-	for _, np := range r.NodePools {
-		quanityPerSubnet, subnetIDs := o.GetPoolQuantityValues(np.Count, networkValues)
+	if r.PrivateEndpoint {
+		if networkValues.EndpointSubnetID == "" {
+			return r, fmt.Errorf("Invalid network config: there is no endpoint subnet for the private endpoint!")
+		}
+		for _, subnetID := range append(networkValues.LBSubnetIDs, networkValues.WNSubnetIDs...) {
+			if subnetID == networkValues.EndpointSubnetID {
+				return r, fmt.Errorf("Invalid network config: endpoint subnet must be distinct from loadbalancer and worker subnets!")
+			}
+		}
+		r.SetEndpointSubnetID(networkValues.EndpointSubnetID)
+	}
+
+	for name, np := range r.NodePools {
+		minCount, maxCount := getProvisionedCountBounds(np.Autoscaling, np.MinCount, np.MaxCount, np.WarmPoolSize)
+		quanityPerSubnet, subnetIDs, err := o.GetPoolQuantityValues(np.Count+np.WarmPoolSize, minCount, maxCount, networkValues)
+		if err != nil {
+			return r, errors.WithMessage(err, fmt.Sprintf("Invalid node pool %s", name))
+		}
 		np.SetQuantityPerSubnet(quanityPerSubnet)
 		np.SetSubnetIDs(subnetIDs)
 	}
@@ -524,30 +1428,150 @@ func (o *OKECluster) PopulateNetworkValues(r *oracle.Cluster, VCNID string) (*or
 	return r, nil
 }
 
-// GetPoolQuantityValues calculates quantityPerSubnet and SubnetIDS for the given instance count
-func (o *OKECluster) GetPoolQuantityValues(count uint, networkValues network.NetworkValues) (qps uint, subnetIDS []string) {
+// ErrZeroNodeCount is returned by GetPoolQuantityValues when there are no nodes to place into the subnets
+var ErrZeroNodeCount = errors.New("node count is zero")
 
-	if count == 0 || len(networkValues.WNSubnetIDs) < 3 {
-		return
+// GetPoolQuantityValues calculates quantityPerSubnet and SubnetIDS for the given instance count spread across the
+// worker subnets by oracle.DistributeNodeCount, the count is kept between minCount and maxCount (no bound if zero),
+// it fails with ErrZeroNodeCount if the count is zero and with oci.NotEnoughWorkerSubnetsError if the VCN has less
+// than 3 worker subnets
+func (o *OKECluster) GetPoolQuantityValues(count, minCount, maxCount uint, networkValues network.NetworkValues) (qps uint, subnetIDS []string, err error) {
+
+	if maxCount > 0 && count > maxCount {
+		count = maxCount
+	}
+	if count < minCount {
+		count = minCount
+	}
+
+	if count == 0 {
+		return 0, nil, ErrZeroNodeCount
+	}
+
+	if len(networkValues.WNSubnetIDs) < 3 {
+		return 0, nil, &oci.NotEnoughWorkerSubnetsError{Required: 3, Available: len(networkValues.WNSubnetIDs)}
+	}
+
+	distribution := oracle.DistributeNodeCount(count, len(networkValues.WNSubnetIDs))
+
+	return distribution.QuantityPerSubnet, networkValues.WNSubnetIDs[0:distribution.SubnetCount], nil
+}
+
+// getProvisionedCountBounds gives back the bounds of the provisioned nodes of a node pool including its warm pool,
+// the nodes are not bounded if autoscaling is disabled
+func getProvisionedCountBounds(autoscaling bool, minCount, maxCount int, warmPoolSize uint) (uint, uint) {
+
+	if !autoscaling {
+		return 0, 0
+	}
+
+	return uint(minCount) + warmPoolSize, uint(maxCount) + warmPoolSize
+}
+
+// ListNodePoolImages gives back the available node images for the given k8s version in the cluster's region
+func (o *OKECluster) ListNodePoolImages(k8sVersion string) ([]oci.NodeImage, error) {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	return OCI.ListNodePoolImages(k8sVersion)
+}
+
+// resolveNodeImages replaces friendly image identifiers (OCID or '<os> <version>') with OKE image names, new node
+// pools without an image get the default image of their k8s version in the region of the cluster.
+// It gives back the OCIDs of the resolved images by node pool name.
+func (o *OKECluster) resolveNodeImages(r *oracle.Cluster) (map[string]string, error) {
+
+	imageOCIDs := make(map[string]string)
+	if r == nil || len(r.NodePools) == 0 {
+		return imageOCIDs, nil
+	}
+
+	cm, err := o.GetClusterManager()
+	if err != nil {
+		return nil, err
+	}
+
+	for name, np := range r.NodePools {
+		// the image of existing node pools can't be changed
+		if o.modelCluster.OKE.GetNodePoolByName(name).ID != 0 {
+			continue
+		}
+
+		version := np.Version
+		if version == "" {
+			version = r.Version
+		}
+
+		image, err := cm.ResolveNodeImage(version, np.Image)
+		if err != nil {
+			return nil, errors.Wrapf(err, "NodePool[%s]", name)
+		}
+		np.Image = image.Name
+		imageOCIDs[name] = image.OCID
+	}
+
+	return imageOCIDs, nil
+}
+
+// setNodePoolImageOCIDs stores the OCIDs of the resolved images in the new node pools of the model
+func setNodePoolImageOCIDs(model *modelOracle.Cluster, imageOCIDs map[string]string) {
+
+	for _, np := range model.NodePools {
+		if OCID, ok := imageOCIDs[np.Name]; ok && np.ID == 0 {
+			np.ImageOCID = OCID
+		}
 	}
+}
+
+// UpdateFeatureFlags updates the per-cluster feature flags
+func (o *OKECluster) UpdateFeatureFlags(features map[string]bool) error {
 
-	qps = count
-	subnetIDS = networkValues.WNSubnetIDs[0:1]
-	if count%3 == 0 {
-		qps = count / 3
-		subnetIDS = networkValues.WNSubnetIDs[0:3]
-	} else if count%2 == 0 {
-		qps = count / 2
-		subnetIDS = networkValues.WNSubnetIDs[0:2]
+	flags, err := modelOracle.GetClusterFeatureFlags(o.modelCluster.OKE.ID)
+	if err != nil {
+		return err
 	}
 
-	return qps, subnetIDS
+	for name, enabled := range features {
+		if err := flags.Set(name, enabled); err != nil {
+			return err
+		}
+	}
+
+	return flags.Save()
 }
 
-// ListNodeNames returns node names to label them
+// ListNodeNames returns node names to label them, the nodes are grouped by the node pool label set in the
+// create request
 func (o *OKECluster) ListNodeNames() (nodeNames pkgCommon.NodeNames, err error) {
-	// nodes are labeled in create request
-	return
+
+	if o.modelCluster.ConfigSecretId == "" {
+		return nil, errors.Errorf("kubeconfig of cluster %s is not available yet", o.modelCluster.Name)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing nodes")
+	}
+
+	nodeNames = make(pkgCommon.NodeNames)
+	for _, node := range nodes.Items {
+		nodePoolName, ok := node.Labels[pkgCommon.LabelKey]
+		if !ok {
+			o.getLogger().WithField("node", node.Name).Debug("node has no node pool label")
+			continue
+		}
+		nodeNames[nodePoolName] = append(nodeNames[nodePoolName], node.Name)
+	}
+
+	return nodeNames, nil
 }
 
 // RbacEnabled returns true if rbac enabled on the cluster
@@ -555,18 +1579,38 @@ func (o *OKECluster) RbacEnabled() bool {
 	return true
 }
 
-// setClusterAdminRights creates a cluster role binding which gives admin
-// rights to the user ocid specified in the secret used to create the cluster
-func (o *OKECluster) setClusterAdminRights(name string) error {
+// ensureClusterAdminRights recreates the cluster admin ClusterRoleBinding if it was removed
+func (o *OKECluster) ensureClusterAdminRights(name string) error {
 
-	kubeConfig, err := o.GetK8sConfig()
+	client, err := o.getK8sClient()
 	if err != nil {
-		return errors.Wrap(err, "error getting k8s config")
+		return err
 	}
 
-	client, err := helm.GetK8sConnection(kubeConfig)
+	_, err = client.RbacV1beta1().ClusterRoleBindings().Get(name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting cluster role binding")
+	}
+
+	log.WithField("name", name).Info("cluster role binding is missing, recreating")
+
+	return o.setClusterAdminRights(name)
+}
+
+// setClusterAdminRights creates a cluster role binding which gives admin
+// rights to the user ocid specified in the secret used to create the cluster,
+// the subject can be overridden by the admin_subject_kind and admin_subject_name
+// values of the secret, an existing binding is updated
+func (o *OKECluster) setClusterAdminRights(name string) error {
+
+	log := o.getLogger()
+
+	client, err := o.getK8sClient()
 	if err != nil {
-		return errors.Wrap(err, "error getting k8s client")
+		return err
 	}
 
 	secret, err := o.GetSecretWithValidation()
@@ -574,29 +1618,51 @@ func (o *OKECluster) setClusterAdminRights(name string) error {
 		return errors.Wrap(err, "error getting secret")
 	}
 
-	if secret.Values[secretOracle.UserOCID] == "" {
-		return errors.New("empty user OCID")
-	}
-
-	_, err = client.RbacV1beta1().ClusterRoleBindings().Create(
-		&v1beta1.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			},
-			Subjects: []v1beta1.Subject{
-				{
-					Kind:     "User",
-					Name:     secret.Values["user_ocid"],
-					APIGroup: v1.GroupName,
-				},
-			},
-			RoleRef: v1beta1.RoleRef{
-				Kind:     "ClusterRole",
-				Name:     "cluster-admin",
-				APIGroup: v1beta1.GroupName,
-			},
-		})
+	subject, err := getClusterAdminSubject(secret.ID, secret.Values)
+	if err != nil {
+		return err
+	}
+
+	binding := &v1beta1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: rbacManagedLabels,
+		},
+		Subjects: []v1beta1.Subject{subject},
+		RoleRef: v1beta1.RoleRef{
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+			APIGroup: v1beta1.GroupName,
+		},
+	}
 
+	_, err = client.RbacV1beta1().ClusterRoleBindings().Create(binding)
+	if k8sErrors.IsAlreadyExists(err) {
+		existing, err := client.RbacV1beta1().ClusterRoleBindings().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "error getting cluster role binding")
+		}
+
+		if existing.RoleRef != binding.RoleRef {
+			// the role of a binding cannot be changed
+			return errors.Errorf("cluster role binding %s exists with role %s", name, existing.RoleRef.Name)
+		}
+
+		existing.Subjects = binding.Subjects
+		if existing.Labels == nil {
+			existing.Labels = make(map[string]string)
+		}
+		for k, v := range rbacManagedLabels {
+			existing.Labels[k] = v
+		}
+
+		if _, err := client.RbacV1beta1().ClusterRoleBindings().Update(existing); err != nil {
+			return errors.Wrap(err, "updating cluster role binding failed")
+		}
+
+		log.WithField("name", name).Info("cluster role binding updated")
+		return nil
+	}
 	if err != nil {
 		return errors.Wrap(err, "creating cluster role binding failed")
 	}
@@ -605,3 +1671,31 @@ func (o *OKECluster) setClusterAdminRights(name string) error {
 
 	return nil
 }
+
+// getClusterAdminSubject gives back the subject of the cluster admin binding, it is the user of the secret
+// unless the secret overrides it
+func getClusterAdminSubject(secretID string, values map[string]string) (v1beta1.Subject, error) {
+
+	kind := values[secretOracle.AdminSubjectKind]
+	name := values[secretOracle.AdminSubjectName]
+
+	if kind == "" && name == "" {
+		if err := secretOracle.ValidateUserOCID(secretID, values); err != nil {
+			return v1beta1.Subject{}, err
+		}
+		kind, name = v1beta1.UserKind, values[secretOracle.UserOCID]
+	}
+
+	if kind != v1beta1.UserKind && kind != v1beta1.GroupKind {
+		return v1beta1.Subject{}, errors.Errorf("invalid admin subject kind: %s", kind)
+	}
+	if name == "" {
+		return v1beta1.Subject{}, errors.New("empty admin subject name")
+	}
+
+	return v1beta1.Subject{
+		Kind:     kind,
+		Name:     name,
+		APIGroup: v1.GroupName,
+	}, nil
+}
@@ -0,0 +1,229 @@
+package cluster
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// ReconcileNodePools imports the node pools of the cluster which were created at OCI outside of pipeline into the
+// model, gives back the names of the imported node pools
+func (o *OKECluster) ReconcileNodePools() ([]string, error) {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nodePools, err := ce.GetNodePools(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return nil, err
+	}
+
+	imported := make([]string, 0)
+	for _, np := range nodePools {
+		if np.Name == nil || o.modelCluster.OKE.GetNodePoolByName(*np.Name).Name != "" {
+			continue
+		}
+
+		nodePool := &modelOracle.NodePool{
+			Name:      *np.Name,
+			OCID:      *np.Id,
+			ClusterID: o.modelCluster.OKE.ID,
+			Subnets:   make([]*modelOracle.NodePoolSubnet, 0, len(np.SubnetIds)),
+			Labels:    make([]*modelOracle.NodePoolLabel, 0, len(np.InitialNodeLabels)),
+		}
+		if np.KubernetesVersion != nil {
+			nodePool.Version = *np.KubernetesVersion
+		}
+		if np.NodeShape != nil {
+			nodePool.Shape = *np.NodeShape
+		}
+		if np.NodeImageName != nil {
+			nodePool.Image = *np.NodeImageName
+		}
+		if np.QuantityPerSubnet != nil {
+			nodePool.QuantityPerSubnet = uint(*np.QuantityPerSubnet)
+		}
+		for _, subnetID := range np.SubnetIds {
+			nodePool.Subnets = append(nodePool.Subnets, &modelOracle.NodePoolSubnet{SubnetID: subnetID})
+		}
+		for _, label := range np.InitialNodeLabels {
+			if label.Key != nil && label.Value != nil && *label.Key != modelOracle.InstanceConfigHashLabelKey {
+				nodePool.Labels = append(nodePool.Labels, &modelOracle.NodePoolLabel{Name: *label.Key, Value: *label.Value})
+			}
+		}
+
+		// the nodes of the imported node pool are considered to run its current instance configuration
+		nodePool.InstanceConfigHash = nodePool.GetInstanceConfigHash()
+		nodePool.Labels = append(nodePool.Labels, &modelOracle.NodePoolLabel{
+			Name:  modelOracle.InstanceConfigHashLabelKey,
+			Value: nodePool.InstanceConfigHash,
+		})
+
+		o.modelCluster.OKE.NodePools = append(o.modelCluster.OKE.NodePools, nodePool)
+		imported = append(imported, nodePool.Name)
+	}
+
+	if len(imported) > 0 {
+		if err := o.modelCluster.Save(); err != nil {
+			return nil, errors.Wrap(err, "error saving imported node pools")
+		}
+		o.getLogger().Infof("node pools imported: %v", imported)
+	}
+
+	return imported, nil
+}
+
+// AdoptCluster brings a cluster which is only partially managed by pipeline under its control: the unmanaged node
+// pools are imported, the kubeconfig is stored, the admin binding is verified and the nodes and instances of the
+// imported node pools are labelled and tagged like the ones created by pipeline
+func (o *OKECluster) AdoptCluster() error {
+
+	if o.modelCluster.OKE.OCID == "" {
+		return errors.Errorf("cluster %s has no OKE cluster", o.modelCluster.Name)
+	}
+
+	imported, err := o.ReconcileNodePools()
+	if err != nil {
+		return errors.WithMessage(err, "error importing node pools")
+	}
+
+	kubeConfig, err := o.DownloadK8sConfig()
+	if err != nil {
+		return errors.Wrap(err, "error downloading k8s config")
+	}
+
+	err = StoreKubernetesConfig(o, kubeConfig)
+	if err != nil {
+		return errors.Wrap(err, "error storing k8s config")
+	}
+	o.CommonClusterBase.config = nil
+
+	err = o.ensureClusterAdminRights(clusterCreatorAdminRight)
+	if err != nil {
+		return errors.WithMessage(err, "error verifying cluster admin rights")
+	}
+
+	err = o.labelImportedNodes(imported)
+	if err != nil {
+		return errors.WithMessage(err, "error labelling nodes of the imported node pools")
+	}
+
+	cm, err := o.GetClusterManager()
+	if err != nil {
+		return err
+	}
+
+	err = cm.SyncNodeTags(context.Background(), &o.modelCluster.OKE)
+	if err != nil {
+		return errors.WithMessage(err, "error tagging node instances")
+	}
+
+	o.getLogger().Info("cluster adopted")
+
+	return nil
+}
+
+// labelImportedNodes puts the node pool and instance configuration hash labels on the nodes of the imported node
+// pools, the nodes are matched to the OCI nodes by their instance OCIDs or their public or private addresses
+func (o *OKECluster) labelImportedNodes(nodePoolNames []string) error {
+
+	if len(nodePoolNames) == 0 {
+		return nil
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		return err
+	}
+
+	compute, err := OCI.NewComputeClient()
+	if err != nil {
+		return err
+	}
+
+	// node pool name by instance OCID and by node IP address
+	nodePoolsByID := make(map[string]string)
+	nodePoolsByIP := make(map[string]string)
+	for _, name := range nodePoolNames {
+		np := o.modelCluster.OKE.GetNodePoolByName(name)
+		nodePool, err := ce.GetNodePool(context.Background(), &np.OCID)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodePool.Nodes {
+			if node.PublicIp != nil {
+				nodePoolsByIP[*node.PublicIp] = name
+			}
+			if node.Id == nil {
+				continue
+			}
+			nodePoolsByID[*node.Id] = name
+			privateIPs, err := compute.GetInstancePrivateIPs(context.Background(), *node.Id)
+			if err != nil {
+				return errors.WithMessage(err, "error getting private IPs of instance "+*node.Id)
+			}
+			for _, ip := range privateIPs {
+				nodePoolsByIP[ip] = name
+			}
+		}
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error listing nodes")
+	}
+
+	nodePools := make(map[string]string)
+	nodeNames := make([]string, 0)
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[pkgCommon.LabelKey]; ok {
+			continue
+		}
+		if name, ok := nodePoolsByID[strings.TrimPrefix(node.Spec.ProviderID, ociProviderIDPrefix)]; ok {
+			nodePools[node.Name] = name
+			nodeNames = append(nodeNames, node.Name)
+			continue
+		}
+		for _, address := range node.Status.Addresses {
+			if address.Type != v1.NodeExternalIP && address.Type != v1.NodeInternalIP {
+				continue
+			}
+			if name, ok := nodePoolsByIP[address.Address]; ok {
+				nodePools[node.Name] = name
+				nodeNames = append(nodeNames, node.Name)
+				break
+			}
+		}
+	}
+
+	return patchNodes(nodeNames, getNodePatchConcurrency(), func(nodeName string) error {
+		name := nodePools[nodeName]
+		return addLabelsToNode(client, nodeName, map[string]string{
+			pkgCommon.LabelKey:                     name,
+			modelOracle.InstanceConfigHashLabelKey: o.modelCluster.OKE.GetNodePoolByName(name).InstanceConfigHash,
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// GetOCIAuditEvents returns the OCI Audit events between from and to, related to the cluster,
+// its node pools and its VCN, including the changes made directly in OCI
+func (o *OKECluster) GetOCIAuditEvents(from, to time.Time) ([]oci.OCIAuditEvent, error) {
+
+	if !from.Before(to) {
+		return nil, errors.New("from must be before to")
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := OCI.NewAuditClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating audit client")
+	}
+
+	resourceIDs := []string{o.modelCluster.OKE.OCID, o.modelCluster.OKE.VCNID}
+	for _, np := range o.modelCluster.OKE.NodePools {
+		resourceIDs = append(resourceIDs, np.OCID)
+	}
+
+	events, err := a.GetEventsByResourceIDs(from, to, resourceIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing audit events")
+	}
+
+	return events, nil
+}
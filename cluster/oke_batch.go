@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DeletionProtectionTagKey is the cluster tag key which protects a cluster from batch deletion when set to true
+const DeletionProtectionTagKey = "deletion-protection"
+
+const defaultDeleteParallelism = 4
+
+// DeleteClustersOptions describes the options of deleting clusters in batch
+type DeleteClustersOptions struct {
+	// number of clusters deleted at the same time
+	Parallelism int
+	// grace period of quiescing the clusters which have no own quiesce setting
+	QuiesceGracePeriod time.Duration
+	// deletes the clusters with deletion protection as well
+	IgnoreDeletionProtection bool
+}
+
+// IsDeletionProtected returns true if the cluster is tagged with deletion protection
+func (o *OKECluster) IsDeletionProtected() (bool, error) {
+
+	tags, err := o.modelCluster.OKE.GetClusterTags()
+	if err != nil {
+		return false, err
+	}
+
+	return tags[DeletionProtectionTagKey] == "true", nil
+}
+
+// DeleteClusters deletes the given clusters concurrently with deleteCluster, the delete path of a single cluster,
+// and gives back the result per cluster ID. Clusters using the same secret in the same region share the credentials
+// and region config of an OCI client, at most opts.Parallelism clusters are deleted at the same time.
+func DeleteClusters(clusters []*OKECluster, opts DeleteClustersOptions, deleteCluster func(CommonCluster) error) map[uint]error {
+
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultDeleteParallelism
+	}
+
+	groups := make(map[string][]*OKECluster)
+	for _, o := range clusters {
+		key := o.GetSecretId() + "/" + o.GetLocation()
+		groups[key] = append(groups[key], o)
+	}
+
+	results := make(map[uint]error)
+
+	// the clusters of a group use the same secret and region, so they can share the OCI client config,
+	// every cluster gets a copy of it logging with its own logger
+	deletable := make([]*OKECluster, 0, len(clusters))
+	for _, group := range groups {
+		OCI, err := group[0].GetOCIWithRegion(group[0].GetLocation())
+		if err != nil {
+			for _, o := range group {
+				results[o.GetID()] = errors.WithMessage(err, "error creating OCI client")
+			}
+			continue
+		}
+
+		for _, o := range group {
+			o.setCachedOCI(OCI.WithLogger(o.getLogger()), o.GetLocation())
+			deletable = append(deletable, o)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for _, o := range deletable {
+		wg.Add(1)
+		go func(o *OKECluster) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := deleteClusterInBatch(o, opts, deleteCluster)
+
+			mu.Lock()
+			results[o.GetID()] = err
+			mu.Unlock()
+		}(o)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// deleteClusterInBatch checks that a cluster of a batch deletion can be deleted, then deletes it with deleteCluster
+func deleteClusterInBatch(o *OKECluster, opts DeleteClustersOptions, deleteCluster func(CommonCluster) error) error {
+
+	if !opts.IgnoreDeletionProtection {
+		protected, err := o.IsDeletionProtected()
+		if err != nil {
+			return err
+		}
+		if protected {
+			return errors.New("cluster is protected from deletion")
+		}
+	}
+
+	if o.quiesceGracePeriod == 0 {
+		o.SetQuiesceOnDelete(opts.QuiesceGracePeriod)
+	}
+
+	err := o.CheckDependentResources()
+	if err != nil {
+		return err
+	}
+
+	return deleteCluster(o)
+}
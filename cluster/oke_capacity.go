@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+)
+
+// OCI reserves the first two and the last IP address of every subnet
+const subnetReservedIPCount = 3
+
+// OKE assigns a /24 POD subnet to every node from the POD CIDR
+const (
+	podSubnetPrefixPerNode = 24
+	defaultPodCIDR         = "10.244.0.0/16"
+)
+
+// subnetIPUsage describes the IP address consumption of a worker node subnet
+type subnetIPUsage struct {
+	Name               string
+	CIDR               string
+	AvailabilityDomain string
+	Required           int
+	Available          int
+}
+
+// getSubnetIPUsage gives back the estimated IP address consumption of the worker node subnets of the node pools
+func (o *OKECluster) getSubnetIPUsage() (map[string]*subnetIPUsage, error) {
+
+	usage := make(map[string]*subnetIPUsage)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil || np.Delete {
+			continue
+		}
+		for _, subnet := range np.Subnets {
+			if usage[subnet.SubnetID] == nil {
+				usage[subnet.SubnetID] = &subnetIPUsage{}
+			}
+			usage[subnet.SubnetID].Required += int(np.QuantityPerSubnet)
+		}
+	}
+
+	if len(usage) == 0 {
+		return usage, nil
+	}
+
+	oci, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return usage, err
+	}
+
+	vn, err := oci.NewVirtualNetworkClient()
+	if err != nil {
+		return usage, err
+	}
+
+	for subnetID, u := range usage {
+		subnet, err := vn.GetSubnet(&subnetID)
+		if err != nil {
+			return usage, err
+		}
+
+		available, err := getAvailableIPCount(*subnet.CidrBlock)
+		if err != nil {
+			return usage, err
+		}
+
+		u.Name = *subnet.DisplayName
+		u.CIDR = *subnet.CidrBlock
+		u.AvailabilityDomain = *subnet.AvailabilityDomain
+		u.Available = available - subnetReservedIPCount
+	}
+
+	return usage, nil
+}
+
+// validateIPCapacity checks that the worker node subnets and the POD CIDR can accommodate
+// the requested node pools
+func (o *OKECluster) validateIPCapacity() error {
+
+	usage, err := o.getSubnetIPUsage()
+	if err != nil {
+		return err
+	}
+
+	return o.checkIPCapacity(usage)
+}
+
+// checkIPCapacity checks the given subnet IP usage and the POD CIDR of the cluster
+func (o *OKECluster) checkIPCapacity(usage map[string]*subnetIPUsage) error {
+
+	nodeCount := 0
+	for _, u := range usage {
+		if u.Required > u.Available {
+			return fmt.Errorf("subnet %s (%s) cannot accommodate the requested nodes: %d addresses required, %d available", u.Name, u.CIDR, u.Required, u.Available)
+		}
+		nodeCount += u.Required
+	}
+
+	podCIDR, maxNodes, err := o.getPodCIDRMaxNodes()
+	if err != nil {
+		return err
+	}
+
+	if nodeCount > maxNodes {
+		return fmt.Errorf("POD CIDR %s cannot accommodate the requested nodes: %d POD subnets required, %d available", podCIDR, nodeCount, maxNodes)
+	}
+
+	return nil
+}
+
+// getPodCIDRMaxNodes gives back the POD CIDR of the cluster and the number of nodes it can accommodate
+func (o *OKECluster) getPodCIDRMaxNodes() (podCIDR string, maxNodes int, err error) {
+
+	podCIDR = o.modelCluster.OKE.PodCIDR
+	if podCIDR == "" {
+		podCIDR = defaultPodCIDR
+	}
+
+	ipNet, err := network.ParseIPv4CIDR(podCIDR)
+	if err != nil {
+		return podCIDR, 0, err
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	if ones > podSubnetPrefixPerNode {
+		return podCIDR, 0, fmt.Errorf("POD CIDR %s is too small, at least a /%d is required", podCIDR, podSubnetPrefixPerNode)
+	}
+
+	return podCIDR, 1 << uint(podSubnetPrefixPerNode-ones), nil
+}
+
+// getAvailableIPCount gives back the number of IP addresses in the given CIDR block
+func getAvailableIPCount(CIDR string) (int, error) {
+
+	ipNet, err := network.ParseIPv4CIDR(CIDR)
+	if err != nil {
+		return 0, err
+	}
+
+	ones, bits := ipNet.Mask.Size()
+
+	return 1 << uint(bits-ones), nil
+}
+
+// GetNodePoolMaxPods gives back the max pods per node of the given node pool, it is the pod
+// allocatable of the pool's nodes (which reflects kubelet overrides) if they are running,
+// otherwise it is derived from the CNI of the cluster
+func (o *OKECluster) GetNodePoolMaxPods(name string) (int, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return 0, errors.Errorf("node pool not found: %s", name)
+	}
+
+	if o.modelCluster.Status == pkgCluster.Running {
+		client, err := o.getK8sClient()
+		if err != nil {
+			return 0, err
+		}
+
+		nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+			LabelSelector: pkgCommon.LabelKey + "=" + name,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "error listing nodes")
+		}
+
+		maxPods := 0
+		for _, node := range nodes.Items {
+			pods, ok := node.Status.Allocatable[v1.ResourcePods]
+			if !ok {
+				continue
+			}
+			if maxPods == 0 || int(pods.Value()) < maxPods {
+				maxPods = int(pods.Value())
+			}
+		}
+
+		if maxPods > 0 {
+			return maxPods, nil
+		}
+	}
+
+	// OKE clusters use flannel, where max pods is the kubelet default independently of the shape
+	return oracle.FlannelMaxPodsPerNode, nil
+}
+
+// GetClusterCapacity gives back the total schedulable pods of the cluster
+func (o *OKECluster) GetClusterCapacity() (*pkgCluster.ClusterCapacity, error) {
+
+	capacity := &pkgCluster.ClusterCapacity{
+		NodePools: make(map[string]*pkgCluster.NodePoolCapacity),
+	}
+
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil {
+			continue
+		}
+
+		maxPodsPerNode, err := o.GetNodePoolMaxPods(np.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes := getNodeCount(np)
+		capacity.NodePools[np.Name] = &pkgCluster.NodePoolCapacity{
+			Nodes:          nodes,
+			MaxPodsPerNode: maxPodsPerNode,
+			MaxPods:        nodes * maxPodsPerNode,
+		}
+		capacity.MaxPods += nodes * maxPodsPerNode
+	}
+
+	return capacity, nil
+}
@@ -0,0 +1,193 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// Reasons of the node pool conditions
+const (
+	conditionReasonCheckFailed       = "CheckFailed"
+	conditionReasonIPUsageHigh       = "IPUsageHigh"
+	conditionReasonTooFewSubnets     = "TooFewSubnets"
+	conditionReasonNearSkewLimit     = "NearSkewLimit"
+	conditionReasonSkewViolated      = "SkewViolated"
+	conditionReasonNewerImage        = "NewerImageAvailable"
+	conditionReasonNodesNotReady     = "NodesNotReady"
+	conditionReasonAsExpected        = "AsExpected"
+	conditionReasonNodeCountMismatch = "ReadyCountBelowDesired"
+)
+
+// GetNodePoolConditions gives back the conditions of the node pool aggregated from the configuration warnings, the
+// subnet capacity, the node images and the health of its nodes. A condition is Unknown if its check failed.
+func (o *OKECluster) GetNodePoolConditions(name string) ([]pkgCluster.Condition, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return nil, errors.Errorf("node pool not found: %s", name)
+	}
+
+	return []pkgCluster.Condition{
+		o.getSubnetCapacityCondition(np),
+		o.getSubnetSpreadCondition(np),
+		o.getVersionSkewCondition(np),
+		o.getImageOutdatedCondition(np),
+		o.getUnhealthyCondition(np),
+	}, nil
+}
+
+func (o *OKECluster) getSubnetCapacityCondition(np *modelOracle.NodePool) pkgCluster.Condition {
+
+	usage, err := o.getSubnetIPUsage()
+	if err != nil {
+		return newUnknownCondition(pkgCluster.ConditionSubnetCapacityLow, err)
+	}
+
+	low := make([]string, 0)
+	for _, subnet := range np.Subnets {
+		u := usage[subnet.SubnetID]
+		if u != nil && u.Available > 0 && float64(u.Required)/float64(u.Available) >= subnetIPUsageWarningRatio {
+			low = append(low, fmt.Sprintf("%s (%s, %d of %d IP addresses used)", u.Name, u.CIDR, u.Required, u.Available))
+		}
+	}
+
+	if len(low) > 0 {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionSubnetCapacityLow,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonIPUsageHigh,
+			Message: "subnets are close to running out of IP addresses: " + strings.Join(low, ", "),
+		}
+	}
+
+	return newFalseCondition(pkgCluster.ConditionSubnetCapacityLow)
+}
+
+func (o *OKECluster) getSubnetSpreadCondition(np *modelOracle.NodePool) pkgCluster.Condition {
+
+	if message, ok := o.CheckNodePoolSubnetSpread()[np.Name]; ok {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionUnevenSubnetSpread,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonTooFewSubnets,
+			Message: message,
+		}
+	}
+
+	return newFalseCondition(pkgCluster.ConditionUnevenSubnetSpread)
+}
+
+func (o *OKECluster) getVersionSkewCondition(np *modelOracle.NodePool) pkgCluster.Condition {
+
+	masterVersion := o.modelCluster.OKE.Version
+
+	err := oracle.ValidateVersionSkew(masterVersion, map[string]string{np.Name: np.Version})
+	if _, ok := err.(*oracle.VersionSkewError); ok {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionVersionSkew,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonSkewViolated,
+			Message: err.Error(),
+		}
+	}
+	if err != nil {
+		return newUnknownCondition(pkgCluster.ConditionVersionSkew, err)
+	}
+
+	if oracle.IsVersionSkewNearLimit(masterVersion, np.Version) {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionVersionSkew,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonNearSkewLimit,
+			Message: fmt.Sprintf("node pool version %s will become unsupported on the next control plane upgrade", np.Version),
+		}
+	}
+
+	return newFalseCondition(pkgCluster.ConditionVersionSkew)
+}
+
+func (o *OKECluster) getImageOutdatedCondition(np *modelOracle.NodePool) pkgCluster.Condition {
+
+	updates, err := o.CheckNodeImageUpdates()
+	if err != nil {
+		return newUnknownCondition(pkgCluster.ConditionImageOutdated, err)
+	}
+
+	for _, update := range updates {
+		if update.NodePool != np.Name {
+			continue
+		}
+
+		message := fmt.Sprintf("image %s can be updated to %s", update.CurrentImage, update.TargetImage)
+		if len(update.OutdatedNodes) > 0 {
+			message = fmt.Sprintf("%s, %d node(s) run an outdated image", message, len(update.OutdatedNodes))
+		}
+
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionImageOutdated,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonNewerImage,
+			Message: message,
+		}
+	}
+
+	return newFalseCondition(pkgCluster.ConditionImageOutdated)
+}
+
+func (o *OKECluster) getUnhealthyCondition(np *modelOracle.NodePool) pkgCluster.Condition {
+
+	unhealthy, err := o.ListUnhealthyNodes(np.Name)
+	if err != nil {
+		return newUnknownCondition(pkgCluster.ConditionUnhealthy, err)
+	}
+
+	if len(unhealthy) > 0 {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionUnhealthy,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonNodesNotReady,
+			Message: fmt.Sprintf("nodes are NotReady after the health check grace period: %s", strings.Join(unhealthy, ", ")),
+		}
+	}
+
+	readyCounts, err := o.getNodePoolReadyCounts()
+	if err != nil {
+		return newUnknownCondition(pkgCluster.ConditionUnhealthy, err)
+	}
+
+	if desired := getNodeCount(np); readyCounts[np.Name] < desired {
+		return pkgCluster.Condition{
+			Type:    pkgCluster.ConditionUnhealthy,
+			Status:  pkgCluster.ConditionTrue,
+			Reason:  conditionReasonNodeCountMismatch,
+			Message: fmt.Sprintf("%d of %d nodes are Ready", readyCounts[np.Name], desired),
+		}
+	}
+
+	return newFalseCondition(pkgCluster.ConditionUnhealthy)
+}
+
+func newFalseCondition(conditionType string) pkgCluster.Condition {
+
+	return pkgCluster.Condition{
+		Type:   conditionType,
+		Status: pkgCluster.ConditionFalse,
+		Reason: conditionReasonAsExpected,
+	}
+}
+
+func newUnknownCondition(conditionType string, err error) pkgCluster.Condition {
+
+	return pkgCluster.Condition{
+		Type:    conditionType,
+		Status:  pkgCluster.ConditionUnknown,
+		Reason:  conditionReasonCheckFailed,
+		Message: err.Error(),
+	}
+}
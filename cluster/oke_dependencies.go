@@ -0,0 +1,263 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Policies of DeleteCluster for the OCI resources created by the workloads of the cluster
+const (
+	DependentResourcesIgnore  = ""
+	DependentResourcesRefuse  = "refuse"
+	DependentResourcesCleanup = "cleanup"
+)
+
+// Kinds of the dependent resources
+const (
+	DependentResourceLoadBalancer = "LoadBalancer"
+	DependentResourceBlockVolume  = "BlockVolume"
+)
+
+// dependentResourcesCleanupTimeout is the time to wait for the cloud controller and the volume provisioner to delete
+// the OCI resources of the deleted Services and PersistentVolumeClaims
+const dependentResourcesCleanupTimeout = 5 * time.Minute
+
+// ociVolumeProvisioners are the provisioners which create OCI block volumes for PersistentVolumeClaims
+var ociVolumeProvisioners = []string{"oracle.com/oci", "blockvolume.csi.oraclecloud.com"}
+
+// DependentResource describes an OCI resource created through the Kubernetes API of the cluster which would be
+// orphaned by deleting the cluster
+type DependentResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`                // name of the Service or the PersistentVolume
+	Reference string `json:"reference,omitempty"` // IP addresses of the load balancer or the claim of the volume
+}
+
+// DependentResourcesError is returned when deleting the cluster would orphan OCI resources it created
+type DependentResourcesError struct {
+	ClusterName string
+	Resources   []DependentResource
+}
+
+func (e *DependentResourcesError) Error() string {
+
+	resources := make([]string, 0, len(e.Resources))
+	for _, r := range e.Resources {
+		resources = append(resources, fmt.Sprintf("%s %s/%s (%s)", r.Kind, r.Namespace, r.Name, r.Reference))
+	}
+
+	return fmt.Sprintf("cluster %s has resources which would be orphaned: %s", e.ClusterName, strings.Join(resources, ", "))
+}
+
+// SetDependentResourcesPolicy sets how deleting the cluster handles the load balancers and block volumes created by
+// its workloads: they are ignored, the delete is refused or they are deleted before the cluster
+func (o *OKECluster) SetDependentResourcesPolicy(policy string) error {
+
+	switch policy {
+	case DependentResourcesIgnore, DependentResourcesRefuse, DependentResourcesCleanup:
+		o.dependentResourcesPolicy = policy
+		return nil
+	}
+
+	return errors.Errorf("invalid dependent resources policy: %s", policy)
+}
+
+// GetDependentResources gives back the load balancers of the LoadBalancer Services and the block volumes of the
+// PersistentVolumes provisioned at OCI
+func (o *OKECluster) GetDependentResources() ([]DependentResource, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return listDependentResources(client)
+}
+
+func listDependentResources(client *kubernetes.Clientset) ([]DependentResource, error) {
+
+	resources := make([]DependentResource, 0)
+
+	services, err := client.CoreV1().Services(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing services")
+	}
+
+	for _, service := range services.Items {
+		if service.Spec.Type != v1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		ips := make([]string, 0, len(service.Status.LoadBalancer.Ingress))
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			ips = append(ips, ingress.IP)
+		}
+
+		resources = append(resources, DependentResource{
+			Kind:      DependentResourceLoadBalancer,
+			Namespace: service.Namespace,
+			Name:      service.Name,
+			Reference: strings.Join(ips, ","),
+		})
+	}
+
+	volumes, err := client.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing persistent volumes")
+	}
+
+	for _, volume := range volumes.Items {
+		if !isOCIVolume(&volume) {
+			continue
+		}
+
+		resource := DependentResource{
+			Kind: DependentResourceBlockVolume,
+			Name: volume.Name,
+		}
+		if claim := volume.Spec.ClaimRef; claim != nil {
+			resource.Namespace = claim.Namespace
+			resource.Reference = claim.Name
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// isOCIVolume checks whether the PersistentVolume is backed by an OCI block volume
+func isOCIVolume(volume *v1.PersistentVolume) bool {
+
+	if volume.Spec.FlexVolume != nil && volume.Spec.FlexVolume.Driver == "oracle/oci" {
+		return true
+	}
+
+	provisioner := volume.Annotations["pv.kubernetes.io/provisioned-by"]
+	for _, p := range ociVolumeProvisioners {
+		if p == provisioner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckDependentResources returns a DependentResourcesError listing the dependent resources if the dependent
+// resources policy refuses to delete the cluster with them, it is called before the delete is started
+func (o *OKECluster) CheckDependentResources() error {
+
+	if o.dependentResourcesPolicy != DependentResourcesRefuse {
+		return nil
+	}
+
+	resources, err := o.GetDependentResources()
+	if err != nil {
+		return err
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	return &DependentResourcesError{
+		ClusterName: o.modelCluster.Name,
+		Resources:   resources,
+	}
+}
+
+// CleanupDependentResources deletes the dependent resources if the dependent resources policy is cleanup, a
+// DependentResourcesError is returned for the resources which are not deleted in time
+func (o *OKECluster) CleanupDependentResources() error {
+
+	if o.dependentResourcesPolicy != DependentResourcesCleanup {
+		return nil
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	resources, err := listDependentResources(client)
+	if err != nil {
+		return err
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	resources, err = o.cleanupDependentResources(client, resources)
+	if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	return &DependentResourcesError{
+		ClusterName: o.modelCluster.Name,
+		Resources:   resources,
+	}
+}
+
+// cleanupDependentResources deletes the Services and the claims of the volumes so the cloud controller and the
+// volume provisioner delete their OCI resources, gives back the resources which still exist after the timeout
+func (o *OKECluster) cleanupDependentResources(client *kubernetes.Clientset, resources []DependentResource) ([]DependentResource, error) {
+
+	log := o.getLogger()
+
+	for _, r := range resources {
+		switch r.Kind {
+		case DependentResourceLoadBalancer:
+			err := client.CoreV1().Services(r.Namespace).Delete(r.Name, &metav1.DeleteOptions{})
+			if err != nil && !k8sErrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "error deleting service %s/%s", r.Namespace, r.Name)
+			}
+		case DependentResourceBlockVolume:
+			// retained volumes are deleted too, the volume would be orphaned with the cluster anyway
+			volume, err := client.CoreV1().PersistentVolumes().Get(r.Name, metav1.GetOptions{})
+			if err != nil {
+				if k8sErrors.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "error getting persistent volume %s", r.Name)
+			}
+			if volume.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete {
+				volume.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimDelete
+				if _, err := client.CoreV1().PersistentVolumes().Update(volume); err != nil {
+					return nil, errors.Wrapf(err, "error updating reclaim policy of persistent volume %s", r.Name)
+				}
+			}
+			if r.Reference != "" {
+				err = client.CoreV1().PersistentVolumeClaims(r.Namespace).Delete(r.Reference, &metav1.DeleteOptions{})
+			} else {
+				err = client.CoreV1().PersistentVolumes().Delete(r.Name, &metav1.DeleteOptions{})
+			}
+			if err != nil && !k8sErrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "error deleting volume %s", r.Name)
+			}
+		}
+		log.WithField("kind", r.Kind).Infof("%s/%s deleted", r.Namespace, r.Name)
+	}
+
+	deadline := time.Now().Add(dependentResourcesCleanupTimeout)
+	for {
+		remaining, err := listDependentResources(client)
+		if err != nil {
+			return nil, err
+		}
+		if len(remaining) == 0 || time.Now().After(deadline) {
+			return remaining, nil
+		}
+		time.Sleep(quiescePollInterval)
+	}
+}
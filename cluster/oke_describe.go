@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// maxDescribedEvents is the number of the most recent Kubernetes events included in the cluster description
+const maxDescribedEvents = 50
+
+// Describe gives back a snapshot of the cluster with its details, subnet layout, warnings
+// and the most recent Kubernetes events
+func (o *OKECluster) Describe() (*pkgCluster.ClusterDescription, error) {
+
+	log := o.getLogger()
+
+	details, err := o.GetClusterDetails()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting cluster details")
+	}
+
+	description := &pkgCluster.ClusterDescription{
+		Details:  details,
+		Warnings: details.Warnings,
+	}
+
+	description.Subnets, err = o.describeSubnets()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error describing subnets")
+	}
+
+	// events are best effort, the description is still useful when the API server is not reachable
+	description.Events, err = o.getRecentEvents(maxDescribedEvents)
+	if err != nil {
+		log.Warnf("error listing events: %s", err.Error())
+	}
+
+	return description, nil
+}
+
+// describeSubnets gives back the load balancer, endpoint and worker subnets of the cluster
+func (o *OKECluster) describeSubnets() ([]pkgCluster.SubnetDescription, error) {
+
+	roles := map[string]string{
+		o.modelCluster.OKE.LBSubnetID1: pkgCluster.SubnetRoleLoadBalancer,
+		o.modelCluster.OKE.LBSubnetID2: pkgCluster.SubnetRoleLoadBalancer,
+	}
+	if o.modelCluster.OKE.EndpointSubnetID != "" {
+		roles[o.modelCluster.OKE.EndpointSubnetID] = pkgCluster.SubnetRoleEndpoint
+	}
+
+	nodePools := make(map[string][]string)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil {
+			continue
+		}
+		for _, subnet := range np.Subnets {
+			roles[subnet.SubnetID] = pkgCluster.SubnetRoleWorker
+			nodePools[subnet.SubnetID] = append(nodePools[subnet.SubnetID], np.Name)
+		}
+	}
+	delete(roles, "")
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	vn, err := OCI.NewVirtualNetworkClient()
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := make([]pkgCluster.SubnetDescription, 0)
+	for id, role := range roles {
+		subnetID := id
+		subnet, err := vn.GetSubnet(&subnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		subnets = append(subnets, pkgCluster.SubnetDescription{
+			ID:                 subnetID,
+			Name:               *subnet.DisplayName,
+			CIDR:               *subnet.CidrBlock,
+			AvailabilityDomain: *subnet.AvailabilityDomain,
+			Role:               role,
+			NodePools:          nodePools[subnetID],
+		})
+	}
+
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].Name < subnets[j].Name
+	})
+
+	return subnets, nil
+}
+
+// getRecentEvents gives back the given number of the most recent Kubernetes events of the cluster
+func (o *OKECluster) getRecentEvents(limit int) ([]pkgCluster.ClusterEvent, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.CoreV1().Events(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing events")
+	}
+
+	events := make([]pkgCluster.ClusterEvent, 0)
+	for _, event := range list.Items {
+		events = append(events, pkgCluster.ClusterEvent{
+			Namespace:     event.Namespace,
+			Object:        event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Type:          event.Type,
+			Reason:        event.Reason,
+			Message:       event.Message,
+			Count:         event.Count,
+			LastTimestamp: event.LastTimestamp.Time,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.After(events[j].LastTimestamp)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
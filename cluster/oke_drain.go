@@ -0,0 +1,145 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// DrainNodePool cordons the nodes of the node pool and evicts their pods before the node pool is removed, the
+// evictions respect the PodDisruptionBudgets and the drain fails if the pods do not terminate in time, in which
+// case the nodes cordoned by the drain are uncordoned again
+func (o *OKECluster) DrainNodePool(ctx context.Context, name string) (err error) {
+
+	log := o.getLogger().WithField("nodePool", name)
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return errors.Errorf("node pool not found: %s", name)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	timeout := time.Duration(viper.GetInt(pipConfig.OKEDrainTimeoutSeconds)) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var gracePeriodSeconds *int64
+	if gracePeriod := viper.GetInt64(pipConfig.OKEDrainGracePeriodSeconds); gracePeriod >= 0 {
+		gracePeriodSeconds = &gracePeriod
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing nodes")
+	}
+
+	cordoned := make([]string, 0)
+	defer func() {
+		if err != nil {
+			uncordonNodes(client, cordoned, log)
+		}
+	}()
+
+	// every node is cordoned first, so the evicted pods are not rescheduled onto the node pool
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable {
+			continue
+		}
+		node.Spec.Unschedulable = true
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return errors.Wrapf(err, "error cordoning node %s", node.Name)
+		}
+		log.WithField("node", node.Name).Info("node cordoned")
+		cordoned = append(cordoned, node.Name)
+	}
+
+	evicted := make([]v1.Pod, 0)
+	for _, node := range nodes.Items {
+		pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "error listing pods")
+		}
+
+		for _, pod := range pods.Items {
+			if !isEvictable(&pod) {
+				continue
+			}
+			if err := evictPod(ctx, client, &pod, gracePeriodSeconds); err != nil {
+				return err
+			}
+			log.WithFields(logrus.Fields{"namespace": pod.Namespace, "pod": pod.Name}).Debug("pod evicted")
+			evicted = append(evicted, pod)
+		}
+	}
+
+	log.Infof("%d pods evicted from %d nodes, waiting for them to terminate", len(evicted), len(nodes.Items))
+
+	return waitForPodsDeleted(ctx, client, evicted)
+}
+
+// UncordonNodePool makes the nodes of a drained node pool schedulable again, e.g. when removing the node pool failed,
+// the nodes of the warm pool are kept cordoned
+func (o *OKECluster) UncordonNodePool(name string) error {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + name + "," + modelOracle.WarmPoolLabelKey + "!=true",
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing nodes")
+	}
+
+	names := make([]string, 0)
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			names = append(names, node.Name)
+		}
+	}
+
+	uncordonNodes(client, names, o.getLogger().WithField("nodePool", name))
+
+	return nil
+}
+
+// uncordonNodes marks the given nodes schedulable, failures are only logged since it is used for rolling back
+func uncordonNodes(client *kubernetes.Clientset, names []string, log *logrus.Entry) {
+
+	for _, name := range names {
+		node, err := client.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+		if err != nil {
+			log.WithField("node", name).Warnf("error getting node to uncordon: %s", err.Error())
+			continue
+		}
+
+		node.Spec.Unschedulable = false
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			log.WithField("node", name).Warnf("error uncordoning node: %s", err.Error())
+			continue
+		}
+		log.WithField("node", name).Info("node uncordoned")
+	}
+}
@@ -0,0 +1,247 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	"github.com/banzaicloud/pipeline/model"
+	"github.com/banzaicloud/pipeline/notify"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// driftCheckerTick is the time between checks for clusters which are due for a drift check
+const driftCheckerTick = time.Minute
+
+// CheckDrift compares the state of the cluster stored by Pipeline with the state observed at OCI and with the
+// instance configuration of the nodes, the changes describe how the cluster was modified outside of Pipeline
+func (o *OKECluster) CheckDrift() (*pkgCluster.DriftReport, error) {
+
+	report := &pkgCluster.DriftReport{
+		ClusterID:   o.GetID(),
+		ClusterName: o.GetName(),
+		CheckedAt:   time.Now(),
+		Changes:     make([]pkgCluster.ReconcileAction, 0),
+	}
+
+	observed, err := o.getObservedSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(observed.NodePools) > 0 {
+		plan, err := o.Plan(observed)
+		if err != nil {
+			return nil, err
+		}
+		report.Changes = plan.Actions
+	} else {
+		for _, np := range o.modelCluster.OKE.NodePools {
+			report.Changes = append(report.Changes, pkgCluster.ReconcileAction{
+				Type:       pkgCluster.ActionDeleteNodePool,
+				NodePool:   np.Name,
+				From:       strconv.Itoa(getNodeCount(np)),
+				Disruptive: true,
+			})
+		}
+	}
+
+	report.NodesPendingRoll, err = o.GetNodePoolConfigDrift()
+	if err != nil {
+		o.getLogger().Warnf("error checking node configuration drift: %s", err.Error())
+	}
+
+	return report, nil
+}
+
+// getObservedSpec gives back the state of the cluster at OCI
+func (o *OKECluster) getObservedSpec() (*pkgCluster.ClusterSpec, error) {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := ce.GetCluster(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePools, err := ce.GetNodePools(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &pkgCluster.ClusterSpec{
+		MasterVersion: *cluster.KubernetesVersion,
+		NodePools:     make(map[string]*pkgCluster.NodePoolSpec),
+	}
+
+	for _, np := range nodePools {
+		count := 0
+		if np.QuantityPerSubnet != nil {
+			count = *np.QuantityPerSubnet * len(np.SubnetIds)
+		}
+		if warmPoolSize := int(o.modelCluster.OKE.GetNodePoolByName(*np.Name).WarmPoolSize); count >= warmPoolSize {
+			count -= warmPoolSize
+		}
+
+		spec.NodePools[*np.Name] = &pkgCluster.NodePoolSpec{
+			Count:        count,
+			Version:      *np.KubernetesVersion,
+			InstanceType: *np.NodeShape,
+			Image:        *np.NodeImageName,
+		}
+	}
+
+	return spec, nil
+}
+
+// GetDriftReports gives back the latest stored drift reports of the cluster
+func (o *OKECluster) GetDriftReports(limit int) ([]pkgCluster.DriftReport, error) {
+
+	stored, err := modelOracle.GetDriftReports(o.modelCluster.OKE.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]pkgCluster.DriftReport, 0, len(stored))
+	for _, s := range stored {
+		var report pkgCluster.DriftReport
+		if err := json.Unmarshal([]byte(s.Report), &report); err != nil {
+			return nil, errors.Wrap(err, "error parsing drift report")
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// getDriftCheckInterval gives back the time between the drift checks of the cluster
+func (o *OKECluster) getDriftCheckInterval() time.Duration {
+
+	if o.modelCluster.OKE.DriftCheckInterval > 0 {
+		return time.Duration(o.modelCluster.OKE.DriftCheckInterval) * time.Second
+	}
+
+	return time.Duration(viper.GetInt(pipConfig.OKEDriftCheckIntervalSeconds)) * time.Second
+}
+
+// StartDriftChecker periodically checks the running OKE clusters for drift at their configured interval,
+// detected drifts are stored and sent as a Slack notification
+func StartDriftChecker() {
+
+	lastChecks := make(map[uint]time.Time)
+
+	go func() {
+		for range time.NewTicker(driftCheckerTick).C {
+			checkDrifts(lastChecks)
+		}
+	}()
+}
+
+func checkDrifts(lastChecks map[uint]time.Time) {
+
+	clusters, err := model.QueryCluster(map[string]interface{}{
+		"cloud":  pkgCluster.Oracle,
+		"status": pkgCluster.Running,
+	})
+	if err != nil {
+		log.Errorf("error listing OKE clusters: %s", err.Error())
+		return
+	}
+
+	for i := range clusters {
+		commonCluster, err := GetCommonClusterFromModel(&clusters[i])
+		if err != nil {
+			log.Errorf("error getting cluster %s: %s", clusters[i].Name, err.Error())
+			continue
+		}
+
+		okeCluster, ok := commonCluster.(*OKECluster)
+		if !ok {
+			continue
+		}
+
+		id := okeCluster.GetID()
+		if time.Since(lastChecks[id]) < okeCluster.getDriftCheckInterval() {
+			continue
+		}
+		lastChecks[id] = time.Now()
+
+		if err := okeCluster.reportDrift(); err != nil {
+			okeCluster.getLogger().Warnf("error checking drift: %s", err.Error())
+		}
+	}
+}
+
+// reportDrift checks the cluster for drift, stores and emits the report if drift is detected
+func (o *OKECluster) reportDrift() error {
+
+	report, err := o.CheckDrift()
+	if err != nil {
+		return err
+	}
+
+	if !report.Drifted() {
+		return nil
+	}
+
+	o.getLogger().Infof("drift detected: %d changes, pending rolls in %d node pools", len(report.Changes), len(report.NodesPendingRoll))
+
+	o.emitEvent(EventDriftDetected, "", nil, map[string]string{
+		"changes":         strconv.Itoa(len(report.Changes)),
+		"nodePoolsToRoll": strconv.Itoa(len(report.NodesPendingRoll)),
+	})
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	stored := modelOracle.DriftReport{
+		ClusterID: o.modelCluster.OKE.ID,
+		CheckedAt: report.CheckedAt,
+		Report:    string(raw),
+	}
+	if err := stored.Save(); err != nil {
+		return errors.Wrap(err, "error saving drift report")
+	}
+
+	if viper.GetBool(pipConfig.OKEDriftCheckNotify) {
+		return notify.SlackNotify(getDriftMessage(report))
+	}
+
+	return nil
+}
+
+// getDriftMessage creates a human readable summary of the drift report
+func getDriftMessage(report *pkgCluster.DriftReport) string {
+
+	lines := []string{fmt.Sprintf("Drift detected in cluster %s (%d):", report.ClusterName, report.ClusterID)}
+	for _, change := range report.Changes {
+		target := "cluster"
+		if change.NodePool != "" {
+			target = fmt.Sprintf("node pool %s", change.NodePool)
+		}
+		lines = append(lines, fmt.Sprintf("- %s of %s: %s -> %s", change.Type, target, change.From, change.To))
+	}
+	for nodePool, nodes := range report.NodesPendingRoll {
+		lines = append(lines, fmt.Sprintf("- node pool %s has %d nodes with outdated configuration", nodePool, len(nodes)))
+	}
+
+	return strings.Join(lines, "\n")
+}
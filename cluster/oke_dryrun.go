@@ -0,0 +1,335 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/model"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// plannedSubnetIDPrefix is the prefix of the placeholder IDs of the subnets which would be created
+const plannedSubnetIDPrefix = "planned:"
+
+// subnet roles of the preconfigured VCN in cluster descriptions
+var subnetRoleDescriptions = map[string]string{
+	network.SubnetRoleLoadBalancer: pkgCluster.SubnetRoleLoadBalancer,
+	network.SubnetRoleWorker:       pkgCluster.SubnetRoleWorker,
+	network.SubnetRoleEndpoint:     pkgCluster.SubnetRoleEndpoint,
+}
+
+// DryRunCreateOKECluster runs all validations of CreateOKEClusterFromRequest and ValidateCreationFields
+// (region, shape, version, image, subnet capacity, quotas) and gives back what would be provisioned,
+// neither the VCN nor the cluster is created and nothing is persisted
+func DryRunCreateOKECluster(request *pkgCluster.CreateClusterRequest, orgId, userId uint) (*pkgCluster.CreateDryRunReport, error) {
+
+	// the network values and node images are resolved in the request, the one of the caller is left intact
+	request, err := copyCreateClusterRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Create ClusterModel struct from the request for dry run")
+
+	var oke OKECluster
+
+	oke.modelCluster = &model.ClusterModel{
+		Name:           request.Name,
+		Location:       request.Location,
+		Cloud:          request.Cloud,
+		OrganizationId: orgId,
+		SecretId:       request.SecretId,
+		CreatedBy:      userId,
+		Distribution:   pkgCluster.OKE,
+	}
+
+	r := request.Properties.CreateClusterOKE
+	if err := r.Validate(false); err != nil {
+		return nil, err
+	}
+
+	// the OCI client checks that the region is valid and subscribed
+	OCI, err := oke.GetOCIWithRegion(oke.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &pkgCluster.CreateDryRunReport{
+		VCNName:   getPreconfiguredVCNName(request.Name),
+		NodePools: make(map[string]*pkgCluster.DryRunNodePool),
+	}
+
+	m := network.NewVCNManager(OCI)
+	vcn, found, err := findReusableVCN(m, report.VCNName, r.PrivateEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned map[string]network.PreconfiguredSubnet
+	var subnetADs map[string]string
+	if found && !isVCNCIDR(vcn, r.VCNCIDR) {
+		return nil, errors.Errorf("VCN %s created by pipeline exists with CIDR block %s, requested: %s", report.VCNName, *vcn.CidrBlock, r.VCNCIDR)
+	}
+
+	if found {
+		report.ReuseVCN = true
+		report.VCNID = *vcn.Id
+		if vcn.CidrBlock != nil {
+			report.VCNCIDR = *vcn.CidrBlock
+		}
+
+		r, err = oke.PopulateNetworkValues(r, *vcn.Id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		report.VCNCIDR = r.VCNCIDR
+		if report.VCNCIDR == "" {
+			report.VCNCIDR = network.PreconfiguredVCNCIDR
+		}
+
+		var values network.NetworkValues
+		planned, subnetADs, values, err = planPreconfiguredSubnets(OCI, report.VCNCIDR, r.PrivateEndpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err = oke.applyNetworkValues(r, "", values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	request.Properties.CreateClusterOKE = r
+
+	_, err = oke.resolveNodeImages(r)
+	if err != nil {
+		return nil, err
+	}
+
+	Model, err := modelOracle.CreateModelFromCreateRequest(request, userId)
+	if err != nil {
+		return nil, err
+	}
+	oke.modelCluster.OKE = Model
+
+	cm, err := oke.GetClusterManager()
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		err = cm.ValidateModel(&oke.modelCluster.OKE)
+		if err == nil {
+			err = oke.validateIPCapacity()
+		}
+		if err == nil {
+			err = oke.validateShapeAvailability()
+		}
+		if err == nil {
+			err = oke.validateCompartmentQuotas()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		report.Subnets, err = oke.describeSubnets()
+		if err != nil {
+			return nil, err
+		}
+
+		// the cached warnings belong to the current configuration of the cluster
+		ipWarnings, err := oke.getSubnetIPUsageWarnings()
+		if err != nil {
+			log.Warnf("error checking subnet IP usage: %s", err.Error())
+		}
+		report.Warnings = append(oke.getConfigWarnings(), ipWarnings...)
+	} else {
+		err = cm.ValidateNodePoolOptions(&oke.modelCluster.OKE)
+		if err != nil {
+			return nil, err
+		}
+
+		usage, err := oke.getPlannedSubnetIPUsage(planned)
+		if err != nil {
+			return nil, err
+		}
+
+		err = oke.checkIPCapacity(usage)
+		if err != nil {
+			return nil, err
+		}
+
+		err = oke.checkShapeAvailability(subnetADs)
+		if err != nil {
+			return nil, err
+		}
+
+		err = oke.checkCompartmentQuotas(subnetADs)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Subnets = oke.describePlannedSubnets(planned, subnetADs)
+		report.Warnings = append(oke.getConfigWarnings(), getIPUsageWarnings(usage)...)
+	}
+
+	subnetNames := make(map[string]string)
+	for _, subnet := range report.Subnets {
+		subnetNames[subnet.ID] = subnet.Name
+	}
+	for id, subnet := range planned {
+		subnetNames[id] = subnet.Name
+	}
+
+	for _, np := range oke.modelCluster.OKE.NodePools {
+		pool := &pkgCluster.DryRunNodePool{
+			Version:           np.Version,
+			Image:             np.Image,
+			Shape:             np.Shape,
+			Count:             np.QuantityPerSubnet * uint(len(np.Subnets)),
+			QuantityPerSubnet: np.QuantityPerSubnet,
+			Subnets:           make([]string, 0),
+		}
+		for _, subnet := range np.Subnets {
+			pool.Subnets = append(pool.Subnets, subnetNames[subnet.SubnetID])
+		}
+		report.NodePools[np.Name] = pool
+	}
+
+	// planned subnets have no ID yet
+	for i := range report.Subnets {
+		if _, ok := planned[report.Subnets[i].ID]; ok {
+			report.Subnets[i].ID = ""
+		}
+	}
+
+	return report, nil
+}
+
+// planPreconfiguredSubnets gives back the subnets the preconfigured VCN would be created with by their placeholder
+// IDs, the availability domains of the subnets and the network values which would be collected from the VCN
+func planPreconfiguredSubnets(OCI *oci.OCI, VCNCIDR string, endpointSubnet bool) (planned map[string]network.PreconfiguredSubnet, subnetADs map[string]string, values network.NetworkValues, err error) {
+
+	i, err := OCI.NewIdentityClient()
+	if err != nil {
+		return
+	}
+
+	ads, err := i.GetAvailabilityDomains()
+	if err != nil {
+		return
+	}
+
+	subnets, err := network.GetPreconfiguredSubnets(VCNCIDR, endpointSubnet)
+	if err != nil {
+		return
+	}
+
+	planned = make(map[string]network.PreconfiguredSubnet)
+	subnetADs = make(map[string]string)
+	values.VCNCIDR = VCNCIDR
+	values.SubnetCIDRs = make(map[string]string)
+	for _, subnet := range subnets {
+		if subnet.ADIndex >= len(ads) {
+			return planned, subnetADs, values, errors.Errorf("subnet %s requires %d availability domains, the region has %d", subnet.Name, subnet.ADIndex+1, len(ads))
+		}
+
+		id := plannedSubnetIDPrefix + subnet.Name
+		planned[id] = subnet
+		subnetADs[id] = *ads[subnet.ADIndex].Name
+		values.SubnetCIDRs[id] = subnet.CIDR
+
+		switch subnet.Role {
+		case network.SubnetRoleLoadBalancer:
+			values.LBSubnetIDs = append(values.LBSubnetIDs, id)
+		case network.SubnetRoleWorker:
+			values.WNSubnetIDs = append(values.WNSubnetIDs, id)
+		case network.SubnetRoleEndpoint:
+			values.EndpointSubnetID = id
+		}
+	}
+
+	return planned, subnetADs, values, nil
+}
+
+// getPlannedSubnetIPUsage gives back the estimated IP address consumption of the planned worker node subnets
+func (o *OKECluster) getPlannedSubnetIPUsage(planned map[string]network.PreconfiguredSubnet) (map[string]*subnetIPUsage, error) {
+
+	usage := make(map[string]*subnetIPUsage)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		for _, subnet := range np.Subnets {
+			if usage[subnet.SubnetID] == nil {
+				p, ok := planned[subnet.SubnetID]
+				if !ok {
+					return usage, fmt.Errorf("unknown subnet: %s", subnet.SubnetID)
+				}
+
+				available, err := getAvailableIPCount(p.CIDR)
+				if err != nil {
+					return usage, err
+				}
+
+				usage[subnet.SubnetID] = &subnetIPUsage{
+					Name:      p.Name,
+					CIDR:      p.CIDR,
+					Available: available - subnetReservedIPCount,
+				}
+			}
+			usage[subnet.SubnetID].Required += int(np.QuantityPerSubnet)
+		}
+	}
+
+	return usage, nil
+}
+
+// describePlannedSubnets describes the planned subnets of the preconfigured VCN
+func (o *OKECluster) describePlannedSubnets(planned map[string]network.PreconfiguredSubnet, subnetADs map[string]string) []pkgCluster.SubnetDescription {
+
+	nodePools := make(map[string][]string)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		for _, subnet := range np.Subnets {
+			nodePools[subnet.SubnetID] = append(nodePools[subnet.SubnetID], np.Name)
+		}
+	}
+
+	subnets := make([]pkgCluster.SubnetDescription, 0)
+	for id, subnet := range planned {
+		subnets = append(subnets, pkgCluster.SubnetDescription{
+			ID:                 id,
+			Name:               subnet.Name,
+			CIDR:               subnet.CIDR,
+			AvailabilityDomain: subnetADs[id],
+			Role:               subnetRoleDescriptions[subnet.Role],
+			NodePools:          nodePools[id],
+		})
+	}
+
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].Name < subnets[j].Name
+	})
+
+	return subnets
+}
+
+// copyCreateClusterRequest gives back a deep copy of the create cluster request
+func copyCreateClusterRequest(request *pkgCluster.CreateClusterRequest) (*pkgCluster.CreateClusterRequest, error) {
+
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error copying create cluster request")
+	}
+
+	var requestCopy pkgCluster.CreateClusterRequest
+	if err := json.Unmarshal(raw, &requestCopy); err != nil {
+		return nil, errors.Wrap(err, "error copying create cluster request")
+	}
+
+	return &requestCopy, nil
+}
@@ -0,0 +1,20 @@
+package cluster
+
+// emitEvent emits an event of the cluster, the error of the operation is added to the event if it failed
+func (o *OKECluster) emitEvent(eventType, nodePool string, err error, details map[string]string) {
+
+	event := Event{
+		Type:           eventType,
+		OrganizationID: o.GetOrganizationId(),
+		ClusterID:      o.GetID(),
+		ClusterName:    o.GetName(),
+		Cloud:          o.GetCloud(),
+		NodePool:       nodePool,
+		Details:        details,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	emitEvent(event)
+}
@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LeakedResource describes an OCI resource which could not be deleted with the cluster
+type LeakedResource struct {
+	Type  string
+	ID    string
+	Error string
+}
+
+// LeakedResourcesError is returned by ForceDelete when some resources of the cluster need manual cleanup
+type LeakedResourcesError struct {
+	ClusterName string
+	Resources   []LeakedResource
+}
+
+func (e *LeakedResourcesError) Error() string {
+
+	resources := make([]string, 0, len(e.Resources))
+	for _, r := range e.Resources {
+		resources = append(resources, fmt.Sprintf("%s %s (%s)", r.Type, r.ID, r.Error))
+	}
+
+	return fmt.Sprintf("cluster %s deleted, resources need manual cleanup: %s", e.ClusterName, strings.Join(resources, ", "))
+}
+
+// ForceDelete deletes the cluster like DeleteCluster but doesn't stop at the errors of deleting the OKE cluster
+// and its VCN, the cluster is removed from the database and its OCI model cleaned up anyway. The resources which could not be deleted are
+// returned in a LeakedResourcesError.
+func (o *OKECluster) ForceDelete(ctx context.Context) error {
+
+	log := o.getLogger()
+
+	leaked := &LeakedResourcesError{
+		ClusterName: o.modelCluster.Name,
+	}
+
+	o.modelCluster.OKE.Delete = true
+
+	cm, err := o.GetClusterManager()
+	if err == nil {
+		err = cm.ManageOKECluster(ctx, &o.modelCluster.OKE)
+	}
+	if err != nil {
+		log.Warnf("error deleting cluster, forcing delete: %s", err.Error())
+		leaked.Resources = append(leaked.Resources, LeakedResource{
+			Type:  "cluster",
+			ID:    o.modelCluster.OKE.OCID,
+			Error: err.Error(),
+		})
+	}
+
+	o.invalidateK8sConfig()
+
+	// the VCN is already deleted if the cluster creation was rolled back
+	if o.modelCluster.OKE.VCNID != "" {
+		if err := o.DeletePreconfiguredVCN(o.modelCluster.OKE.VCNID); err != nil {
+			log.Warnf("error deleting VCN, forcing delete: %s", err.Error())
+			leaked.Resources = append(leaked.Resources, LeakedResource{
+				Type:  "vcn",
+				ID:    o.modelCluster.OKE.VCNID,
+				Error: err.Error(),
+			})
+		}
+	}
+
+	if err := o.DeleteFromDatabase(); err != nil {
+		return err
+	}
+
+	if len(leaked.Resources) > 0 {
+		return leaked
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// GetNodePoolHeadroom gives back the number of additional nodes which fit into each subnet of the node pool,
+// it is limited by the free IP addresses of the subnet, the unused part of the POD CIDR and the compute and
+// block storage quotas of the compartment in the availability domain of the subnet
+func (o *OKECluster) GetNodePoolHeadroom(name string) (map[string]int, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return nil, errors.Errorf("node pool not found: %s", name)
+	}
+
+	usage, err := o.getSubnetIPUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeCount := 0
+	for _, u := range usage {
+		nodeCount += u.Required
+	}
+
+	_, maxNodes, err := o.getPodCIDRMaxNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := OCI.NewLimitsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	quotaHeadroom := make(map[string]int)
+	headroom := make(map[string]int)
+	for _, subnet := range np.Subnets {
+		u := usage[subnet.SubnetID]
+		if u == nil {
+			continue
+		}
+
+		free := minInt(u.Available-u.Required, maxNodes-nodeCount)
+
+		AD := u.AvailabilityDomain
+		if _, ok := quotaHeadroom[AD]; !ok {
+			quotaHeadroom[AD], err = getQuotaHeadroom(limits, np.Shape, AD)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if quotaHeadroom[AD] >= 0 {
+			free = minInt(free, quotaHeadroom[AD])
+		}
+
+		if free < 0 {
+			free = 0
+		}
+		headroom[subnet.SubnetID] = free
+	}
+
+	return headroom, nil
+}
+
+// getQuotaHeadroom gives back the number of nodes of the given shape which fit into the compute and block storage
+// quotas in the availability domain, it is -1 if the resources are not limited
+func getQuotaHeadroom(limits *oci.Limits, shape, AD string) (int, error) {
+
+	headroom := -1
+
+	if limitName, cores, ok := oci.GetShapeCoreLimit(shape); ok {
+		availability, err := limits.GetResourceAvailability(oci.LimitsServiceCompute, limitName, AD)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error getting availability of %s %s", oci.LimitsServiceCompute, limitName)
+		}
+		if availability.Available != nil {
+			headroom = int(*availability.Available / cores)
+		}
+	}
+
+	availability, err := limits.GetResourceAvailability(oci.LimitsServiceBlockStorage, oci.LimitTotalStorageGB, AD)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error getting availability of %s %s", oci.LimitsServiceBlockStorage, oci.LimitTotalStorageGB)
+	}
+	if availability.Available != nil {
+		storageHeadroom := int(*availability.Available / nodeBootVolumeSizeInGBs)
+		if headroom < 0 || storageHeadroom < headroom {
+			headroom = storageHeadroom
+		}
+	}
+
+	return headroom, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
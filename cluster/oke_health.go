@@ -0,0 +1,192 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/helm"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// healthCheckTimeout is the timeout of the requests made by CheckHealth
+const healthCheckTimeout = 5 * time.Second
+
+// Health endpoints of the API server, /readyz is not served before Kubernetes 1.16
+const (
+	readyzPath  = "/readyz"
+	healthzPath = "/healthz"
+)
+
+// Kinds of the system components
+const (
+	componentKindDaemonSet  = "DaemonSet"
+	componentKindDeployment = "Deployment"
+)
+
+// GetSystemComponentHealth gives back the readiness of the DaemonSets and Deployments in the kube-system namespace,
+// e.g. CoreDNS, kube-proxy and the CNI, a component is healthy when all of its desired pods are ready and up to date
+func (o *OKECluster) GetSystemComponentHealth() ([]pkgCluster.ComponentHealth, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]pkgCluster.ComponentHealth, 0)
+
+	daemonSets, err := client.AppsV1().DaemonSets(metav1.NamespaceSystem).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing kube-system daemonsets")
+	}
+
+	for _, ds := range daemonSets.Items {
+		desired := int(ds.Status.DesiredNumberScheduled)
+		ready := int(ds.Status.NumberReady)
+		updated := int(ds.Status.UpdatedNumberScheduled)
+
+		component := pkgCluster.ComponentHealth{
+			Name:    ds.Name,
+			Kind:    componentKindDaemonSet,
+			Desired: desired,
+			Ready:   ready,
+			Healthy: ready >= desired && updated >= desired,
+		}
+		if !component.Healthy {
+			component.Message = fmt.Sprintf("%d of %d pods ready, %d updated", ready, desired, updated)
+		}
+		components = append(components, component)
+	}
+
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceSystem).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing kube-system deployments")
+	}
+
+	for _, deployment := range deployments.Items {
+		desired := 1
+		if deployment.Spec.Replicas != nil {
+			desired = int(*deployment.Spec.Replicas)
+		}
+		ready := int(deployment.Status.ReadyReplicas)
+		updated := int(deployment.Status.UpdatedReplicas)
+
+		component := pkgCluster.ComponentHealth{
+			Name:    deployment.Name,
+			Kind:    componentKindDeployment,
+			Desired: desired,
+			Ready:   ready,
+			Healthy: ready >= desired && updated >= desired,
+		}
+		if !component.Healthy {
+			component.Message = fmt.Sprintf("%d of %d replicas ready, %d updated", ready, desired, updated)
+		}
+		components = append(components, component)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return components, nil
+}
+
+// CheckHealth checks whether the Kubernetes API server of the cluster is serving requests by calling its /readyz
+// endpoint (/healthz on older versions) with the stored kubeconfig, it doesn't depend on the state stored by
+// pipeline or reported by OCI; the result is unreachable if the API server doesn't respond in time
+func (o *OKECluster) CheckHealth() (*pkgCluster.HealthCheckResult, error) {
+
+	kubeConfig, err := o.GetK8sConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s config")
+	}
+
+	config, err := helm.GetK8sClientConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s client config")
+	}
+	config.Timeout = healthCheckTimeout
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s client")
+	}
+
+	result := &pkgCluster.HealthCheckResult{
+		Endpoint:  config.Host,
+		CheckedAt: time.Now(),
+	}
+
+	body, err := client.Discovery().RESTClient().Get().AbsPath(readyzPath).Param("verbose", "").DoRaw()
+	if statusErr, ok := err.(k8sErrors.APIStatus); ok && statusErr.Status().Code == http.StatusNotFound {
+		body, err = client.Discovery().RESTClient().Get().AbsPath(healthzPath).Param("verbose", "").DoRaw()
+	}
+
+	result.Checks = parseHealthChecks(string(body))
+
+	if err == nil {
+		result.Status = pkgCluster.HealthStatusHealthy
+		return result, nil
+	}
+
+	if _, ok := err.(k8sErrors.APIStatus); !ok {
+		result.Status = pkgCluster.HealthStatusUnreachable
+		result.Detail = err.Error()
+		return result, nil
+	}
+
+	// the API server responded, but some of its checks failed
+	result.Status = pkgCluster.HealthStatusDegraded
+	failed := make([]string, 0)
+	for _, check := range result.Checks {
+		if !check.Healthy {
+			failed = append(failed, check.Name)
+		}
+	}
+	if len(failed) > 0 {
+		result.Detail = fmt.Sprintf("failed checks: %s", strings.Join(failed, ", "))
+	} else {
+		result.Detail = err.Error()
+	}
+
+	return result, nil
+}
+
+// parseHealthChecks parses the verbose output of the health endpoints of the API server, which has a line per check,
+// e.g. "[+]ping ok" and "[-]etcd failed: reason withheld"
+func parseHealthChecks(body string) []pkgCluster.HealthCheck {
+
+	checks := make([]pkgCluster.HealthCheck, 0)
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+
+		var healthy bool
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			healthy = true
+		case strings.HasPrefix(line, "[-]"):
+			healthy = false
+		default:
+			continue
+		}
+
+		fields := strings.SplitN(strings.TrimSpace(line[3:]), " ", 2)
+		check := pkgCluster.HealthCheck{
+			Name:    fields[0],
+			Healthy: healthy,
+		}
+		if !healthy && len(fields) > 1 {
+			check.Message = fields[1]
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
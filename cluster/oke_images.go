@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"context"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// CheckNodeImageUpdates compares the image of each node pool and the images of its nodes with the latest image
+// available for the operating system and k8s version of the pool, and gives back the pools running outdated images.
+// The outdated nodes can be replaced by RollNodePool after updating the image of the pool.
+func (o *OKECluster) CheckNodeImageUpdates() ([]pkgCluster.NodeImageUpdate, error) {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		return nil, err
+	}
+
+	compute, err := OCI.NewComputeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string][]oci.NodeImage)
+	updates := make([]pkgCluster.NodeImageUpdate, 0)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil || np.Delete {
+			continue
+		}
+
+		if images[np.Version] == nil {
+			images[np.Version], err = OCI.ListNodePoolImages(np.Version)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		latest, found := oci.LatestNodeImage(images[np.Version], np.Image)
+		if !found || latest.OCID == "" {
+			o.getLogger().WithField("nodePool", np.Name).Warnf("no image found for %s", np.Image)
+			continue
+		}
+
+		update := pkgCluster.NodeImageUpdate{
+			NodePool:        np.Name,
+			CurrentImage:    np.Image,
+			TargetImage:     latest.Name,
+			TargetImageOCID: latest.OCID,
+		}
+
+		if np.OCID != "" {
+			nodePool, err := ce.GetNodePool(context.Background(), &np.OCID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, node := range nodePool.Nodes {
+				if node.Id == nil {
+					continue
+				}
+				instance, err := compute.GetInstance(context.Background(), *node.Id)
+				if err != nil {
+					return nil, err
+				}
+				if instance.ImageId != nil && *instance.ImageId != latest.OCID {
+					name := *node.Id
+					if node.Name != nil {
+						name = *node.Name
+					}
+					update.OutdatedNodes = append(update.OutdatedNodes, name)
+				}
+			}
+		}
+
+		if update.CurrentImage != update.TargetImage || len(update.OutdatedNodes) > 0 {
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+)
+
+// k8sConfigCacheEntry is a kubeconfig of an OKE cluster with its expiry
+type k8sConfigCacheEntry struct {
+	config    []byte
+	expiresAt time.Time
+}
+
+// k8sConfigs caches the kubeconfigs of the OKE clusters keyed by cluster UID, the kubeconfig of an OKE cluster
+// doesn't change during its lifetime apart from the authentication tokens
+var k8sConfigs = struct {
+	sync.Mutex
+	entries map[string]k8sConfigCacheEntry
+}{
+	entries: make(map[string]k8sConfigCacheEntry),
+}
+
+// getCachedK8sConfig gives back the cached kubeconfig of the cluster, false if it isn't cached or expired
+func (o *OKECluster) getCachedK8sConfig() ([]byte, bool) {
+
+	k8sConfigs.Lock()
+	defer k8sConfigs.Unlock()
+
+	entry, ok := k8sConfigs.entries[o.GetUID()]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(k8sConfigs.entries, o.GetUID())
+		return nil, false
+	}
+
+	return entry.config, true
+}
+
+// cacheK8sConfig caches the kubeconfig of the cluster for the configured TTL
+func (o *OKECluster) cacheK8sConfig(config []byte) {
+
+	ttl := time.Duration(viper.GetInt(pipConfig.OKEK8sConfigCacheTTLSeconds)) * time.Second
+	if ttl <= 0 || o.GetUID() == "" {
+		return
+	}
+
+	k8sConfigs.Lock()
+	defer k8sConfigs.Unlock()
+
+	k8sConfigs.entries[o.GetUID()] = k8sConfigCacheEntry{
+		config:    config,
+		expiresAt: time.Now().Add(ttl),
+	}
+}
+
+// invalidateK8sConfig removes the kubeconfig of the cluster from the cache
+func (o *OKECluster) invalidateK8sConfig() {
+
+	k8sConfigs.Lock()
+	defer k8sConfigs.Unlock()
+
+	delete(k8sConfigs.entries, o.GetUID())
+	o.CommonClusterBase.config = nil
+}
+
+// RefreshK8sConfig downloads the kubeconfig of the cluster again bypassing the cache and stores it,
+// it is used when the authentication tokens of the cached kubeconfig are expired
+func (o *OKECluster) RefreshK8sConfig() ([]byte, error) {
+
+	o.invalidateK8sConfig()
+
+	config, err := o.downloadK8sConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading k8s config")
+	}
+
+	err = StoreKubernetesConfig(o, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing k8s config")
+	}
+
+	o.CommonClusterBase.config = config
+	o.cacheK8sConfig(config)
+
+	return config, nil
+}
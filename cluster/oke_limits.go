@@ -0,0 +1,116 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// boot volume size of the OKE node images
+const nodeBootVolumeSizeInGBs = 47
+
+// validateCompartmentQuotas checks that the node pools fit into the compute and block storage quotas of the target
+// compartment in every availability domain, which may be lower than the service limits of the tenancy
+func (o *OKECluster) validateCompartmentQuotas() error {
+
+	return o.checkCompartmentQuotas(make(map[string]string))
+}
+
+// checkCompartmentQuotas checks the compartment quotas, the availability domains of the subnets which are
+// not in subnetADs are looked up
+func (o *OKECluster) checkCompartmentQuotas(subnetADs map[string]string) error {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	vn, err := OCI.NewVirtualNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	// required cores per limit and boot volume storage per availability domain
+	cores := make(map[string]map[string]int64)
+	storage := make(map[string]int64)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil || np.Delete {
+			continue
+		}
+
+		limitName, shapeCores, ok := oci.GetShapeCoreLimit(np.Shape)
+
+		for _, subnet := range np.Subnets {
+			AD, err := getSubnetAD(vn, subnetADs, subnet.SubnetID)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				if cores[AD] == nil {
+					cores[AD] = make(map[string]int64)
+				}
+				cores[AD][limitName] += shapeCores * int64(np.QuantityPerSubnet)
+			}
+			storage[AD] += nodeBootVolumeSizeInGBs * int64(np.QuantityPerSubnet)
+		}
+	}
+
+	limits, err := OCI.NewLimitsClient()
+	if err != nil {
+		return err
+	}
+
+	for AD, required := range cores {
+		for limitName, count := range required {
+			if err := checkResourceAvailability(limits, oci.LimitsServiceCompute, limitName, AD, count); err != nil {
+				return err
+			}
+		}
+	}
+
+	for AD, size := range storage {
+		if err := checkResourceAvailability(limits, oci.LimitsServiceBlockStorage, oci.LimitTotalStorageGB, AD, size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSubnetAD gives back the availability domain of the subnet, it is looked up if it is not in subnetADs yet
+func getSubnetAD(vn *oci.VirtualNetwork, subnetADs map[string]string, subnetID string) (string, error) {
+
+	if AD, ok := subnetADs[subnetID]; ok {
+		return AD, nil
+	}
+
+	s, err := vn.GetSubnet(&subnetID)
+	if err != nil {
+		return "", err
+	}
+	subnetADs[subnetID] = *s.AvailabilityDomain
+
+	return *s.AvailabilityDomain, nil
+}
+
+// checkResourceAvailability returns CompartmentQuotaExceededError if the required amount of the resource is not available
+func checkResourceAvailability(limits *oci.Limits, service, limitName, AD string, required int64) error {
+
+	availability, err := limits.GetResourceAvailability(service, limitName, AD)
+	if err != nil {
+		return errors.Wrapf(err, "error getting availability of %s %s", service, limitName)
+	}
+
+	if availability.Available != nil && *availability.Available < required {
+		return &oci.CompartmentQuotaExceededError{
+			Service:            service,
+			Quota:              limitName,
+			AvailabilityDomain: AD,
+			Required:           required,
+			Available:          *availability.Available,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/model"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// OKEClusterFilter describes which OKE clusters are listed, empty fields are not filtered on
+type OKEClusterFilter struct {
+	IDs          []uint
+	Status       []string
+	Distribution string
+}
+
+// modelFilter gives back the database filter of the OKE clusters of the organization matching the filter
+func (filter OKEClusterFilter) modelFilter(orgID uint) model.ClusterFilter {
+
+	status := make([]string, 0, len(filter.Status))
+	for _, s := range filter.Status {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			status = append(status, s)
+		}
+	}
+
+	return model.ClusterFilter{
+		IDs:            filter.IDs,
+		OrganizationID: orgID,
+		Cloud:          pkgCluster.Oracle,
+		Distribution:   filter.Distribution,
+		Status:         status,
+	}
+}
+
+// ListOKEClusterIDs gives back the IDs of the OKE clusters of the organization (of every organization if orgID is 0)
+// matching the filter, the clusters themselves are not loaded
+func ListOKEClusterIDs(orgID uint, filter OKEClusterFilter) ([]uint, error) {
+
+	ids, err := model.QueryClusterIDsWithFilter(filter.modelFilter(orgID))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing OKE cluster IDs")
+	}
+
+	return ids, nil
+}
+
+// ListOKEClusters gives back the OKE clusters of the organization (of every organization if orgID is 0) matching
+// the filter, the filtering is done by the database so only the matching clusters are loaded
+func ListOKEClusters(orgID uint, filter OKEClusterFilter) ([]*OKECluster, error) {
+
+	clusters, err := model.QueryClusterWithFilter(filter.modelFilter(orgID))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing OKE clusters")
+	}
+
+	okeClusters := make([]*OKECluster, 0, len(clusters))
+	for i := range clusters {
+		okeCluster, err := CreateOKEClusterFromModel(&clusters[i])
+		if err != nil {
+			return nil, err
+		}
+		okeClusters = append(okeClusters, okeCluster)
+	}
+
+	return okeClusters, nil
+}
@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// levelLoggers caches the loggers of the clusters which log at their own log level keyed by level, they share
+// the output, formatter and hooks of the global logger
+var levelLoggers = struct {
+	sync.Mutex
+	entries map[logrus.Level]*logrus.Logger
+}{
+	entries: make(map[logrus.Level]*logrus.Logger),
+}
+
+// SetLogLevel sets the log level used by the operations of this cluster and saves it with the cluster,
+// an empty level resets to the global log level
+func (o *OKECluster) SetLogLevel(level string) error {
+
+	if level != "" {
+		if _, err := logrus.ParseLevel(level); err != nil {
+			return err
+		}
+	}
+
+	o.modelCluster.OKE.LogLevel = level
+
+	if o.modelCluster.ID == 0 {
+		return nil
+	}
+
+	return o.modelCluster.Save()
+}
+
+// getOperationLogger gives back the logger of a lifecycle operation of the cluster
+func (o *OKECluster) getOperationLogger(operation string) logrus.FieldLogger {
+
+	return o.getLogger().WithField("operation", operation)
+}
+
+// getLogger gives back the logger of the cluster with the cluster id, name, organization id and region fields,
+// which logs at the cluster's own log level if it is set
+func (o *OKECluster) getLogger() logrus.FieldLogger {
+
+	fields := logrus.Fields{}
+	logLevel := ""
+	if o.modelCluster != nil {
+		fields["cluster"] = o.modelCluster.Name
+		fields["clusterId"] = o.modelCluster.ID
+		fields["organization"] = o.modelCluster.OrganizationId
+		fields["region"] = o.modelCluster.Location
+		logLevel = o.modelCluster.OKE.LogLevel
+	}
+
+	if logLevel == "" {
+		return log.WithFields(fields)
+	}
+
+	level, err := logrus.ParseLevel(logLevel)
+	if err != nil {
+		return log.WithFields(fields)
+	}
+
+	return getLevelLogger(level).WithFields(fields)
+}
+
+// getLevelLogger gives back the cached logger of the given level, it is created on first use
+func getLevelLogger(level logrus.Level) *logrus.Logger {
+
+	levelLoggers.Lock()
+	defer levelLoggers.Unlock()
+
+	if logger, ok := levelLoggers.entries[level]; ok {
+		return logger
+	}
+
+	global := config.Logger()
+	logger := logrus.New()
+	logger.Out = global.Out
+	logger.Formatter = global.Formatter
+	logger.Hooks = global.Hooks
+	logger.Level = level
+
+	levelLoggers.entries[level] = logger
+
+	return logger
+}
@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	"github.com/banzaicloud/pipeline/model"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+const nodeMetricsPath = "/apis/metrics.k8s.io/v1beta1/nodes"
+
+// defaultNodePoolMetricsInterval is used when the configured sampling interval is not positive
+const defaultNodePoolMetricsInterval = 300 * time.Second
+
+// nodeMetricsList describes the node metrics served by metrics-server
+type nodeMetricsList struct {
+	Items []struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+		Usage    v1.ResourceList   `json:"usage"`
+	} `json:"items"`
+}
+
+// SampleNodePoolMetrics takes and stores a CPU/memory usage sample of every node pool of the cluster,
+// the usage is collected from metrics-server which must be deployed to the cluster
+func (o *OKECluster) SampleNodePoolMetrics() error {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	raw, err := client.CoreV1().RESTClient().Get().AbsPath(nodeMetricsPath).DoRaw()
+	if err != nil {
+		return errors.Wrap(err, "error getting node metrics")
+	}
+
+	var metrics nodeMetricsList
+	if err := json.Unmarshal(raw, &metrics); err != nil {
+		return errors.Wrap(err, "error parsing node metrics")
+	}
+
+	usage := make(map[string]v1.ResourceList)
+	for _, item := range metrics.Items {
+		usage[item.Metadata.Name] = item.Usage
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error listing nodes")
+	}
+
+	sampledAt := time.Now()
+	samples := make(map[string]*modelOracle.NodePoolMetricSample)
+	for _, node := range nodes.Items {
+		np := o.modelCluster.OKE.GetNodePoolByName(node.Labels[pkgCommon.LabelKey])
+		if np.ID == 0 {
+			continue
+		}
+
+		sample := samples[np.Name]
+		if sample == nil {
+			sample = &modelOracle.NodePoolMetricSample{
+				ClusterID: o.modelCluster.OKE.ID,
+				NodePool:  np.Name,
+				SampledAt: sampledAt,
+			}
+			samples[np.Name] = sample
+		}
+
+		sample.NodeCount++
+		sample.CPUAllocatable += node.Status.Allocatable.Cpu().MilliValue()
+		sample.MemoryAllocatable += node.Status.Allocatable.Memory().Value()
+		if u, ok := usage[node.Name]; ok {
+			sample.CPUUsage += u.Cpu().MilliValue()
+			sample.MemoryUsage += u.Memory().Value()
+		}
+	}
+
+	for _, sample := range samples {
+		if err := sample.Save(); err != nil {
+			return errors.Wrap(err, "error saving node pool metric sample")
+		}
+	}
+
+	return nil
+}
+
+// GetNodePoolMetricHistory gives back the CPU/memory usage samples of the node pool taken between from and to
+func (o *OKECluster) GetNodePoolMetricHistory(name string, from, to time.Time) ([]modelOracle.NodePoolMetricSample, error) {
+
+	if o.modelCluster.OKE.GetNodePoolByName(name).ID == 0 {
+		return nil, errors.Errorf("node pool not found: %s", name)
+	}
+
+	return modelOracle.GetNodePoolMetricSamples(o.modelCluster.OKE.ID, name, from, to)
+}
+
+// StartNodePoolMetricCollector periodically samples the node pool utilization of the running OKE clusters
+// and removes the samples older than the configured retention
+func StartNodePoolMetricCollector() {
+
+	interval := time.Duration(viper.GetInt(pipConfig.OKEMetricsIntervalSeconds)) * time.Second
+	if interval <= 0 {
+		log.Warnf("invalid %s: %s, using %s", pipConfig.OKEMetricsIntervalSeconds, interval, defaultNodePoolMetricsInterval)
+		interval = defaultNodePoolMetricsInterval
+	}
+	retention := time.Duration(viper.GetInt(pipConfig.OKEMetricsRetentionDays)) * 24 * time.Hour
+
+	go func() {
+		for range time.NewTicker(interval).C {
+			collectNodePoolMetrics()
+
+			if err := modelOracle.DeleteNodePoolMetricSamplesBefore(time.Now().Add(-retention)); err != nil {
+				log.Errorf("error deleting expired node pool metric samples: %s", err.Error())
+			}
+		}
+	}()
+}
+
+func collectNodePoolMetrics() {
+
+	clusters, err := model.QueryCluster(map[string]interface{}{
+		"cloud":  pkgCluster.Oracle,
+		"status": pkgCluster.Running,
+	})
+	if err != nil {
+		log.Errorf("error listing OKE clusters: %s", err.Error())
+		return
+	}
+
+	for i := range clusters {
+		commonCluster, err := GetCommonClusterFromModel(&clusters[i])
+		if err != nil {
+			log.Errorf("error getting cluster %s: %s", clusters[i].Name, err.Error())
+			continue
+		}
+
+		okeCluster, ok := commonCluster.(*OKECluster)
+		if !ok {
+			continue
+		}
+
+		if err := okeCluster.SampleNodePoolMetrics(); err != nil {
+			okeCluster.getLogger().Warnf("error sampling node pool metrics: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+const defaultDenyNetworkPolicyName = "default-deny"
+
+// networkPolicyDaemonSets are the kube-system DaemonSets of the network plugins enforcing NetworkPolicies
+var networkPolicyDaemonSets = []string{"calico-node", "canal", "cilium", "weave-net", "kube-router"}
+
+// applyNetworkPolicies applies the given NetworkPolicies and creates a default deny policy in the given namespaces,
+// the create requests with NetworkPolicies are rejected by validation as long as the clusters run flannel
+func (o *OKECluster) applyNetworkPolicies(policies *oracle.NetworkPolicies) error {
+
+	if policies == nil || (len(policies.DefaultDenyNamespaces) == 0 && len(policies.Policies) == 0) {
+		return nil
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	supported, err := isNetworkPolicySupported(client)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return errors.Errorf("the cluster's network plugin (%s) doesn't enforce NetworkPolicies", oracle.CNIFlannel)
+	}
+
+	for _, namespace := range policies.DefaultDenyNamespaces {
+		err = ensureNamespace(client, namespace)
+		if err != nil {
+			return err
+		}
+
+		err = applyNetworkPolicy(client, &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultDenyNetworkPolicyName,
+				Namespace: namespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeIngress,
+					networkingv1.PolicyTypeEgress,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range policies.Policies {
+		err = ensureNamespace(client, policies.Policies[i].Namespace)
+		if err != nil {
+			return err
+		}
+
+		err = applyNetworkPolicy(client, &policies.Policies[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isNetworkPolicySupported returns true if a network plugin enforcing NetworkPolicies runs in the cluster
+func isNetworkPolicySupported(client *kubernetes.Clientset) (bool, error) {
+
+	for _, name := range networkPolicyDaemonSets {
+		_, err := client.ExtensionsV1beta1().DaemonSets(metav1.NamespaceSystem).Get(name, metav1.GetOptions{})
+		if err == nil {
+			return true, nil
+		}
+		if !k8sErrors.IsNotFound(err) {
+			return false, errors.Wrap(err, "error getting network plugin daemonset")
+		}
+	}
+
+	return false, nil
+}
+
+// applyNetworkPolicy creates or updates the given NetworkPolicy
+func applyNetworkPolicy(client *kubernetes.Clientset, policy *networkingv1.NetworkPolicy) error {
+
+	_, err := client.NetworkingV1().NetworkPolicies(policy.Namespace).Create(policy)
+	if k8sErrors.IsAlreadyExists(err) {
+		_, err = client.NetworkingV1().NetworkPolicies(policy.Namespace).Update(policy)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error applying network policy %s/%s", policy.Namespace, policy.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+// NodePatchErrors is returned when some nodes of a node pool could not be patched, the errors are keyed by node name
+type NodePatchErrors struct {
+	Errors map[string]error
+}
+
+func (e *NodePatchErrors) Error() string {
+
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %s", name, e.Errors[name].Error()))
+	}
+
+	return fmt.Sprintf("error patching %d node(s): %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// getNodePatchConcurrency gives back the number of nodes which are patched in parallel
+func getNodePatchConcurrency() int {
+
+	return viper.GetInt(pipConfig.OKENodePatchConcurrency)
+}
+
+// patchNodes calls patch for every node with at most concurrency calls running in parallel,
+// the errors of the nodes are collected into NodePatchErrors
+func patchNodes(nodeNames []string, concurrency int, patch func(nodeName string) error) error {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	names := make(chan string)
+	failures := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency && i < len(nodeNames); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if err := patch(name); err != nil {
+					mu.Lock()
+					failures[name] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, name := range nodeNames {
+		names <- name
+	}
+	close(names)
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &NodePatchErrors{Errors: failures}
+	}
+
+	return nil
+}
+
+// ReconcileNodeLabels puts the labels of the node pool on its nodes, OKE applies the labels of a node pool only to
+// the nodes created after they were set, returns the number of patched nodes
+func (o *OKECluster) ReconcileNodeLabels(nodePoolName string) (int, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(nodePoolName)
+	if np.ID == 0 {
+		return 0, errors.Errorf("node pool not found: %s", nodePoolName)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return 0, err
+	}
+
+	labels := o.getNodePoolLabels(nodePoolName)
+	if len(labels) == 0 {
+		return 0, nil
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + np.Name,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "error listing nodes")
+	}
+
+	outdated := make([]string, 0)
+	for _, node := range nodes.Items {
+		for k, v := range labels {
+			if current, ok := node.Labels[k]; !ok || current != v {
+				outdated = append(outdated, node.Name)
+				break
+			}
+		}
+	}
+
+	err = patchNodes(outdated, getNodePatchConcurrency(), func(nodeName string) error {
+		return addLabelsToNode(client, nodeName, labels)
+	})
+	if err != nil {
+		return len(outdated) - len(err.(*NodePatchErrors).Errors), err
+	}
+
+	o.getLogger().WithField("nodePool", np.Name).Infof("labels of %d node(s) updated", len(outdated))
+
+	return len(outdated), nil
+}
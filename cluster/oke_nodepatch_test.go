@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPatchNodes(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		count       int
+		concurrency int
+		failing     map[string]bool
+	}{
+		{name: "no nodes", count: 0, concurrency: 10},
+		{name: "serial", count: 5, concurrency: 1},
+		{name: "invalid concurrency", count: 5, concurrency: 0},
+		{name: "parallel", count: 100, concurrency: 10},
+		{name: "concurrency above node count", count: 3, concurrency: 10},
+		{name: "errors collected", count: 100, concurrency: 10, failing: map[string]bool{"node-7": true, "node-42": true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			nodeNames := make([]string, 0, test.count)
+			for i := 0; i < test.count; i++ {
+				nodeNames = append(nodeNames, fmt.Sprintf("node-%d", i))
+			}
+
+			var mu sync.Mutex
+			patched := make(map[string]int)
+			running, maxRunning := 0, 0
+
+			err := patchNodes(nodeNames, test.concurrency, func(nodeName string) error {
+				mu.Lock()
+				patched[nodeName]++
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				if test.failing[nodeName] {
+					return errors.New("patch failed")
+				}
+				return nil
+			})
+
+			for _, name := range nodeNames {
+				if patched[name] != 1 {
+					t.Errorf("node %s patched %d times", name, patched[name])
+				}
+			}
+
+			limit := test.concurrency
+			if limit < 1 {
+				limit = 1
+			}
+			if maxRunning > limit {
+				t.Errorf("%d patches running in parallel, limit is %d", maxRunning, limit)
+			}
+
+			if len(test.failing) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+
+			patchErrors, ok := err.(*NodePatchErrors)
+			if !ok {
+				t.Fatalf("expected NodePatchErrors, got %v", err)
+			}
+			if len(patchErrors.Errors) != len(test.failing) {
+				t.Errorf("expected %d errors, got %d", len(test.failing), len(patchErrors.Errors))
+			}
+			for name := range test.failing {
+				if patchErrors.Errors[name] == nil {
+					t.Errorf("missing error of node %s", name)
+				}
+			}
+		})
+	}
+}
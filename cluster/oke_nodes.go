@@ -0,0 +1,300 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	"github.com/banzaicloud/pipeline/helm"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+const nodeReadinessPollInterval = 10 * time.Second
+
+// NodesNotReadyError is returned when the nodes of a node pool don't become Ready within the readiness timeout
+type NodesNotReadyError struct {
+	NodePool      string
+	ExpectedCount int
+	ReadyCount    int
+	NotReadyNodes []string
+}
+
+func (e *NodesNotReadyError) Error() string {
+
+	return fmt.Sprintf("node pool %s: %d of %d nodes are Ready, NotReady nodes: [%s]",
+		e.NodePool, e.ReadyCount, e.ExpectedCount, strings.Join(e.NotReadyNodes, ", "))
+}
+
+// getK8sClient creates a new Kubernetes client from the stored kubeconfig
+func (o *OKECluster) getK8sClient() (*kubernetes.Clientset, error) {
+
+	kubeConfig, err := o.GetK8sConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s config")
+	}
+
+	client, err := helm.GetK8sConnection(kubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting k8s client")
+	}
+
+	return client, nil
+}
+
+// nodePoolNodeStatusTTL is how long the node status of the node pools of a cluster is cached, getting it lists the
+// nodes of the cluster several times
+const nodePoolNodeStatusTTL = 30 * time.Second
+
+// nodePoolNodeStatus is the status of the node pools of a cluster which is read from the Kubernetes API
+type nodePoolNodeStatus struct {
+	readyCounts map[string]int
+	drift       map[string][]string
+	warmPools   map[string]*pkgCluster.WarmPoolStatus
+}
+
+type nodePoolNodeStatusCacheEntry struct {
+	status    nodePoolNodeStatus
+	expiresAt time.Time
+}
+
+// nodePoolNodeStatuses caches the node status of the node pools of the OKE clusters keyed by cluster UID
+var nodePoolNodeStatuses = struct {
+	sync.Mutex
+	entries map[string]nodePoolNodeStatusCacheEntry
+}{
+	entries: make(map[string]nodePoolNodeStatusCacheEntry),
+}
+
+// getCachedNodePoolNodeStatus gives back the cached node status of the node pools, it is read from the Kubernetes
+// API again if it is not cached or expired; a status which could not be read completely is not cached
+func (o *OKECluster) getCachedNodePoolNodeStatus() nodePoolNodeStatus {
+
+	nodePoolNodeStatuses.Lock()
+	entry, ok := nodePoolNodeStatuses.entries[o.GetUID()]
+	nodePoolNodeStatuses.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.status
+	}
+
+	var status nodePoolNodeStatus
+	var failed bool
+	var err error
+
+	status.readyCounts, err = o.getNodePoolReadyCounts()
+	if err != nil {
+		log.Warnf("error getting ready node counts: %s", err.Error())
+		failed = true
+	}
+	status.drift, err = o.GetNodePoolConfigDrift()
+	if err != nil {
+		log.Warnf("error getting node pool config drift: %s", err.Error())
+		failed = true
+	}
+	status.warmPools, err = o.getWarmPoolStatus()
+	if err != nil {
+		log.Warnf("error getting warm pool status: %s", err.Error())
+		failed = true
+	}
+
+	if failed || o.GetUID() == "" {
+		return status
+	}
+
+	nodePoolNodeStatuses.Lock()
+	defer nodePoolNodeStatuses.Unlock()
+
+	nodePoolNodeStatuses.entries[o.GetUID()] = nodePoolNodeStatusCacheEntry{
+		status:    status,
+		expiresAt: time.Now().Add(nodePoolNodeStatusTTL),
+	}
+
+	return status
+}
+
+// getNodePoolReadyCounts returns the number of healthy nodes per node pool,
+// NotReady nodes within the node pool's health check grace period are counted as healthy
+func (o *OKECluster) getNodePoolReadyCounts() (map[string]int, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing nodes")
+	}
+
+	counts := make(map[string]int)
+	for _, node := range nodes.Items {
+		np := o.modelCluster.OKE.GetNodePoolByName(node.Labels[pkgCommon.LabelKey])
+		if np.ID == 0 {
+			continue
+		}
+		if isNodeHealthy(&node, time.Duration(np.HealthCheckGracePeriod)*time.Second) {
+			counts[np.Name]++
+		}
+	}
+
+	return counts, nil
+}
+
+// ListUnhealthyNodes returns the names of the nodes in the given node pool which are
+// NotReady and older than the health check grace period of the node pool
+func (o *OKECluster) ListUnhealthyNodes(nodePoolName string) ([]string, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(nodePoolName)
+	if np.ID == 0 {
+		return nil, errors.Errorf("node pool not found: %s", nodePoolName)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + nodePoolName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing nodes")
+	}
+
+	names := make([]string, 0)
+	for _, node := range nodes.Items {
+		if !isNodeHealthy(&node, time.Duration(np.HealthCheckGracePeriod)*time.Second) {
+			names = append(names, node.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// GetNodePoolConfigDrift returns the names of the nodes per node pool which were created with an older
+// instance configuration than the current one of their node pool, these nodes are pending a roll
+func (o *OKECluster) GetNodePoolConfigDrift() (map[string][]string, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing nodes")
+	}
+
+	drift := make(map[string][]string)
+	for _, node := range nodes.Items {
+		np := o.modelCluster.OKE.GetNodePoolByName(node.Labels[pkgCommon.LabelKey])
+		if np.ID == 0 || np.InstanceConfigHash == "" {
+			continue
+		}
+		if node.Labels[modelOracle.InstanceConfigHashLabelKey] != np.InstanceConfigHash {
+			drift[np.Name] = append(drift[np.Name], node.Name)
+		}
+	}
+
+	return drift, nil
+}
+
+// isNodeHealthy returns true if the node is Ready or it is younger than the given grace period
+func isNodeHealthy(node *v1.Node, gracePeriod time.Duration) bool {
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+
+	return time.Since(node.CreationTimestamp.Time) < gracePeriod
+}
+
+// WaitForNodePoolSize waits until the given number of nodes of the node pool are Ready, when the configured
+// node readiness timeout is exceeded a NodesNotReadyError is returned
+func (o *OKECluster) WaitForNodePoolSize(nodePoolName string, size int) error {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	timeout := time.Duration(viper.GetInt(pipConfig.OKENodeReadinessTimeoutSeconds)) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	log := o.getLogger().WithField("nodePool", nodePoolName)
+	log.Infof("Waiting for %d nodes to become Ready", size)
+
+	np := o.modelCluster.OKE.GetNodePoolByName(nodePoolName)
+
+	for {
+		// startup taints are put on the nodes as soon as they are registered
+		if _, err := o.reconcileStartupTaints(client, np); err != nil {
+			log.Warnf("Error reconciling startup taints: %s", err.Error())
+		}
+
+		nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+			LabelSelector: pkgCommon.LabelKey + "=" + nodePoolName,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error listing nodes")
+		}
+
+		readyCount := 0
+		notReady := make([]string, 0)
+		for _, node := range nodes.Items {
+			if isNodeHealthy(&node, 0) {
+				readyCount++
+			} else {
+				notReady = append(notReady, node.Name)
+			}
+		}
+
+		if readyCount >= size {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return &NodesNotReadyError{
+				NodePool:      nodePoolName,
+				ExpectedCount: size,
+				ReadyCount:    readyCount,
+				NotReadyNodes: notReady,
+			}
+		}
+
+		time.Sleep(nodeReadinessPollInterval)
+	}
+}
+
+// waitForNodePools waits for the nodes of the given node pools to become Ready and their startup taints removed
+func (o *OKECluster) waitForNodePools(nodePools []*modelOracle.NodePool) error {
+
+	for _, np := range nodePools {
+		if np == nil || np.Delete {
+			continue
+		}
+		if err := o.WaitForNodePoolSize(np.Name, getNodeCount(np)); err != nil {
+			return err
+		}
+		if err := o.waitForStartupTaints(np); err != nil {
+			return err
+		}
+		if err := o.syncWarmPool(np); err != nil {
+			return err
+		}
+		o.addProgressEvent(ProgressPhaseNodePool, "node pool %s is ready with %d node(s)", np.Name, getNodeCount(np))
+	}
+
+	return nil
+}
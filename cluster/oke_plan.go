@@ -0,0 +1,253 @@
+package cluster
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+// Plan computes the ordered actions needed to reach the desired state without applying them
+func (o *OKECluster) Plan(desired *pkgCluster.ClusterSpec) (*pkgCluster.ReconcilePlan, error) {
+
+	if desired == nil || len(desired.NodePools) == 0 {
+		return nil, errors.New("at least one node pool must be specified in the desired state")
+	}
+
+	fingerprint, err := o.getPlanFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &pkgCluster.ReconcilePlan{
+		Desired:     desired,
+		Actions:     make([]pkgCluster.ReconcileAction, 0),
+		Fingerprint: fingerprint,
+	}
+
+	current := o.modelCluster.OKE
+
+	if desired.MasterVersion != "" && desired.MasterVersion != current.Version {
+		plan.Actions = append(plan.Actions, pkgCluster.ReconcileAction{
+			Type: pkgCluster.ActionUpgradeControlPlane,
+			From: current.Version,
+			To:   desired.MasterVersion,
+		})
+	}
+
+	names := make([]string, 0, len(desired.NodePools))
+	for name := range desired.NodePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var adds, scaleUps, upgrades, replaces, scaleDowns, deletes []pkgCluster.ReconcileAction
+	for _, name := range names {
+		spec := desired.NodePools[name]
+		np := current.GetNodePoolByName(name)
+		if np.ID == 0 {
+			adds = append(adds, pkgCluster.ReconcileAction{
+				Type:     pkgCluster.ActionAddNodePool,
+				NodePool: name,
+				To:       strconv.Itoa(spec.Count),
+			})
+			continue
+		}
+
+		// shape and image of an OKE node pool cannot be changed, the pool must be recreated
+		if (spec.InstanceType != "" && spec.InstanceType != np.Shape) || (spec.Image != "" && spec.Image != np.Image) {
+			replaces = append(replaces, pkgCluster.ReconcileAction{
+				Type:       pkgCluster.ActionReplaceNodePool,
+				NodePool:   name,
+				From:       fmt.Sprintf("%s/%s", np.Shape, np.Image),
+				To:         fmt.Sprintf("%s/%s", spec.InstanceType, spec.Image),
+				Disruptive: true,
+			})
+			continue
+		}
+
+		if spec.Version != "" && spec.Version != np.Version {
+			upgrades = append(upgrades, pkgCluster.ReconcileAction{
+				Type:     pkgCluster.ActionUpgradeNodePool,
+				NodePool: name,
+				From:     np.Version,
+				To:       spec.Version,
+			})
+		}
+
+		if count := getNodeCount(np); spec.Count != count {
+			action := pkgCluster.ReconcileAction{
+				Type:     pkgCluster.ActionScaleNodePool,
+				NodePool: name,
+				From:     strconv.Itoa(count),
+				To:       strconv.Itoa(spec.Count),
+			}
+			if spec.Count < count {
+				action.Disruptive = true
+				scaleDowns = append(scaleDowns, action)
+			} else {
+				scaleUps = append(scaleUps, action)
+			}
+		}
+	}
+
+	for _, np := range current.NodePools {
+		if np != nil && desired.NodePools[np.Name] == nil {
+			deletes = append(deletes, pkgCluster.ReconcileAction{
+				Type:       pkgCluster.ActionDeleteNodePool,
+				NodePool:   np.Name,
+				From:       strconv.Itoa(getNodeCount(np)),
+				Disruptive: true,
+			})
+		}
+	}
+
+	// capacity is added before it is removed
+	for _, actions := range [][]pkgCluster.ReconcileAction{adds, scaleUps, upgrades, replaces, scaleDowns, deletes} {
+		plan.Actions = append(plan.Actions, actions...)
+	}
+
+	return plan, nil
+}
+
+// Reconcile executes the actions of the plan in their order, the plan is refused if the cluster has changed
+// since it was made
+func (o *OKECluster) Reconcile(plan *pkgCluster.ReconcilePlan, userId uint) error {
+
+	if plan == nil || plan.Desired == nil {
+		return errors.New("empty reconcile plan")
+	}
+
+	fingerprint, err := o.getPlanFingerprint()
+	if err != nil {
+		return err
+	}
+	if plan.Fingerprint != fingerprint {
+		return errors.New("the cluster has changed since the plan was made")
+	}
+
+	for _, action := range plan.Actions {
+		err := o.applyReconcileAction(action, plan.Desired, userId)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error executing %s action", action.Type))
+		}
+	}
+
+	return nil
+}
+
+// applyReconcileAction applies a single action to the cluster, the update request is created from the stored
+// state of the cluster so only the fields changed by the action are overwritten
+func (o *OKECluster) applyReconcileAction(action pkgCluster.ReconcileAction, desired *pkgCluster.ClusterSpec, userId uint) error {
+
+	request := o.modelCluster.OKE.GetClusterRequestFromModel()
+	spec := desired.NodePools[action.NodePool]
+	current := request.NodePools[action.NodePool]
+
+	if action.NodePool != "" && action.Type != pkgCluster.ActionDeleteNodePool && spec == nil {
+		return fmt.Errorf("node pool %s is not in the desired state", action.NodePool)
+	}
+	if action.NodePool != "" && action.Type != pkgCluster.ActionAddNodePool && current == nil {
+		return fmt.Errorf("node pool %s is not found", action.NodePool)
+	}
+
+	switch action.Type {
+	case pkgCluster.ActionUpgradeControlPlane:
+		request.Version = desired.MasterVersion
+	case pkgCluster.ActionAddNodePool:
+		nodePool := &oracle.NodePool{Version: request.Version}
+		applyNodePoolSpec(nodePool, spec)
+		request.NodePools[action.NodePool] = nodePool
+	case pkgCluster.ActionUpgradeNodePool:
+		current.Version = spec.Version
+	case pkgCluster.ActionScaleNodePool:
+		current.Count = uint(spec.Count)
+	case pkgCluster.ActionReplaceNodePool:
+		// the node pool is removed first, then created again with its current settings and the desired state
+		replacement := *current
+		applyNodePoolSpec(&replacement, spec)
+
+		delete(request.NodePools, action.NodePool)
+		if len(request.NodePools) == 0 {
+			return errors.New("cannot replace the only node pool of the cluster")
+		}
+		err := o.UpdateCluster(context.Background(), o.createUpdateRequest(request), userId)
+		if err != nil {
+			return errors.WithMessage(err, "error removing node pool to replace")
+		}
+
+		request = o.modelCluster.OKE.GetClusterRequestFromModel()
+		request.NodePools[action.NodePool] = &replacement
+	case pkgCluster.ActionDeleteNodePool:
+		delete(request.NodePools, action.NodePool)
+	default:
+		return fmt.Errorf("unknown action: %s", action.Type)
+	}
+
+	return o.UpdateCluster(context.Background(), o.createUpdateRequest(request), userId)
+}
+
+// applyNodePoolSpec overwrites the fields of the node pool set in the spec
+func applyNodePoolSpec(nodePool *oracle.NodePool, spec *pkgCluster.NodePoolSpec) {
+
+	nodePool.Count = uint(spec.Count)
+	if spec.Version != "" {
+		nodePool.Version = spec.Version
+	}
+	if spec.InstanceType != "" {
+		nodePool.Shape = spec.InstanceType
+	}
+	if spec.Image != "" {
+		nodePool.Image = spec.Image
+	}
+	if spec.Labels != nil {
+		nodePool.Labels = spec.Labels
+	}
+}
+
+// createUpdateRequest wraps the cluster request into an update request
+func (o *OKECluster) createUpdateRequest(request *oracle.Cluster) *pkgCluster.UpdateClusterRequest {
+
+	r := &pkgCluster.UpdateClusterRequest{
+		Cloud: pkgCluster.Oracle,
+		UpdateProperties: pkgCluster.UpdateProperties{
+			OKE: request,
+		},
+	}
+	r.UpdateProperties.OKE.AddDefaults()
+
+	return r
+}
+
+// getPlanFingerprint gives back the hash of the stored state of the cluster, it identifies the state a plan
+// was made from
+func (o *OKECluster) getPlanFingerprint() (string, error) {
+
+	raw, err := json.Marshal(o.modelCluster.OKE.GetClusterRequestFromModel())
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha1.Sum(raw)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getNodePoolLabels gets the labels of the node pool from the model
+func (o *OKECluster) getNodePoolLabels(name string) map[string]string {
+
+	request := o.modelCluster.OKE.GetClusterRequestFromModel()
+	if np, ok := request.NodePools[name]; ok {
+		return np.Labels
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// CheckOCIPolicies gives back the IAM policy statements pipeline needs to manage the cluster which are missing for
+// the user of the cluster's secret
+func (o *OKECluster) CheckOCIPolicies() ([]string, error) {
+
+	OCI, err := o.GetOCI()
+	if err != nil {
+		return nil, err
+	}
+
+	return OCI.CheckRequiredPolicies()
+}
+
+// diagnoseAuthorizationError adds the missing IAM policy statements to the error if it is caused by a missing
+// authorization, the error is given back unchanged if the policies cannot be checked
+func (o *OKECluster) diagnoseAuthorizationError(err error) error {
+
+	if !oci.IsNotAuthorizedError(errors.Cause(err)) {
+		return err
+	}
+
+	missing, checkErr := o.CheckOCIPolicies()
+	if checkErr != nil {
+		o.getLogger().Warnf("error checking OCI policies: %s", checkErr.Error())
+		return err
+	}
+
+	if len(missing) == 0 {
+		return err
+	}
+
+	return errors.WithMessage(err, "the following OCI policies may be missing: "+strings.Join(missing, "; "))
+}
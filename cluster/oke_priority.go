@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	schedulingv1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// applyPriorityClasses creates the PriorityClasses of the given node pools, an existing PriorityClass is
+// reused if its value matches the one of the node pool; the PriorityClasses created for node pools which are
+// deleted or don't use them anymore are removed
+func (o *OKECluster) applyPriorityClasses(nodePools []*modelOracle.NodePool) error {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, np := range nodePools {
+		if np == nil || np.Delete || np.PriorityClassName == "" {
+			continue
+		}
+		used[np.PriorityClassName] = true
+
+		existing, err := client.SchedulingV1alpha1().PriorityClasses().Get(np.PriorityClassName, metav1.GetOptions{})
+		if err == nil {
+			if existing.Value != np.PriorityClassValue {
+				return errors.Errorf("PriorityClass %s of node pool %s already exists with value %d", np.PriorityClassName, np.Name, existing.Value)
+			}
+			continue
+		}
+		if !k8sErrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error getting PriorityClass %s", np.PriorityClassName)
+		}
+
+		_, err = client.SchedulingV1alpha1().PriorityClasses().Create(&schedulingv1alpha1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: np.PriorityClassName,
+				Labels: map[string]string{
+					oracle.PriorityClassLabelKey: np.PriorityClassName,
+				},
+			},
+			Value:       np.PriorityClassValue,
+			Description: getPriorityClassDescription(np),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error creating PriorityClass %s", np.PriorityClassName)
+		}
+
+		o.getLogger().WithField("nodePool", np.Name).Infof("PriorityClass %s created", np.PriorityClassName)
+	}
+
+	// only the PriorityClasses created by Pipeline are labelled
+	classes, err := client.SchedulingV1alpha1().PriorityClasses().List(metav1.ListOptions{
+		LabelSelector: oracle.PriorityClassLabelKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing PriorityClasses")
+	}
+
+	for _, class := range classes.Items {
+		if used[class.Name] {
+			continue
+		}
+
+		err = client.SchedulingV1alpha1().PriorityClasses().Delete(class.Name, &metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting PriorityClass %s", class.Name)
+		}
+
+		o.getLogger().Infof("PriorityClass %s deleted", class.Name)
+	}
+
+	return nil
+}
+
+// getPriorityClassDescription gives back the description of the PriorityClass of the node pool, a default one
+// is generated if it isn't given
+func getPriorityClassDescription(np *modelOracle.NodePool) string {
+
+	if np.PriorityClassDescription != "" {
+		return np.PriorityClassDescription
+	}
+
+	return "Workloads of the " + np.Name + " node pool, target its nodes with the " + oracle.PriorityClassLabelKey + "=" + np.PriorityClassName + " node label"
+}
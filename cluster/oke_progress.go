@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// Phases of the progress events
+const (
+	ProgressPhaseNetwork      = "Network"
+	ProgressPhaseControlPlane = "ControlPlane"
+	ProgressPhaseNodePool     = "NodePool"
+	ProgressPhaseConfigure    = "Configure"
+	ProgressPhaseCompleted    = "Completed"
+	ProgressPhaseFailed       = "Failed"
+)
+
+// AddProgressEvent stores a step of the current operation of the cluster, the status of the cluster is not changed
+func (o *OKECluster) AddProgressEvent(phase, message string) error {
+
+	if o.modelCluster.OKE.ID == 0 {
+		return errors.Errorf("cluster %s is not saved yet", o.modelCluster.Name)
+	}
+
+	event := modelOracle.ProgressEvent{
+		ClusterID: o.modelCluster.OKE.ID,
+		Phase:     phase,
+		Message:   message,
+	}
+
+	return errors.Wrap(event.Create(), "error saving progress event")
+}
+
+// SetDetailsProgressEvents sets the number of the latest progress events included in the cluster details,
+// no events are included if zero
+func (o *OKECluster) SetDetailsProgressEvents(limit int) {
+
+	o.detailsProgressEvents = limit
+}
+
+// GetProgressEvents gives back the latest progress events of the cluster, newest first
+func (o *OKECluster) GetProgressEvents(limit int) ([]pkgCluster.ProgressEvent, error) {
+
+	stored, err := modelOracle.GetProgressEvents(o.modelCluster.OKE.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]pkgCluster.ProgressEvent, 0, len(stored))
+	for _, e := range stored {
+		events = append(events, pkgCluster.ProgressEvent{
+			Phase:     e.Phase,
+			Message:   e.Message,
+			Timestamp: e.CreatedAt,
+		})
+	}
+
+	return events, nil
+}
+
+// addProgressEvent stores a progress event, failing to store it does not fail the operation
+func (o *OKECluster) addProgressEvent(phase, format string, args ...interface{}) {
+
+	if err := o.AddProgressEvent(phase, fmt.Sprintf(format, args...)); err != nil {
+		o.getLogger().Warnf("error adding progress event: %s", err.Error())
+	}
+}
@@ -0,0 +1,158 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const quiescePollInterval = 5 * time.Second
+
+// quiesceSkippedNamespaces are the system namespaces whose pods are left running, the cluster needs them
+// until it is deleted
+var quiesceSkippedNamespaces = map[string]bool{
+	metav1.NamespaceSystem: true,
+	metav1.NamespacePublic: true,
+}
+
+// SetQuiesceOnDelete makes PrepareDelete quiesce the cluster with the given grace period before deleting it
+func (o *OKECluster) SetQuiesceOnDelete(gracePeriod time.Duration) {
+
+	o.quiesceGracePeriod = gracePeriod
+}
+
+// QuiesceCluster cordons all nodes and evicts the workloads outside the system namespaces (respecting
+// PodDisruptionBudgets), then waits for the evicted pods to terminate so StatefulSets can flush their data
+func (o *OKECluster) QuiesceCluster(ctx context.Context, gracePeriod time.Duration) error {
+
+	log := o.getLogger()
+
+	ctx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error listing nodes")
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable {
+			continue
+		}
+		node.Spec.Unschedulable = true
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return errors.Wrapf(err, "error cordoning node %s", node.Name)
+		}
+		log.WithField("node", node.Name).Info("node cordoned")
+	}
+
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error listing pods")
+	}
+
+	evicted := make([]v1.Pod, 0)
+	for _, pod := range pods.Items {
+		if !isEvictable(&pod) || quiesceSkippedNamespaces[pod.Namespace] {
+			continue
+		}
+		if err := evictPod(ctx, client, &pod, nil); err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{"namespace": pod.Namespace, "pod": pod.Name}).Debug("pod evicted")
+		evicted = append(evicted, pod)
+	}
+
+	log.Infof("%d pods evicted, waiting for them to terminate", len(evicted))
+
+	return waitForPodsDeleted(ctx, client, evicted)
+}
+
+// isEvictable returns false for pods which wouldn't be drained: DaemonSet managed, mirror and finished pods
+func isEvictable(pod *v1.Pod) bool {
+
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evictPod evicts the pod through the eviction API, it retries while a PodDisruptionBudget blocks the eviction,
+// the termination grace period of the pod is used if gracePeriodSeconds is nil
+func evictPod(ctx context.Context, client *kubernetes.Clientset, pod *v1.Pod, gracePeriodSeconds *int64) error {
+
+	eviction := &policy.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if gracePeriodSeconds != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}
+	}
+
+	for {
+		err := client.CoreV1().Pods(pod.Namespace).Evict(eviction)
+		if err == nil || k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		if !k8sErrors.IsTooManyRequests(err) {
+			return errors.Wrapf(err, "error evicting pod %s/%s", pod.Namespace, pod.Name)
+		}
+
+		// eviction is not allowed by a PodDisruptionBudget yet
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "pod %s/%s could not be evicted", pod.Namespace, pod.Name)
+		case <-time.After(quiescePollInterval):
+		}
+	}
+}
+
+// waitForPodsDeleted waits until the given pods are deleted
+func waitForPodsDeleted(ctx context.Context, client *kubernetes.Clientset, pods []v1.Pod) error {
+
+	for _, pod := range pods {
+		for {
+			p, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+			if k8sErrors.IsNotFound(err) || (err == nil && p.UID != pod.UID) {
+				break
+			}
+			if err != nil {
+				return errors.Wrapf(err, "error getting pod %s/%s", pod.Namespace, pod.Name)
+			}
+
+			select {
+			case <-ctx.Done():
+				return errors.Wrapf(ctx.Err(), "pod %s/%s did not terminate", pod.Namespace, pod.Name)
+			case <-time.After(quiescePollInterval):
+			}
+		}
+	}
+
+	return nil
+}
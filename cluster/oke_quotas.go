@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+const defaultResourceQuotaName = "pipeline-default-quota"
+
+// applyResourceQuotas creates the namespaces and applies the default ResourceQuotas/LimitRanges on them
+func (o *OKECluster) applyResourceQuotas(quotas map[string]*oracle.ResourceQuota) error {
+
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	for namespace, quota := range quotas {
+		o.getLogger().WithFields(logrus.Fields{"namespace": namespace}).Info("Applying resource quota")
+
+		err = ensureNamespace(client, namespace)
+		if err != nil {
+			return err
+		}
+
+		err = applyResourceQuota(client, namespace, quota)
+		if err != nil {
+			return err
+		}
+
+		err = applyLimitRange(client, namespace, quota)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureNamespace creates the namespace if it doesn't exist
+func ensureNamespace(client *kubernetes.Clientset, namespace string) error {
+
+	_, err := client.CoreV1().Namespaces().Create(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	})
+	if err != nil && !k8sErrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "error creating namespace %s", namespace)
+	}
+
+	return nil
+}
+
+// applyResourceQuota creates or updates the default ResourceQuota of the namespace
+func applyResourceQuota(client *kubernetes.Clientset, namespace string, quota *oracle.ResourceQuota) error {
+
+	hard := v1.ResourceList{}
+	if quota.CPU != "" {
+		hard[v1.ResourceLimitsCPU] = resource.MustParse(quota.CPU)
+	}
+	if quota.Memory != "" {
+		hard[v1.ResourceLimitsMemory] = resource.MustParse(quota.Memory)
+	}
+
+	if len(hard) == 0 {
+		return nil
+	}
+
+	resourceQuota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultResourceQuotaName,
+			Namespace: namespace,
+		},
+		Spec: v1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+
+	_, err := client.CoreV1().ResourceQuotas(namespace).Create(resourceQuota)
+	if k8sErrors.IsAlreadyExists(err) {
+		_, err = client.CoreV1().ResourceQuotas(namespace).Update(resourceQuota)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error applying resource quota in namespace %s", namespace)
+	}
+
+	return nil
+}
+
+// applyLimitRange creates or updates the default container LimitRange of the namespace,
+// pods without limits would be rejected in a namespace with limit quotas
+func applyLimitRange(client *kubernetes.Clientset, namespace string, quota *oracle.ResourceQuota) error {
+
+	defaults := v1.ResourceList{}
+	if quota.DefaultCPU != "" {
+		defaults[v1.ResourceCPU] = resource.MustParse(quota.DefaultCPU)
+	}
+	if quota.DefaultMemory != "" {
+		defaults[v1.ResourceMemory] = resource.MustParse(quota.DefaultMemory)
+	}
+
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	limitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultResourceQuotaName,
+			Namespace: namespace,
+		},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{
+				{
+					Type:           v1.LimitTypeContainer,
+					Default:        defaults,
+					DefaultRequest: defaults,
+				},
+			},
+		},
+	}
+
+	_, err := client.CoreV1().LimitRanges(namespace).Create(limitRange)
+	if k8sErrors.IsAlreadyExists(err) {
+		_, err = client.CoreV1().LimitRanges(namespace).Update(limitRange)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error applying limit range in namespace %s", namespace)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package cluster
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/rbac/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const (
+	rbacManagedByLabel = "app.kubernetes.io/managed-by"
+	rbacManagedByValue = "pipeline"
+)
+
+// rbacManagedLabels are put on every RBAC binding pipeline creates
+var rbacManagedLabels = map[string]string{
+	rbacManagedByLabel: rbacManagedByValue,
+}
+
+// GetRBACBindings lists the ClusterRoleBindings and RoleBindings managed by pipeline with their subjects and roles
+func (o *OKECluster) GetRBACBindings() ([]pkgCluster.RBACBinding, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: rbacManagedByLabel + "=" + rbacManagedByValue,
+	}
+
+	clusterRoleBindings, err := client.RbacV1beta1().ClusterRoleBindings().List(listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing cluster role bindings")
+	}
+
+	bindings := make([]pkgCluster.RBACBinding, 0)
+	found := false
+	for _, crb := range clusterRoleBindings.Items {
+		if crb.Name == clusterCreatorAdminRight {
+			found = true
+		}
+		bindings = append(bindings, newRBACBinding("ClusterRoleBinding", crb.ObjectMeta, crb.RoleRef, crb.Subjects))
+	}
+
+	// the cluster creator binding of clusters created before labeling isn't labeled
+	if !found {
+		crb, err := client.RbacV1beta1().ClusterRoleBindings().Get(clusterCreatorAdminRight, metav1.GetOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "error getting cluster role binding")
+		}
+		if err == nil {
+			bindings = append(bindings, newRBACBinding("ClusterRoleBinding", crb.ObjectMeta, crb.RoleRef, crb.Subjects))
+		}
+	}
+
+	roleBindings, err := client.RbacV1beta1().RoleBindings(metav1.NamespaceAll).List(listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing role bindings")
+	}
+
+	for _, rb := range roleBindings.Items {
+		bindings = append(bindings, newRBACBinding("RoleBinding", rb.ObjectMeta, rb.RoleRef, rb.Subjects))
+	}
+
+	return bindings, nil
+}
+
+func newRBACBinding(kind string, meta metav1.ObjectMeta, roleRef v1beta1.RoleRef, subjects []v1beta1.Subject) pkgCluster.RBACBinding {
+
+	binding := pkgCluster.RBACBinding{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		RoleKind:  roleRef.Kind,
+		RoleName:  roleRef.Name,
+		Subjects:  make([]pkgCluster.RBACSubject, 0, len(subjects)),
+	}
+
+	for _, subject := range subjects {
+		binding.Subjects = append(binding.Subjects, pkgCluster.RBACSubject{
+			Kind:      subject.Kind,
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+		})
+	}
+
+	return binding
+}
+
+// ExportRBACBundle serializes the RBAC bindings managed by pipeline into a bundle which can be imported into other
+// clusters, the binding of the cluster creator is specific to the cluster and left out
+func (o *OKECluster) ExportRBACBundle() ([]byte, error) {
+
+	bindings, err := o.GetRBACBindings()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := pkgCluster.RBACBundle{
+		Version:  pkgCluster.RBACBundleVersion,
+		Bindings: make([]pkgCluster.RBACBinding, 0, len(bindings)),
+	}
+	for _, binding := range bindings {
+		if binding.Kind == "ClusterRoleBinding" && binding.Name == clusterCreatorAdminRight {
+			continue
+		}
+		bundle.Bindings = append(bundle.Bindings, binding)
+	}
+
+	return json.Marshal(bundle)
+}
+
+// ImportRBACBundle creates or updates the RBAC bindings of the bundle in the cluster as pipeline managed bindings
+func (o *OKECluster) ImportRBACBundle(raw []byte) error {
+
+	var bundle pkgCluster.RBACBundle
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return errors.Wrap(err, "error parsing RBAC bundle")
+	}
+
+	if bundle.Version != pkgCluster.RBACBundleVersion {
+		return errors.Errorf("unsupported RBAC bundle version: %q", bundle.Version)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	for _, binding := range bundle.Bindings {
+		if binding.Name == clusterCreatorAdminRight {
+			continue
+		}
+
+		meta := metav1.ObjectMeta{
+			Name:      binding.Name,
+			Namespace: binding.Namespace,
+			Labels:    rbacManagedLabels,
+		}
+		roleRef := v1beta1.RoleRef{
+			APIGroup: v1beta1.GroupName,
+			Kind:     binding.RoleKind,
+			Name:     binding.RoleName,
+		}
+		subjects := make([]v1beta1.Subject, 0, len(binding.Subjects))
+		for _, subject := range binding.Subjects {
+			s := v1beta1.Subject{
+				Kind:      subject.Kind,
+				Name:      subject.Name,
+				Namespace: subject.Namespace,
+			}
+			if subject.Kind != v1beta1.ServiceAccountKind {
+				s.APIGroup = v1beta1.GroupName
+			}
+			subjects = append(subjects, s)
+		}
+
+		switch binding.Kind {
+		case "ClusterRoleBinding":
+			crb := &v1beta1.ClusterRoleBinding{ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects}
+			_, err = client.RbacV1beta1().ClusterRoleBindings().Create(crb)
+			if k8sErrors.IsAlreadyExists(err) {
+				_, err = client.RbacV1beta1().ClusterRoleBindings().Update(crb)
+			}
+		case "RoleBinding":
+			if binding.Namespace == "" {
+				return errors.Errorf("namespace of role binding %s must be specified", binding.Name)
+			}
+			if err = ensureNamespace(client, binding.Namespace); err != nil {
+				return err
+			}
+			rb := &v1beta1.RoleBinding{ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects}
+			_, err = client.RbacV1beta1().RoleBindings(binding.Namespace).Create(rb)
+			if k8sErrors.IsAlreadyExists(err) {
+				_, err = client.RbacV1beta1().RoleBindings(binding.Namespace).Update(rb)
+			}
+		default:
+			return errors.Errorf("invalid kind of RBAC binding %s: %s", binding.Name, binding.Kind)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error applying %s %s", binding.Kind, binding.Name)
+		}
+
+		o.getLogger().Infof("%s %s imported", binding.Kind, binding.Name)
+	}
+
+	return nil
+}
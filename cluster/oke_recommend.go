@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+const (
+	// recommendationWindow is the period of the metric samples the recommendations are based on
+	recommendationWindow = 7 * 24 * time.Hour
+	// minRecommendationSamples is the number of samples needed to recommend scaling a node pool
+	minRecommendationSamples = 12
+
+	// the node pools are sized for the target utilization when they are out of the utilization bounds
+	targetUtilization    = 0.7
+	scaleUpUtilization   = 0.8
+	scaleDownUtilization = 0.4
+)
+
+// RecommendScaling analyzes the utilization of the node pools in the last week and recommends scaling the node pools
+// which are over or under utilized, scale ups are limited by the headroom of the subnets of the node pool
+func (o *OKECluster) RecommendScaling() ([]pkgCluster.ScalingRecommendation, error) {
+
+	recommendations := make([]pkgCluster.ScalingRecommendation, 0)
+
+	to := time.Now()
+	from := to.Add(-recommendationWindow)
+
+	for _, np := range o.modelCluster.OKE.NodePools {
+		samples, err := modelOracle.GetNodePoolMetricSamples(o.modelCluster.OKE.ID, np.Name, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(samples) < minRecommendationSamples {
+			o.getLogger().WithField("nodePool", np.Name).Debugf("not enough metric samples for a recommendation: %d", len(samples))
+			continue
+		}
+
+		current := int(np.GetActiveNodeCount())
+		utilization := getPeakUtilization(samples)
+		recommended := getRecommendedCount(current, utilization)
+
+		minCount, maxCount := 1, 0
+		if np.Autoscaling {
+			minCount, maxCount = np.MinCount, np.MaxCount
+		}
+		if recommended < minCount {
+			recommended = minCount
+		}
+		if maxCount > 0 && recommended > maxCount {
+			recommended = maxCount
+		}
+
+		if recommended == current {
+			continue
+		}
+
+		r := pkgCluster.ScalingRecommendation{
+			NodePool:         np.Name,
+			CurrentCount:     current,
+			RecommendedCount: recommended,
+			Utilization:      utilization,
+		}
+
+		if recommended > current {
+			r.Action = pkgCluster.ScaleUp
+			r.Rationale = fmt.Sprintf("peak utilization %.0f%% is above %.0f%%", utilization*100, scaleUpUtilization*100)
+
+			headroom, err := o.GetNodePoolHeadroom(np.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			free := 0
+			for _, h := range headroom {
+				free += h
+			}
+			if recommended-current > free {
+				r.RecommendedCount = current + free
+				r.HeadroomLimited = true
+				r.Rationale += fmt.Sprintf(", the subnets have room for %d more nodes only", free)
+			}
+			if r.RecommendedCount == current {
+				continue
+			}
+		} else {
+			r.Action = pkgCluster.ScaleDown
+			r.Rationale = fmt.Sprintf("peak utilization %.0f%% is below %.0f%%", utilization*100, scaleDownUtilization*100)
+		}
+		r.Rationale += fmt.Sprintf(", %d nodes give %.0f%% utilization", r.RecommendedCount, targetUtilization*100)
+
+		recommendations = append(recommendations, r)
+	}
+
+	return recommendations, nil
+}
+
+// getPeakUtilization gives back the 95th percentile of the CPU or memory utilization of the samples, whichever
+// is higher, so short spikes don't drive the recommendations
+func getPeakUtilization(samples []modelOracle.NodePoolMetricSample) float64 {
+
+	utilizations := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		u := 0.0
+		if s.CPUAllocatable > 0 {
+			u = float64(s.CPUUsage) / float64(s.CPUAllocatable)
+		}
+		if s.MemoryAllocatable > 0 {
+			u = math.Max(u, float64(s.MemoryUsage)/float64(s.MemoryAllocatable))
+		}
+		utilizations = append(utilizations, u)
+	}
+
+	if len(utilizations) == 0 {
+		return 0
+	}
+
+	sort.Float64s(utilizations)
+
+	return utilizations[int(math.Ceil(0.95*float64(len(utilizations))))-1]
+}
+
+// getRecommendedCount gives back the node count which gives the target utilization if the utilization is out of
+// the scale up and scale down bounds, otherwise the current count
+func getRecommendedCount(current int, utilization float64) int {
+
+	if utilization <= scaleUpUtilization && utilization >= scaleDownUtilization {
+		return current
+	}
+
+	return int(math.Ceil(float64(current) * utilization / targetUtilization))
+}
@@ -0,0 +1,167 @@
+package cluster
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+// OCI cloud controller manager sets the instance OCID as provider ID with this prefix
+const ociProviderIDPrefix = "oci://"
+
+// RollNodePool replaces the nodes of the node pool which were created with an older instance configuration,
+// UpdateBatchSize nodes of the pool are drained and terminated at the same time, then the roll waits for OKE
+// to replace them with Ready nodes before continuing with the next batch
+func (o *OKECluster) RollNodePool(name string) (err error) {
+
+	defer func() { o.emitEvent(EventNodePoolRolled, name, err, nil) }()
+
+	log := o.getLogger().WithField("nodePool", name)
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return errors.Errorf("node pool not found: %s", name)
+	}
+
+	size := getNodeCount(np)
+	batchSize, err := oracle.ParseUpdateBatchSize(np.UpdateBatchSize, uint(size))
+	if err != nil {
+		return err
+	}
+
+	drift, err := o.GetNodePoolConfigDrift()
+	if err != nil {
+		return err
+	}
+
+	nodes := drift[name]
+	if len(nodes) == 0 {
+		log.Info("There are no nodes pending a roll")
+		return nil
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	compute, err := OCI.NewComputeClient()
+	if err != nil {
+		return err
+	}
+
+	gracePeriod := time.Duration(viper.GetInt(pipConfig.OKENodeReadinessTimeoutSeconds)) * time.Second
+
+	for start := 0; start < len(nodes); start += int(batchSize) {
+		end := start + int(batchSize)
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		batch := nodes[start:end]
+
+		log.Infof("Rolling nodes: %s", strings.Join(batch, ", "))
+
+		for _, nodeName := range batch {
+			node, err := client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "error getting node %s", nodeName)
+			}
+
+			if err := o.drainNode(client, node, gracePeriod); err != nil {
+				return err
+			}
+
+			if err := compute.TerminateInstance(context.Background(), strings.TrimPrefix(node.Spec.ProviderID, ociProviderIDPrefix)); err != nil {
+				return errors.Wrapf(err, "error terminating instance of node %s", nodeName)
+			}
+
+			o.emitEvent(EventNodeReplaced, name, nil, map[string]string{"node": nodeName})
+		}
+
+		// the terminated nodes would be counted as Ready until they are removed
+		if err := waitForNodesDeleted(client, batch, gracePeriod); err != nil {
+			return err
+		}
+
+		if err := o.WaitForNodePoolSize(name, size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// drainNode cordons the node and evicts its pods, respecting PodDisruptionBudgets
+func (o *OKECluster) drainNode(client *kubernetes.Clientset, node *v1.Node, gracePeriod time.Duration) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return errors.Wrapf(err, "error cordoning node %s", node.Name)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing pods")
+	}
+
+	evicted := make([]v1.Pod, 0)
+	for _, pod := range pods.Items {
+		if !isEvictable(&pod) {
+			continue
+		}
+		if err := evictPod(ctx, client, &pod, nil); err != nil {
+			return err
+		}
+		evicted = append(evicted, pod)
+	}
+
+	return waitForPodsDeleted(ctx, client, evicted)
+}
+
+// waitForNodesDeleted waits until the given nodes are removed from the cluster
+func waitForNodesDeleted(client *kubernetes.Clientset, nodeNames []string, timeout time.Duration) error {
+
+	deadline := time.Now().Add(timeout)
+
+	for _, nodeName := range nodeNames {
+		for {
+			_, err := client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+			if k8sErrors.IsNotFound(err) {
+				break
+			}
+			if err != nil {
+				return errors.Wrapf(err, "error getting node %s", nodeName)
+			}
+			if time.Now().After(deadline) {
+				return errors.Errorf("node %s was not removed", nodeName)
+			}
+
+			time.Sleep(nodeReadinessPollInterval)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+)
+
+// maxSubnetExpansions limits the number of worker subnets added to the VCN by a single scaling
+const maxSubnetExpansions = 3
+
+// ScaleNodePool scales the given node pool to count nodes, returns the new node count which may differ from
+// the requested one since the nodes are distributed evenly over the worker subnets.
+// When the worker subnets cannot accommodate the nodes and subnet auto expansion is enabled, new worker subnets
+// are added to the VCN and the node pool is extended onto them.
+// Nodes of the warm pool are activated first, the warm pool is refilled with the newly provisioned nodes.
+// The count of an autoscaled node pool must be within its autoscaling bounds.
+// The node pool is left unchanged if the scaling fails, the added worker subnets are removed unless the node pool
+// was already extended onto them.
+func (o *OKECluster) ScaleNodePool(ctx context.Context, name string, count uint) (scaled uint, err error) {
+
+	defer func() {
+		o.emitEvent(EventNodePoolScaled, name, err, map[string]string{
+			"requestedCount": strconv.Itoa(int(count)),
+			"count":          strconv.Itoa(int(scaled)),
+		})
+	}()
+
+	np := o.modelCluster.OKE.GetNodePoolByName(name)
+	if np.ID == 0 {
+		return 0, errors.Errorf("node pool not found: %s", name)
+	}
+	if np.Delete {
+		return 0, errors.Errorf("node pool %s is marked for deletion", name)
+	}
+	if np.Autoscaling {
+		minCount, maxCount := np.GetNodeCountBounds()
+		if int(count) < minCount || (maxCount > 0 && int(count) > maxCount) {
+			return 0, &invalidError{errors.Errorf("node count of node pool %s must be between the autoscaling bounds %d and %d: %d", name, minCount, maxCount, count)}
+		}
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return 0, err
+	}
+
+	m := network.NewVCNManager(OCI)
+	networkValues, err := m.GetNetworkValues(o.modelCluster.OKE.VCNID)
+	if err != nil {
+		return 0, err
+	}
+
+	if activeCount := np.GetActiveNodeCount(); count > activeCount && np.WarmPoolSize > 0 {
+		if _, err := o.activateWarmNodes(np, int(count-activeCount)); err != nil {
+			return 0, errors.WithMessage(err, "error activating warm pool nodes")
+		}
+	}
+
+	quantityPerSubnet, subnets := np.QuantityPerSubnet, np.Subnets
+	addedSubnetIDs := make([]string, 0)
+	applied := false
+	defer func() {
+		if err == nil {
+			return
+		}
+		np.QuantityPerSubnet, np.Subnets = quantityPerSubnet, subnets
+		if !applied {
+			o.removeWorkerSubnets(addedSubnetIDs)
+		}
+	}()
+
+	total := count + np.WarmPoolSize
+
+	minCount, maxCount := getProvisionedCountBounds(np.Autoscaling, np.MinCount, np.MaxCount, np.WarmPoolSize)
+	qps, subnetIDs, err := o.GetPoolQuantityValues(total, minCount, maxCount, networkValues)
+	if err != nil {
+		return 0, errors.WithMessage(err, fmt.Sprintf("invalid node count for node pool %s: %d", name, count))
+	}
+
+	for expansions := 0; ; expansions++ {
+		err = o.validateNodePoolIPCapacity(np, qps, subnetIDs)
+		if err == nil {
+			break
+		}
+		if !viper.GetBool(pipConfig.OKESubnetAutoExpansion) {
+			return 0, err
+		}
+		if expansions == maxSubnetExpansions {
+			return 0, errors.WithMessage(err, fmt.Sprintf("%d worker subnets were added", expansions))
+		}
+
+		o.getLogger().WithField("nodePool", name).Infof("Adding worker subnet to VCN: %s", err.Error())
+
+		subnet, err := m.AddWorkerSubnet(o.modelCluster.OKE.VCNID)
+		if err != nil {
+			return 0, errors.Wrap(err, "error adding worker subnet")
+		}
+
+		addedSubnetIDs = append(addedSubnetIDs, *subnet.Id)
+		subnetIDs = append(subnetIDs, *subnet.Id)
+		qps = (total + uint(len(subnetIDs)) - 1) / uint(len(subnetIDs))
+	}
+
+	np.QuantityPerSubnet = qps
+	np.Subnets = make([]*modelOracle.NodePoolSubnet, 0)
+	for _, subnetID := range subnetIDs {
+		np.Subnets = append(np.Subnets, &modelOracle.NodePoolSubnet{
+			SubnetID: subnetID,
+		})
+	}
+
+	cm, err := o.GetClusterManager()
+	if err != nil {
+		return 0, err
+	}
+
+	err = cm.ManageOKECluster(ctx, &o.modelCluster.OKE)
+	if err != nil {
+		return 0, err
+	}
+	applied = true
+
+	err = o.waitForNodePools([]*modelOracle.NodePool{np})
+	if err != nil {
+		return 0, err
+	}
+
+	err = o.modelCluster.Save()
+	if err != nil {
+		return 0, errors.Wrap(err, "error saving cluster")
+	}
+
+	return np.GetActiveNodeCount(), nil
+}
+
+// removeWorkerSubnets removes the worker subnets which were added for a failed scaling
+func (o *OKECluster) removeWorkerSubnets(subnetIDs []string) {
+
+	if len(subnetIDs) == 0 {
+		return
+	}
+
+	log := o.getLogger()
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		log.Warnf("error removing added worker subnets: %s", err.Error())
+		return
+	}
+
+	vn, err := OCI.NewVirtualNetworkClient()
+	if err != nil {
+		log.Warnf("error removing added worker subnets: %s", err.Error())
+		return
+	}
+
+	for _, subnetID := range subnetIDs {
+		log.WithField("subnet", subnetID).Info("Removing added worker subnet")
+		if err := vn.DeleteSubnet(&subnetID); err != nil {
+			log.Warnf("error removing worker subnet %s: %s", subnetID, err.Error())
+		}
+	}
+}
+
+// validateNodePoolIPCapacity checks whether the given worker subnets can accommodate qps nodes of the node pool
+// besides the nodes of the other node pools
+func (o *OKECluster) validateNodePoolIPCapacity(np *modelOracle.NodePool, qps uint, subnetIDs []string) error {
+
+	usage, err := o.getSubnetIPUsage()
+	if err != nil {
+		return err
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	vn, err := OCI.NewVirtualNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		u := usage[subnetID]
+		if u == nil {
+			subnet, err := vn.GetSubnet(&subnetID)
+			if err != nil {
+				return err
+			}
+			available, err := getAvailableIPCount(*subnet.CidrBlock)
+			if err != nil {
+				return err
+			}
+			u = &subnetIPUsage{
+				Name:      *subnet.DisplayName,
+				CIDR:      *subnet.CidrBlock,
+				Available: available - subnetReservedIPCount,
+			}
+		}
+
+		required := u.Required + int(qps)
+		for _, subnet := range np.Subnets {
+			if subnet.SubnetID == subnetID {
+				required -= int(np.QuantityPerSubnet)
+			}
+		}
+
+		if required > u.Available {
+			return fmt.Errorf("subnet %s (%s) cannot accommodate the requested nodes: %d addresses required, %d available", u.Name, u.CIDR, required, u.Available)
+		}
+	}
+
+	return nil
+}
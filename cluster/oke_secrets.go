@@ -0,0 +1,8 @@
+package cluster
+
+// SetDeleteSecretsOnDelete makes DeleteFromDatabase delete the secrets of the cluster, like its config and ssh
+// secrets, from the secret store, secrets which are used by other clusters are kept
+func (o *OKECluster) SetDeleteSecretsOnDelete(deleteSecrets bool) {
+
+	o.deleteSecrets = deleteSecrets
+}
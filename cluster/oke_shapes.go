@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// validateShapeAvailability checks that the shapes of the node pools are offered in the availability domain of
+// every subnet the node pools are spread across, a shape available in the region may be missing from some ADs
+func (o *OKECluster) validateShapeAvailability() error {
+
+	return o.checkShapeAvailability(make(map[string]string))
+}
+
+// checkShapeAvailability checks the shape availability, the availability domains of the subnets which are not in
+// subnetADs are looked up
+func (o *OKECluster) checkShapeAvailability(subnetADs map[string]string) error {
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return err
+	}
+
+	vn, err := OCI.NewVirtualNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	compute, err := OCI.NewComputeClient()
+	if err != nil {
+		return err
+	}
+
+	// shapes offered per availability domain
+	shapes := make(map[string]map[string]bool)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil || np.Delete {
+			continue
+		}
+
+		for _, subnet := range np.Subnets {
+			AD, err := getSubnetAD(vn, subnetADs, subnet.SubnetID)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := shapes[AD]; !ok {
+				names, err := compute.GetShapeNamesInAvailabilityDomain(context.Background(), AD)
+				if err != nil {
+					return err
+				}
+				shapes[AD] = make(map[string]bool, len(names))
+				for _, name := range names {
+					shapes[AD][name] = true
+				}
+			}
+
+			if !shapes[AD][np.Shape] {
+				return &oci.ShapeNotAvailableError{
+					NodePool:           np.Name,
+					Shape:              np.Shape,
+					AvailabilityDomain: AD,
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/containerengine"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// statusRefresherTick is the time between checks for clusters which are due for a status poll
+const statusRefresherTick = 5 * time.Second
+
+// statusRefreshes holds the last observed OKE lifecycle state, the next poll time and the instance of the clusters,
+// the instances are kept between the polls so their OCI clients are reused
+var statusRefreshes = struct {
+	sync.Mutex
+	states   map[uint]string
+	next     map[uint]time.Time
+	clusters map[uint]*OKECluster
+}{
+	states:   make(map[uint]string),
+	next:     make(map[uint]time.Time),
+	clusters: make(map[uint]*OKECluster),
+}
+
+// getStatusPollInterval gives back the time between status polls of a cluster in the given OKE lifecycle state
+func getStatusPollInterval(state string) time.Duration {
+
+	intervals := viper.GetStringMap(pipConfig.OKEStatusRefreshStateIntervals)
+	if interval, ok := intervals[strings.ToLower(state)]; ok {
+		if seconds := cast.ToInt(interval); seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	return time.Duration(viper.GetInt(pipConfig.OKEStatusRefreshIntervalSeconds)) * time.Second
+}
+
+// GetNextStatusRefresh gives back when the status of the cluster is polled next, false if it isn't polled
+func (o *OKECluster) GetNextStatusRefresh() (time.Time, bool) {
+
+	flags, err := modelOracle.GetClusterFeatureFlags(o.modelCluster.OKE.ID)
+	if err != nil || !flags.StatusRefresher {
+		return time.Time{}, false
+	}
+
+	statusRefreshes.Lock()
+	defer statusRefreshes.Unlock()
+
+	next, ok := statusRefreshes.next[o.GetID()]
+	return next, ok
+}
+
+// StartStatusRefresher periodically polls the lifecycle state of the OKE clusters which have the status refresher
+// feature enabled, transitional states are polled more frequently than stable ones
+func StartStatusRefresher() {
+
+	go func() {
+		for range time.NewTicker(statusRefresherTick).C {
+			refreshStatuses()
+		}
+	}()
+}
+
+// refreshStatuses polls the status of the clusters which are due, only those are loaded from the database
+func refreshStatuses() {
+
+	ids, err := ListOKEClusterIDs(0, OKEClusterFilter{})
+	if err != nil {
+		log.Errorf("error listing OKE clusters: %s", err.Error())
+		return
+	}
+
+	now := time.Now()
+	existing := make(map[uint]bool, len(ids))
+	due := make([]uint, 0, len(ids))
+
+	statusRefreshes.Lock()
+	for _, id := range ids {
+		existing[id] = true
+		if next, ok := statusRefreshes.next[id]; !ok || !now.Before(next) {
+			due = append(due, id)
+		}
+	}
+
+	// forget the deleted clusters
+	for id := range statusRefreshes.next {
+		if !existing[id] {
+			delete(statusRefreshes.next, id)
+			delete(statusRefreshes.states, id)
+			delete(statusRefreshes.clusters, id)
+		}
+	}
+	statusRefreshes.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+
+	clusters, err := ListOKEClusters(0, OKEClusterFilter{IDs: due})
+	if err != nil {
+		log.Errorf("error listing OKE clusters: %s", err.Error())
+		return
+	}
+
+	for _, loaded := range clusters {
+		id := loaded.GetID()
+
+		// the kept instance gets the current model of the cluster
+		statusRefreshes.Lock()
+		okeCluster, ok := statusRefreshes.clusters[id]
+		if ok {
+			okeCluster.modelCluster = loaded.modelCluster
+		} else {
+			okeCluster = loaded
+			statusRefreshes.clusters[id] = okeCluster
+		}
+		statusRefreshes.Unlock()
+
+		state, enabled, err := okeCluster.refreshStatus()
+		if err != nil {
+			okeCluster.getLogger().Warnf("error refreshing status: %s", err.Error())
+		}
+
+		statusRefreshes.Lock()
+		if !enabled && err == nil {
+			// the clusters which have the feature disabled are not polled, only their flag is checked again later
+			delete(statusRefreshes.states, id)
+			delete(statusRefreshes.clusters, id)
+			state = ""
+		} else {
+			statusRefreshes.states[id] = state
+		}
+		statusRefreshes.next[id] = time.Now().Add(getStatusPollInterval(state))
+		statusRefreshes.Unlock()
+	}
+}
+
+// refreshStatus polls the lifecycle state of the cluster at OCI, a running cluster which failed at OCI is put into
+// error state and the admin binding of an active one is recreated if it was removed; enabled is false if the status
+// of the cluster is not polled
+func (o *OKECluster) refreshStatus() (state string, enabled bool, err error) {
+
+	flags, err := modelOracle.GetClusterFeatureFlags(o.modelCluster.OKE.ID)
+	if err != nil {
+		return "", false, err
+	}
+	if !flags.StatusRefresher || o.modelCluster.OKE.OCID == "" {
+		return "", false, nil
+	}
+
+	OCI, err := o.GetOCIWithRegion(o.modelCluster.Location)
+	if err != nil {
+		return "", true, err
+	}
+
+	ce, err := OCI.NewContainerEngineClient()
+	if err != nil {
+		return "", true, err
+	}
+
+	cluster, err := ce.GetCluster(context.Background(), &o.modelCluster.OKE.OCID)
+	if err != nil {
+		return "", true, err
+	}
+
+	state = string(cluster.LifecycleState)
+
+	statusRefreshes.Lock()
+	previous := statusRefreshes.states[o.GetID()]
+	statusRefreshes.Unlock()
+
+	if state != previous {
+		o.getLogger().Infof("OKE lifecycle state: %s", state)
+	}
+
+	if cluster.LifecycleState == containerengine.ClusterLifecycleStateFailed && o.modelCluster.Status == pkgCluster.Running {
+		message := fmt.Sprintf("OKE cluster is in %s state", state)
+		if cluster.LifecycleDetails != nil {
+			message = fmt.Sprintf("%s: %s", message, *cluster.LifecycleDetails)
+		}
+		return state, true, o.UpdateStatus(pkgCluster.Error, message)
+	}
+
+	if cluster.LifecycleState == containerengine.ClusterLifecycleStateActive && o.modelCluster.Status == pkgCluster.Running && flags.AdminBindingSelfHeal {
+		if err := o.ensureClusterAdminRights(clusterCreatorAdminRight); err != nil {
+			o.getLogger().Warnf("error healing cluster admin rights: %s", err.Error())
+		}
+	}
+
+	return state, true, nil
+}
@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+)
+
+func TestGetStatusPollInterval(t *testing.T) {
+
+	defaultInterval := viper.Get(pipConfig.OKEStatusRefreshIntervalSeconds)
+	stateIntervals := viper.Get(pipConfig.OKEStatusRefreshStateIntervals)
+	defer func() {
+		viper.Set(pipConfig.OKEStatusRefreshIntervalSeconds, defaultInterval)
+		viper.Set(pipConfig.OKEStatusRefreshStateIntervals, stateIntervals)
+	}()
+
+	viper.Set(pipConfig.OKEStatusRefreshIntervalSeconds, 300)
+	viper.Set(pipConfig.OKEStatusRefreshStateIntervals, map[string]interface{}{
+		"creating": 15,
+		"deleting": "30",
+		"active":   0,
+		"failed":   -1,
+	})
+
+	tests := []struct {
+		state    string
+		expected time.Duration
+	}{
+		{state: "creating", expected: 15 * time.Second},
+		{state: "CREATING", expected: 15 * time.Second},
+		{state: "DELETING", expected: 30 * time.Second},
+		{state: "ACTIVE", expected: 300 * time.Second},
+		{state: "FAILED", expected: 300 * time.Second},
+		{state: "UPDATING", expected: 300 * time.Second},
+		{state: "", expected: 300 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.state, func(t *testing.T) {
+
+			if interval := getStatusPollInterval(test.state); interval != test.expected {
+				t.Errorf("expected interval: %s, got: %s", test.expected, interval)
+			}
+		})
+	}
+}
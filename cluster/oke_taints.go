@@ -0,0 +1,188 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// startupTaintsRemovedAnnotation marks the nodes which startup taints were already removed,
+// so they are not put back on nodes when their readiness condition flaps later
+const startupTaintsRemovedAnnotation = "pipeline-startup-taints-removed"
+
+const defaultStartupReadyCondition = string(v1.NodeReady)
+
+// ReconcileStartupTaints puts the startup taints of the node pool on its nodes until the readiness condition
+// of the node pool is met and removes them afterwards, returns the number of nodes still having startup taints.
+// OKE can't register nodes with taints, so pods may get scheduled to a new node before the taints are put on it.
+func (o *OKECluster) ReconcileStartupTaints(nodePoolName string) (int, error) {
+
+	np := o.modelCluster.OKE.GetNodePoolByName(nodePoolName)
+	if np.ID == 0 {
+		return 0, errors.Errorf("node pool not found: %s", nodePoolName)
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return 0, err
+	}
+
+	return o.reconcileStartupTaints(client, np)
+}
+
+func (o *OKECluster) reconcileStartupTaints(client *kubernetes.Clientset, np *modelOracle.NodePool) (int, error) {
+
+	if len(np.StartupTaints) == 0 {
+		return 0, nil
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + np.Name,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "error listing nodes")
+	}
+
+	condition := np.StartupReadyCondition
+	if condition == "" {
+		condition = defaultStartupReadyCondition
+	}
+
+	log := o.getLogger().WithField("nodePool", np.Name)
+
+	pending := 0
+	changed := make(map[string]*v1.Node)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Annotations[startupTaintsRemovedAnnotation] == "true" {
+			continue
+		}
+
+		if isNodeConditionTrue(node, condition) {
+			removeStartupTaints(node, np.StartupTaints)
+			if node.Annotations == nil {
+				node.Annotations = make(map[string]string)
+			}
+			node.Annotations[startupTaintsRemovedAnnotation] = "true"
+			log.Infof("Removing startup taints from node %s", node.Name)
+		} else {
+			pending++
+			if !addStartupTaints(node, np.StartupTaints) {
+				continue
+			}
+			log.Infof("Adding startup taints to node %s", node.Name)
+		}
+
+		changed[node.Name] = node
+	}
+
+	nodeNames := make([]string, 0, len(changed))
+	for name := range changed {
+		nodeNames = append(nodeNames, name)
+	}
+
+	err = patchNodes(nodeNames, getNodePatchConcurrency(), func(nodeName string) error {
+		_, err := client.CoreV1().Nodes().Update(changed[nodeName])
+		return errors.Wrap(err, "error updating taints")
+	})
+
+	return pending, err
+}
+
+// waitForStartupTaints waits until the startup taints are removed from all nodes of the node pool
+func (o *OKECluster) waitForStartupTaints(np *modelOracle.NodePool) error {
+
+	if len(np.StartupTaints) == 0 {
+		return nil
+	}
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	timeout := time.Duration(viper.GetInt(pipConfig.OKENodeReadinessTimeoutSeconds)) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pending, err := o.reconcileStartupTaints(client, np)
+		if err != nil {
+			return err
+		}
+		if pending == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("node pool %s: readiness condition %s is not met on %d nodes, startup taints are kept",
+				np.Name, np.StartupReadyCondition, pending)
+		}
+
+		time.Sleep(nodeReadinessPollInterval)
+	}
+}
+
+// isNodeConditionTrue returns true if the given condition of the node has True status
+func isNodeConditionTrue(node *v1.Node, conditionType string) bool {
+
+	for _, condition := range node.Status.Conditions {
+		if string(condition.Type) == conditionType && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addStartupTaints adds the missing startup taints to the node, returns true if the node was changed
+func addStartupTaints(node *v1.Node, taints []*modelOracle.NodePoolTaint) bool {
+
+	changed := false
+	for _, t := range taints {
+		found := false
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == t.Key && string(taint.Effect) == t.Effect {
+				found = true
+				break
+			}
+		}
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, v1.Taint{
+				Key:    t.Key,
+				Value:  t.Value,
+				Effect: v1.TaintEffect(t.Effect),
+			})
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// removeStartupTaints removes the startup taints from the node
+func removeStartupTaints(node *v1.Node, taints []*modelOracle.NodePoolTaint) {
+
+	kept := make([]v1.Taint, 0)
+	for _, taint := range node.Spec.Taints {
+		startup := false
+		for _, t := range taints {
+			if taint.Key == t.Key && string(taint.Effect) == t.Effect {
+				startup = true
+				break
+			}
+		}
+		if !startup {
+			kept = append(kept, taint)
+		}
+	}
+
+	node.Spec.Taints = kept
+}
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// syncWarmPool keeps WarmPoolSize nodes of the node pool cordoned and labelled as standby nodes, only nodes
+// running no workloads are put into the warm pool, the newest first. OCI chooses the nodes removed by a scale
+// down, so the warm pool is refilled from the idle nodes afterwards; it stays smaller while there are none.
+func (o *OKECluster) syncWarmPool(np *modelOracle.NodePool) error {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	warm, active, err := listWarmPoolNodes(client, np.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(warm) > int(np.WarmPoolSize) {
+		_, err = activateNodes(client, warm, len(warm)-int(np.WarmPoolSize))
+		return err
+	}
+
+	// newest nodes first
+	sort.Slice(active, func(i, j int) bool {
+		return active[j].CreationTimestamp.Before(&active[i].CreationTimestamp)
+	})
+
+	log := o.getLogger().WithField("nodePool", np.Name)
+	missing := int(np.WarmPoolSize) - len(warm)
+	for i := 0; missing > 0 && i < len(active); i++ {
+		node := &active[i]
+		idle, err := isNodeIdle(client, node.Name)
+		if err != nil {
+			return err
+		}
+		if !idle {
+			continue
+		}
+
+		node.Spec.Unschedulable = true
+		node.Labels[modelOracle.WarmPoolLabelKey] = "true"
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return errors.Wrapf(err, "error moving node %s into the warm pool", node.Name)
+		}
+		log.WithField("node", node.Name).Info("node moved into the warm pool")
+		missing--
+	}
+
+	if missing > 0 {
+		log.Warnf("warm pool is %d node(s) short, there are no more nodes without workloads", missing)
+	}
+
+	return nil
+}
+
+// isNodeIdle returns true if the node runs no pods apart from the ones which wouldn't be drained, e.g. DaemonSet pods
+func isNodeIdle(client *kubernetes.Clientset, nodeName string) (bool, error) {
+
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "error listing pods of node %s", nodeName)
+	}
+
+	for i := range pods.Items {
+		if isEvictable(&pods.Items[i]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// activateWarmNodes uncordons at most count Ready nodes of the warm pool of the node pool,
+// gives back the number of activated nodes
+func (o *OKECluster) activateWarmNodes(np *modelOracle.NodePool, count int) (int, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return 0, err
+	}
+
+	warm, _, err := listWarmPoolNodes(client, np.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := make([]v1.Node, 0, len(warm))
+	for _, node := range warm {
+		if isNodeHealthy(&node, 0) {
+			ready = append(ready, node)
+		}
+	}
+
+	activated, err := activateNodes(client, ready, count)
+	if activated > 0 {
+		o.getLogger().WithField("nodePool", np.Name).Infof("%d node(s) activated from the warm pool", activated)
+	}
+
+	return activated, err
+}
+
+// getWarmPoolStatus gives back the warm pool status of the node pools which have a warm pool
+func (o *OKECluster) getWarmPoolStatus() (map[string]*pkgCluster.WarmPoolStatus, error) {
+
+	client, err := o.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]*pkgCluster.WarmPoolStatus)
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np == nil || np.WarmPoolSize == 0 {
+			continue
+		}
+
+		warm, _, err := listWarmPoolNodes(client, np.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		s := &pkgCluster.WarmPoolStatus{
+			Size: len(warm),
+		}
+		for _, node := range warm {
+			if isNodeHealthy(&node, 0) {
+				s.ReadyCount++
+			}
+		}
+		status[np.Name] = s
+	}
+
+	return status, nil
+}
+
+// listWarmPoolNodes gives back the warm pool and the active nodes of the node pool
+func listWarmPoolNodes(client *kubernetes.Clientset, nodePoolName string) (warm, active []v1.Node, err error) {
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: pkgCommon.LabelKey + "=" + nodePoolName,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error listing nodes")
+	}
+
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[modelOracle.WarmPoolLabelKey]; ok {
+			warm = append(warm, node)
+		} else {
+			active = append(active, node)
+		}
+	}
+
+	return warm, active, nil
+}
+
+// activateNodes uncordons at most count of the given warm pool nodes and removes their warm pool label
+func activateNodes(client *kubernetes.Clientset, nodes []v1.Node, count int) (int, error) {
+
+	activated := 0
+	for i := 0; i < count && i < len(nodes); i++ {
+		node := &nodes[i]
+		node.Spec.Unschedulable = false
+		delete(node.Labels, modelOracle.WarmPoolLabelKey)
+		if _, err := client.CoreV1().Nodes().Update(node); err != nil {
+			return activated, errors.Wrapf(err, "error activating node %s", node.Name)
+		}
+		activated++
+	}
+
+	return activated, nil
+}
@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+// subnetIPUsageWarningRatio is the ratio of used subnet IP addresses above which a warning is given
+const subnetIPUsageWarningRatio = 0.8
+
+// subnetIPUsageWarningsTTL is how long the subnet IP usage warnings of a cluster are cached, checking them takes
+// several OCI calls
+const subnetIPUsageWarningsTTL = 5 * time.Minute
+
+type ipUsageWarningsCacheEntry struct {
+	warnings  []pkgCluster.Warning
+	expiresAt time.Time
+}
+
+// ipUsageWarnings caches the subnet IP usage warnings of the OKE clusters keyed by cluster UID
+var ipUsageWarnings = struct {
+	sync.Mutex
+	entries map[string]ipUsageWarningsCacheEntry
+}{
+	entries: make(map[string]ipUsageWarningsCacheEntry),
+}
+
+// GetWarnings returns non-fatal advisories about the cluster's configuration, the ones which need OCI calls
+// are cached for subnetIPUsageWarningsTTL
+func (o *OKECluster) GetWarnings() []pkgCluster.Warning {
+
+	warnings := o.getConfigWarnings()
+
+	return append(warnings, o.getCachedSubnetIPUsageWarnings()...)
+}
+
+// getCachedSubnetIPUsageWarnings gives back the cached subnet IP usage warnings of the cluster, they are checked
+// again if they are not cached or expired
+func (o *OKECluster) getCachedSubnetIPUsageWarnings() []pkgCluster.Warning {
+
+	ipUsageWarnings.Lock()
+	entry, ok := ipUsageWarnings.entries[o.GetUID()]
+	ipUsageWarnings.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.warnings
+	}
+
+	warnings, err := o.getSubnetIPUsageWarnings()
+	if err != nil {
+		// not cached, so it is checked again next time
+		log.Warnf("error checking subnet IP usage: %s", err.Error())
+		return warnings
+	}
+	if o.GetUID() == "" {
+		return warnings
+	}
+
+	ipUsageWarnings.Lock()
+	defer ipUsageWarnings.Unlock()
+
+	ipUsageWarnings.entries[o.GetUID()] = ipUsageWarningsCacheEntry{
+		warnings:  warnings,
+		expiresAt: time.Now().Add(subnetIPUsageWarningsTTL),
+	}
+
+	return warnings
+}
+
+// getConfigWarnings returns the advisories which can be given based on the cluster model only
+func (o *OKECluster) getConfigWarnings() []pkgCluster.Warning {
+
+	warnings := make([]pkgCluster.Warning, 0)
+
+	for name, message := range o.CheckNodePoolSubnetSpread() {
+		warnings = append(warnings, pkgCluster.Warning{
+			Code:     pkgCluster.WarningUnevenSubnetSpread,
+			Message:  message,
+			NodePool: name,
+		})
+	}
+
+	for _, np := range o.modelCluster.OKE.NodePools {
+		if np != nil && oracle.IsVersionSkewNearLimit(o.modelCluster.OKE.Version, np.Version) {
+			warnings = append(warnings, pkgCluster.Warning{
+				Code:     pkgCluster.WarningVersionSkew,
+				Message:  fmt.Sprintf("node pool version %s will become unsupported on the next control plane upgrade", np.Version),
+				NodePool: np.Name,
+			})
+		}
+	}
+
+	return warnings
+}
+
+// getSubnetIPUsageWarnings warns about worker node subnets which are close to running out of IP addresses
+func (o *OKECluster) getSubnetIPUsageWarnings() ([]pkgCluster.Warning, error) {
+
+	usage, err := o.getSubnetIPUsage()
+	if err != nil {
+		return make([]pkgCluster.Warning, 0), err
+	}
+
+	return getIPUsageWarnings(usage), nil
+}
+
+// getIPUsageWarnings gives back warnings for the subnets with high IP address usage
+func getIPUsageWarnings(usage map[string]*subnetIPUsage) []pkgCluster.Warning {
+
+	warnings := make([]pkgCluster.Warning, 0)
+
+	for _, u := range usage {
+		if u.Available > 0 && float64(u.Required)/float64(u.Available) >= subnetIPUsageWarningRatio {
+			warnings = append(warnings, pkgCluster.Warning{
+				Code:    pkgCluster.WarningIPExhaustion,
+				Message: fmt.Sprintf("subnet %s (%s) has %d nodes of %d available IP addresses", u.Name, u.CIDR, u.Required, u.Available),
+			})
+		}
+	}
+
+	return warnings
+}
@@ -50,6 +50,65 @@ const (
 	// Config keys to GKE resource delete
 	GKEResourceDeleteWaitAttempt  = "gke.resourceDeleteWaitAttempt"
 	GKEResourceDeleteSleepSeconds = "gke.resourceDeleteSleepSeconds"
+
+	// OKENodeReadinessTimeoutSeconds configuration key for the time to wait for OKE nodes to become Ready
+	OKENodeReadinessTimeoutSeconds = "oke.nodeReadinessTimeoutSeconds"
+	// OKEAPIEndpointTimeoutSeconds configuration key for the time to wait for the Kubernetes API endpoint of an OKE
+	// cluster to be published
+	OKEAPIEndpointTimeoutSeconds = "oke.apiEndpointTimeoutSeconds"
+	// OKENodePatchConcurrency configuration key for the number of nodes patched in parallel when reconciling
+	// the labels and taints of an OKE node pool
+	OKENodePatchConcurrency = "oke.nodePatchConcurrency"
+	// OKENodePoolConcurrency configuration key for the number of node pools of an OKE cluster provisioned in parallel
+	OKENodePoolConcurrency = "oke.nodePoolConcurrency"
+
+	// OKEDrainGracePeriodSeconds configuration key for the termination grace period of the pods evicted by draining
+	// a node pool, the grace period of the pods is used if negative
+	OKEDrainGracePeriodSeconds = "oke.drain.gracePeriodSeconds"
+	// OKEDrainTimeoutSeconds configuration key for the time to wait for the pods of a drained node pool to terminate
+	OKEDrainTimeoutSeconds = "oke.drain.timeoutSeconds"
+
+	// OKESubnetAutoExpansion configuration key for adding worker subnets to the VCN when scaling
+	// a node pool exceeds the IP capacity of its subnets
+	OKESubnetAutoExpansion = "oke.subnetAutoExpansion"
+
+	// OKERetryMaxAttempts configuration key for the max attempts of OCI API calls, 1 disables retrying
+	OKERetryMaxAttempts = "oke.retry.maxAttempts"
+	// OKERetryBackoffSeconds configuration key for the initial backoff between OCI API call attempts
+	OKERetryBackoffSeconds = "oke.retry.backoffSeconds"
+	// OKERetryMaxBackoffSeconds configuration key for the max backoff between OCI API call attempts
+	OKERetryMaxBackoffSeconds = "oke.retry.maxBackoffSeconds"
+	// OKERetryStatusCodes configuration key for the HTTP status codes of OCI API calls which are retried
+	OKERetryStatusCodes = "oke.retry.statusCodes"
+
+	// OKEMetricsCollectorEnabled configuration key for enabling the node pool utilization sampling of OKE clusters
+	OKEMetricsCollectorEnabled = "oke.metrics.enabled"
+	// OKEMetricsIntervalSeconds configuration key for the time between node pool utilization samples
+	OKEMetricsIntervalSeconds = "oke.metrics.intervalSeconds"
+	// OKEMetricsRetentionDays configuration key for how long node pool utilization samples are kept
+	OKEMetricsRetentionDays = "oke.metrics.retentionDays"
+	// OKEDriftCheckEnabled configuration key for enabling the scheduled drift checks of OKE clusters
+	OKEDriftCheckEnabled = "oke.driftCheck.enabled"
+	// OKEDriftCheckIntervalSeconds configuration key for the default time between drift checks of a cluster
+	OKEDriftCheckIntervalSeconds = "oke.driftCheck.intervalSeconds"
+	// OKEDriftCheckNotify configuration key for sending a Slack notification about detected drifts
+	OKEDriftCheckNotify = "oke.driftCheck.notify"
+	// OKEStatusRefreshEnabled configuration key for enabling the background status refresh of OKE clusters
+	OKEStatusRefreshEnabled = "oke.statusRefresh.enabled"
+	// OKEStatusRefreshIntervalSeconds configuration key for the time between status polls of clusters in a state
+	// without a configured interval
+	OKEStatusRefreshIntervalSeconds = "oke.statusRefresh.intervalSeconds"
+	// OKEStatusRefreshStateIntervals configuration key for the time between status polls per OKE lifecycle state
+	OKEStatusRefreshStateIntervals = "oke.statusRefresh.stateIntervalSeconds"
+	// OKEK8sConfigCacheTTLSeconds configuration key for how long the kubeconfig of an OKE cluster is cached,
+	// 0 disables caching
+	OKEK8sConfigCacheTTLSeconds = "oke.k8sConfigCacheTTLSeconds"
+
+	// SecretTagPolicies configuration key for the tag policies of the secrets, keyed by organization name
+	SecretTagPolicies = "secret.tagPolicies"
+
+	// EventSinkWebhooks configuration key for the webhook URLs receiving the cluster events, keyed by organization name
+	EventSinkWebhooks = "events.webhooks"
 )
 
 //Init initializes the configurations
@@ -115,6 +174,34 @@ func init() {
 	viper.SetDefault(GKEResourceDeleteWaitAttempt, 12)
 	viper.SetDefault(GKEResourceDeleteSleepSeconds, 5)
 
+	viper.SetDefault(OKENodeReadinessTimeoutSeconds, 900)
+	viper.SetDefault(OKEAPIEndpointTimeoutSeconds, 300)
+	viper.SetDefault(OKENodePatchConcurrency, 10)
+	viper.SetDefault(OKENodePoolConcurrency, 4)
+	viper.SetDefault(OKEDrainGracePeriodSeconds, -1)
+	viper.SetDefault(OKEDrainTimeoutSeconds, 600)
+	viper.SetDefault(OKESubnetAutoExpansion, false)
+	viper.SetDefault(OKERetryMaxAttempts, 5)
+	viper.SetDefault(OKERetryBackoffSeconds, 1)
+	viper.SetDefault(OKERetryMaxBackoffSeconds, 30)
+	viper.SetDefault(OKERetryStatusCodes, []string{"429", "500", "502", "503", "504"})
+	viper.SetDefault(OKEMetricsCollectorEnabled, false)
+	viper.SetDefault(OKEMetricsIntervalSeconds, 300)
+	viper.SetDefault(OKEMetricsRetentionDays, 30)
+	viper.SetDefault(OKEDriftCheckEnabled, false)
+	viper.SetDefault(OKEDriftCheckIntervalSeconds, 3600)
+	viper.SetDefault(OKEDriftCheckNotify, true)
+	viper.SetDefault(OKEStatusRefreshEnabled, false)
+	viper.SetDefault(OKEStatusRefreshIntervalSeconds, 300)
+	viper.SetDefault(OKEStatusRefreshStateIntervals, map[string]int{
+		"creating": 15,
+		"updating": 15,
+		"deleting": 30,
+		"active":   600,
+		"failed":   600,
+	})
+	viper.SetDefault(OKEK8sConfigCacheTTLSeconds, 900)
+
 	ReleaseName := os.Getenv("KUBERNETES_RELEASE_NAME")
 	if ReleaseName == "" {
 		ReleaseName = "pipeline"
@@ -27,6 +27,7 @@ import (
 	"k8s.io/helm/pkg/helm"
 	helm_env "k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/kubernetes/pkg/apis/extensions"
@@ -147,6 +148,28 @@ func ListDeployments(filter *string, kubeConfig []byte) (*rls.ListReleasesRespon
 	return resp, nil
 }
 
+// ListReleases lists the Helm releases of the namespace in any status sorted by name,
+// the releases of all namespaces are listed if the namespace is empty
+func ListReleases(namespace string, kubeConfig []byte) (*rls.ListReleasesResponse, error) {
+
+	hClient, err := GetHelmClient(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]release.Status_Code, 0, len(release.Status_Code_name))
+	for code := range release.Status_Code_name {
+		statuses = append(statuses, release.Status_Code(code))
+	}
+
+	return hClient.ListReleases(
+		helm.ReleaseListSort(int32(rls.ListSort_NAME)),
+		helm.ReleaseListOrder(int32(rls.ListSort_ASC)),
+		helm.ReleaseListStatuses(statuses),
+		helm.ReleaseListNamespace(namespace),
+	)
+}
+
 //UpgradeDeployment upgrades a Helm deployment
 func UpgradeDeployment(releaseName, chartName, chartVersion string, values []byte, reuseValues bool, kubeConfig []byte, env helm_env.EnvSettings) (*rls.UpdateReleaseResponse, error) {
 	//Map chartName as
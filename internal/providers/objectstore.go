@@ -11,6 +11,7 @@ import (
 	_objectstore "github.com/banzaicloud/pipeline/objectstore"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	"github.com/banzaicloud/pipeline/pkg/providers"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 	"github.com/banzaicloud/pipeline/secret"
 	"github.com/banzaicloud/pipeline/secret/verify"
 	"github.com/sirupsen/logrus"
@@ -28,6 +29,9 @@ type ObjectStoreContext struct {
 	// Azure specific parameters
 	ResourceGroup  string
 	StorageAccount string
+
+	// Oracle specific parameters
+	LifecycleRules []oci.ObjectLifecycleRule
 }
 
 // NewObjectStore creates an object store client for the given cloud provider.
@@ -49,7 +53,12 @@ func NewObjectStore(ctx *ObjectStoreContext, logger logrus.FieldLogger) (objects
 		return google.NewObjectStore(ctx.Organization, verify.CreateServiceAccount(ctx.Secret.Values), ctx.Location, db, logger), nil
 
 	case providers.Oracle:
-		return oracle.NewObjectStore(ctx.Location, ctx.Secret, ctx.Organization, db, logger), nil
+		objectStore := oracle.NewObjectStore(ctx.Location, ctx.Secret, ctx.Organization, db, logger)
+		if err := objectStore.SetLifecycleRules(ctx.LifecycleRules); err != nil {
+			return nil, err
+		}
+
+		return objectStore, nil
 
 	default:
 		return nil, pkgErrors.ErrorNotSupportedCloudType
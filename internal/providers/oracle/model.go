@@ -14,10 +14,17 @@ import (
 func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
 	tables := []interface{}{
 		&ObjectStoreBucketModel{},
+		&ObjectStoreBucketLifecycleRuleModel{},
 		&model.Cluster{},
 		&model.NodePool{},
 		&model.NodePoolSubnet{},
 		&model.NodePoolLabel{},
+		&model.NodePoolTaint{},
+		&model.ClusterFeatureFlags{},
+		&model.ClusterTag{},
+		&model.NodePoolMetricSample{},
+		&model.DriftReport{},
+		&model.ProgressEvent{},
 		&model.Profile{},
 		&model.ProfileNodePool{},
 		&model.ProfileNodePoolLabel{},
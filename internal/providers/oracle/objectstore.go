@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/banzaicloud/pipeline/auth"
 	"github.com/banzaicloud/pipeline/internal/objectstore"
@@ -23,6 +24,8 @@ type ObjectStore struct {
 	location string
 	secret   *secret.SecretItemResponse
 
+	lifecycleRules []oci.ObjectLifecycleRule
+
 	org *auth.Organization
 
 	db     *gorm.DB
@@ -46,17 +49,29 @@ func NewObjectStore(
 	}
 }
 
+// SetLifecycleRules sets the object lifecycle rules of the buckets created by CreateBucket
+func (o *ObjectStore) SetLifecycleRules(rules []oci.ObjectLifecycleRule) error {
+
+	if err := oci.ValidateObjectLifecycleRules(rules); err != nil {
+		return err
+	}
+
+	o.lifecycleRules = rules
+
+	return nil
+}
+
 // CreateBucket creates an Oracle object store bucket with the given name and stores it in the database
 func (o *ObjectStore) CreateBucket(name string) error {
 	logger := o.getLogger().WithField("bucket", name)
 
-	oci, err := oci.NewOCI(osecret.CreateOCICredential(o.secret.Values))
+	OCI, err := oci.NewOCI(osecret.CreateOCICredential(o.secret.Values))
 	if err != nil {
 		return errors.Wrap(err, "OCI client initialization failed")
 	}
 
 	bucket := &ObjectStoreBucketModel{}
-	searchCriteria := o.newBucketSearchCriteria(name, o.location, oci.CompartmentOCID)
+	searchCriteria := o.newBucketSearchCriteria(name, o.location, OCI.CompartmentOCID)
 	if err := o.getBucketFromDB(searchCriteria, bucket); err != nil {
 		if _, ok := err.(bucketNotFoundError); !ok {
 			return errors.Wrap(err, "Error happened during getting bucket description from DB")
@@ -69,38 +84,135 @@ func (o *ObjectStore) CreateBucket(name string) error {
 		return nil
 	}
 
-	err = oci.ChangeRegion(o.location)
+	err = OCI.ChangeRegion(o.location)
 	if err != nil {
 		return errors.Wrap(err, "changing region failed")
 	}
 
-	client, err := oci.NewObjectStorageClient()
+	client, err := OCI.NewObjectStorageClient()
 	if err != nil {
 		return errors.Wrap(err, "creating Oracle object storage client failed")
 	}
 
+	status, err := client.CheckBucket(name)
+	if err != nil {
+		return errors.Wrap(err, "checking bucket failed")
+	}
+
+	switch status {
+	case oci.BucketOwned:
+		return &oci.BucketAlreadyExistsError{Name: name, Owned: true}
+	case oci.BucketNotOwned:
+		return &oci.BucketAlreadyExistsError{Name: name}
+	}
+
+	if _, err := client.CreateBucket(name); err != nil {
+		if oci.IsBucketAlreadyExistsError(err) {
+			return err
+		}
+
+		return errors.Wrap(err, "failed to create bucket")
+	}
+
+	logger.Infof("%s bucket created", name)
+
 	bucket.Name = name
 	bucket.Organization = *o.org
-	bucket.CompartmentID = oci.CompartmentOCID
+	bucket.CompartmentID = OCI.CompartmentOCID
 	bucket.Location = o.location
 
 	if err = o.persistBucketToDB(bucket); err != nil {
 		return errors.Wrap(err, "error happened during persisting bucket description to DB")
 	}
 
-	if _, err := client.CreateBucket(name); err != nil {
-		if e := o.deleteBucketFromDB(bucket); e != nil {
-			logger.Error(e.Error())
-		}
+	if len(o.lifecycleRules) == 0 {
+		return nil
+	}
 
-		return errors.Wrap(err, "failed to create bucket")
+	if err := client.PutObjectLifecycleRules(name, o.lifecycleRules); err != nil {
+		return errors.Wrap(err, "failed to set lifecycle rules of bucket")
 	}
 
-	logger.Infof("%s bucket created", name)
+	bucket.LifecycleRules = newLifecycleRuleModels(o.lifecycleRules)
+	if err = o.persistBucketToDB(bucket); err != nil {
+		return errors.Wrap(err, "error happened during persisting bucket lifecycle rules to DB")
+	}
+
+	logger.Infof("lifecycle rules of %s bucket set", name)
 
 	return nil
 }
 
+// UpdateLifecycleRules replaces the object lifecycle rules of the managed bucket with the given name, no rules
+// deletes the lifecycle policy of the bucket
+func (o *ObjectStore) UpdateLifecycleRules(name string, rules []oci.ObjectLifecycleRule) error {
+
+	logger := o.getLogger().WithField("bucket", name)
+
+	if err := oci.ValidateObjectLifecycleRules(rules); err != nil {
+		return err
+	}
+
+	oci, err := oci.NewOCI(osecret.CreateOCICredential(o.secret.Values))
+	if err != nil {
+		return errors.Wrap(err, "OCI client initialization failed")
+	}
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := o.newBucketSearchCriteria(name, o.location, oci.CompartmentOCID)
+	if err := o.getBucketFromDB(searchCriteria, bucket); err != nil {
+		return err
+	}
+
+	err = oci.ChangeRegion(o.location)
+	if err != nil {
+		return errors.Wrap(err, "changing region failed")
+	}
+
+	client, err := oci.NewObjectStorageClient()
+	if err != nil {
+		return errors.Wrap(err, "creating Oracle object storage client failed")
+	}
+
+	if err := client.PutObjectLifecycleRules(name, rules); err != nil {
+		return errors.Wrap(err, "failed to set lifecycle rules of bucket")
+	}
+
+	if err := o.deleteLifecycleRulesFromDB(bucket); err != nil {
+		return errors.Wrap(err, "error happened during deleting bucket lifecycle rules from DB")
+	}
+
+	bucket.LifecycleRules = newLifecycleRuleModels(rules)
+	if err := o.persistBucketToDB(bucket); err != nil {
+		return errors.Wrap(err, "error happened during persisting bucket lifecycle rules to DB")
+	}
+
+	logger.Infof("lifecycle rules of %s bucket updated", name)
+
+	return nil
+}
+
+// newLifecycleRuleModels converts the lifecycle rules to their database representation
+func newLifecycleRuleModels(rules []oci.ObjectLifecycleRule) []ObjectStoreBucketLifecycleRuleModel {
+
+	models := make([]ObjectStoreBucketLifecycleRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		m := ObjectStoreBucketLifecycleRuleModel{
+			Name:       rule.Name,
+			Action:     rule.Action,
+			TimeAmount: rule.TimeAmount,
+			TimeUnit:   rule.TimeUnit,
+			Enabled:    rule.IsEnabled,
+		}
+		if rule.ObjectNameFilter != nil {
+			m.Prefixes = strings.Join(rule.ObjectNameFilter.InclusionPrefixes, ",")
+		}
+		models = append(models, m)
+	}
+
+	return models
+}
+
 // ListBuckets list all buckets in Oracle object store
 func (o *ObjectStore) ListBuckets() ([]*objectstore.BucketInfo, error) {
 
@@ -196,34 +308,43 @@ func (o *ObjectStore) DeleteBucket(name string) error {
 	return nil
 }
 
-// CheckBucket check the status of the given Oracle object store bucket
+// CheckBucket check the status of the given Oracle object store bucket, buckets of other compartments are reported
+// as not found
 func (o *ObjectStore) CheckBucket(name string) error {
 
 	logger := o.getLogger().WithField("bucket", name)
 
 	logger.Debug("Initializing OCI client")
-	oci, err := oci.NewOCI(osecret.CreateOCICredential(o.secret.Values))
+	OCI, err := oci.NewOCI(osecret.CreateOCICredential(o.secret.Values))
 	if err != nil {
 		logger.Errorf("OCI client initialization failed: %s", err.Error())
 		return err
 	}
 
-	err = oci.ChangeRegion(o.location)
+	err = OCI.ChangeRegion(o.location)
 	if err != nil {
 		logger.Errorf("Changing region failed: %s", err.Error())
 		return err
 	}
 
-	client, err := oci.NewObjectStorageClient()
+	client, err := OCI.NewObjectStorageClient()
 	if err != nil {
 		logger.Errorf("Creating Oracle object storage client failed: %s", err.Error())
 		return err
 	}
 
-	logger.Debug("Getting bucket")
-	_, err = client.GetBucket(name)
+	logger.Debug("Checking bucket")
+	status, err := client.CheckBucket(name)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if status != oci.BucketOwned {
+		logger.WithField("status", status).Debug("Bucket is not owned")
+		return bucketNotFoundError{}
+	}
+
+	return nil
 }
 
 // markManagedBucket marks buckets exists in the database to 'managed'
@@ -306,14 +427,27 @@ func (o *ObjectStore) persistBucketToDB(m *ObjectStoreBucketModel) error {
 	return o.db.Save(m).Error
 }
 
-// deleteBucketFromDB deletes a bucket from DB
+// deleteBucketFromDB deletes a bucket and its lifecycle rules from DB
 func (o *ObjectStore) deleteBucketFromDB(m *ObjectStoreBucketModel) error {
 	logger := o.getLogger().WithField("bucket", m.Name)
 	logger.Debug("Deleting from DB")
 
+	if err := o.deleteLifecycleRulesFromDB(m); err != nil {
+		return err
+	}
+
 	return o.db.Delete(m).Error
 }
 
+// deleteLifecycleRulesFromDB deletes the lifecycle rules of a bucket from DB
+func (o *ObjectStore) deleteLifecycleRulesFromDB(m *ObjectStoreBucketModel) error {
+	if m.ID == 0 {
+		return nil
+	}
+
+	return o.db.Where(&ObjectStoreBucketLifecycleRuleModel{BucketID: m.ID}).Delete(&ObjectStoreBucketLifecycleRuleModel{}).Error
+}
+
 // getLogger initializes and gives back a logger instance with some basic fields
 func (o *ObjectStore) getLogger() logrus.FieldLogger {
 
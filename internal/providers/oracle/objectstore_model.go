@@ -6,7 +6,8 @@ import (
 
 // TableName constants
 const (
-	bucketsTableName = "oracle_buckets"
+	bucketsTableName               = "oracle_buckets"
+	bucketsLifecycleRulesTableName = "oracle_buckets_lifecycle_rules"
 )
 
 // ObjectStoreBucketModel is the schema for the DB.
@@ -19,9 +20,29 @@ type ObjectStoreBucketModel struct {
 	CompartmentID string `gorm:"unique_index:bucketNameLocationCompartment"`
 	Name          string `gorm:"unique_index:bucketNameLocationCompartment"`
 	Location      string `gorm:"unique_index:bucketNameLocationCompartment"`
+
+	LifecycleRules []ObjectStoreBucketLifecycleRuleModel `gorm:"foreignkey:BucketID"`
 }
 
 // TableName changes the default table name.
 func (ObjectStoreBucketModel) TableName() string {
 	return bucketsTableName
 }
+
+// ObjectStoreBucketLifecycleRuleModel is the schema for the DB of the object lifecycle rules of a bucket.
+type ObjectStoreBucketLifecycleRuleModel struct {
+	ID       uint `gorm:"primary_key"`
+	BucketID uint `gorm:"unique_index:bucketLifecycleRuleName"`
+
+	Name       string `gorm:"unique_index:bucketLifecycleRuleName"`
+	Action     string
+	TimeAmount int64
+	TimeUnit   string
+	Enabled    bool
+	Prefixes   string // comma separated object name prefixes
+}
+
+// TableName changes the default table name.
+func (ObjectStoreBucketLifecycleRuleModel) TableName() string {
+	return bucketsLifecycleRulesTableName
+}
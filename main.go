@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/banzaicloud/go-gin-prometheus"
 	"github.com/banzaicloud/pipeline/api"
 	"github.com/banzaicloud/pipeline/audit"
 	"github.com/banzaicloud/pipeline/auth"
+	"github.com/banzaicloud/pipeline/cluster"
 	"github.com/banzaicloud/pipeline/config"
 	"github.com/banzaicloud/pipeline/dns"
 	"github.com/banzaicloud/pipeline/dns/route53/model"
@@ -33,6 +39,9 @@ var (
 	BuildDate string
 )
 
+// shutdownTimeout is the time the in-flight API requests are given to finish when the server stops
+const shutdownTimeout = 30 * time.Second
+
 //Common logger for package
 var log *logrus.Logger
 var logger *logrus.Entry
@@ -146,6 +155,21 @@ func main() {
 		}
 	}()
 
+	// OKE node pool utilization history
+	if viper.GetBool(config.OKEMetricsCollectorEnabled) {
+		cluster.StartNodePoolMetricCollector()
+	}
+
+	// OKE scheduled drift reports
+	if viper.GetBool(config.OKEDriftCheckEnabled) {
+		cluster.StartDriftChecker()
+	}
+
+	// OKE lifecycle state polling
+	if viper.GetBool(config.OKEStatusRefreshEnabled) {
+		cluster.StartStatusRefresher()
+	}
+
 	//Initialise Gin router
 	router := gin.New()
 
@@ -176,6 +200,7 @@ func main() {
 	basePath := viper.GetString("pipeline.basepath")
 	v1 := router.Group(basePath + "/api/v1/")
 	v1.GET("/functions", api.ListFunctions)
+	v1.GET("/version", api.NewVersionHandler(Version, GitRev, BuildDate))
 	{
 		v1.Use(auth.Handler)
 		v1.Use(auth.NewAuthorizer(casbinDSN))
@@ -270,6 +295,10 @@ func main() {
 
 	router.GET(basePath+"/api", api.MetaHandler(router, basePath+"/api"))
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api.SetServerContext(ctx)
+
 	notify.SlackNotify("API is already running")
 	var listenPort string
 	port := viper.GetInt("pipeline.listenport")
@@ -278,10 +307,40 @@ func main() {
 		logger.Info("Pipeline API listening on port ", listenPort)
 	}
 
-	certFile, keyFile := viper.GetString("pipeline.certfile"), viper.GetString("pipeline.keyfile")
-	if certFile != "" && keyFile != "" {
-		router.RunTLS(listenPort, certFile, keyFile)
-	} else {
-		router.Run(listenPort)
+	server := &http.Server{
+		Addr:    listenPort,
+		Handler: router,
 	}
+
+	go func() {
+		var err error
+		certFile, keyFile := viper.GetString("pipeline.certfile"), viper.GetString("pipeline.keyfile")
+		if certFile != "" && keyFile != "" {
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Fatalf("Pipeline API failed: %s", err.Error())
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-signals
+
+	logger.Infof("Received %s, shutting down Pipeline API", sig)
+
+	// abort the asynchronous cluster operations, then let the in-flight requests finish
+	cancel()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("Error shutting down Pipeline API: %s", err.Error())
+	}
+
+	api.WaitAsyncOperations()
+	logger.Info("Pipeline API stopped")
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/banzaicloud/pipeline/config"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	modelOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	pkgSecret "github.com/banzaicloud/pipeline/pkg/secret"
 	"github.com/banzaicloud/pipeline/secret"
 	"github.com/banzaicloud/pipeline/utils"
 	"github.com/jinzhu/gorm"
@@ -64,6 +65,7 @@ type ClusterModel struct {
 	Kubernetes     KubernetesClusterModel
 	OKE            modelOracle.Cluster
 	CreatedBy      uint
+	KeepSecrets    bool `gorm:"-"` // the secrets of the cluster are not deleted with it, not persisted
 }
 
 // ACSKNodePoolModel describes Alibaba Cloud CS node groups model of a cluster
@@ -262,9 +264,43 @@ func (cs *ClusterModel) Save() error {
 func (cs *ClusterModel) preDelete() {
 	log := log.WithFields(logrus.Fields{"organization": cs.OrganizationId, "cluster": cs.ID})
 
+	if cs.KeepSecrets {
+		log.Info("Keep cluster secrets")
+		return
+	}
+
 	log.Info("Delete unused cluster secrets")
-	if err := secret.Store.DeleteByClusterUID(cs.OrganizationId, cs.UID); err != nil {
-		log.Errorf("Error during deleting secret: %s", err.Error())
+	secrets, err := secret.Store.List(cs.OrganizationId, &pkgSecret.ListSecretsQuery{
+		Tag: fmt.Sprintf("clusterUID:%s", cs.UID),
+	})
+	if err != nil {
+		log.Errorf("Error during listing secrets: %s", err.Error())
+		return
+	}
+
+	for _, s := range secrets {
+		log := log.WithField("secret", s.ID)
+
+		// the secrets still referenced by other clusters are kept
+		clusters, err := FindClustersBySecretId(cs.OrganizationId, s.ID)
+		if err != nil {
+			log.Errorf("Error during checking usage of secret: %s", err.Error())
+			continue
+		}
+		used := false
+		for _, c := range clusters {
+			if c.ID != cs.ID {
+				used = true
+			}
+		}
+		if used {
+			log.Info("Secret is used by other clusters, keeping it")
+			continue
+		}
+
+		if err := secret.Store.Delete(cs.OrganizationId, s.ID); err != nil {
+			log.Errorf("Error during deleting secret: %s", err.Error())
+		}
 	}
 }
 
@@ -391,6 +427,69 @@ func QueryCluster(filter map[string]interface{}) ([]ClusterModel, error) {
 	return cluster, nil
 }
 
+// FindClustersBySecretId gets the clusters of the organization which use the secret as cloud, config or ssh secret
+func FindClustersBySecretId(orgId uint, secretId string) ([]ClusterModel, error) {
+	var clusters []ClusterModel
+	err := config.DB().
+		Where("organization_id = ?", orgId).
+		Where("secret_id = ? OR config_secret_id = ? OR ssh_secret_id = ?", secretId, secretId, secretId).
+		Find(&clusters).Error
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// ClusterFilter describes the conditions of a cluster query, empty fields are not filtered on
+type ClusterFilter struct {
+	IDs            []uint
+	OrganizationID uint
+	Cloud          string
+	Distribution   string
+	Status         []string
+}
+
+// QueryClusterWithFilter gets the clusters matching the filter from the DB, the OKE properties are preloaded
+func QueryClusterWithFilter(filter ClusterFilter) ([]ClusterModel, error) {
+	var clusters []ClusterModel
+	err := filterClusters(filter).Preload("OKE.NodePools.Subnets").Preload("OKE.NodePools.Labels").Preload("OKE.NodePools.StartupTaints").Find(&clusters).Error
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// QueryClusterIDsWithFilter gets only the IDs of the clusters matching the filter from the DB
+func QueryClusterIDsWithFilter(filter ClusterFilter) ([]uint, error) {
+	var ids []uint
+	err := filterClusters(filter).Model(&ClusterModel{}).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// filterClusters gives back the cluster query with the conditions of the filter
+func filterClusters(filter ClusterFilter) *gorm.DB {
+	db := config.DB()
+	if len(filter.IDs) > 0 {
+		db = db.Where("id IN (?)", filter.IDs)
+	}
+	if filter.OrganizationID != 0 {
+		db = db.Where("organization_id = ?", filter.OrganizationID)
+	}
+	if filter.Cloud != "" {
+		db = db.Where("cloud = ?", filter.Cloud)
+	}
+	if filter.Distribution != "" {
+		db = db.Where("distribution = ?", filter.Distribution)
+	}
+	if len(filter.Status) > 0 {
+		db = db.Where("status IN (?)", filter.Status)
+	}
+	return db
+}
+
 //TableName sets the GoogleClusterModel's table name
 func (GKEClusterModel) TableName() string {
 	return TableNameGoogleProperties
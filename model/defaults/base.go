@@ -63,6 +63,12 @@ type ClusterProfile interface {
 	DeleteProfile() error
 }
 
+// InheritingClusterProfile is a cluster profile which may inherit settings from a base profile
+type InheritingClusterProfile interface {
+	ClusterProfile
+	GetResolvedProfile() (*pkgCluster.ClusterProfileResponse, error)
+}
+
 // DefaultModel describes the common variables all types of clouds
 type DefaultModel struct {
 	Name      string `gorm:"primary_key"`
@@ -3,6 +3,7 @@ package cluster
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/banzaicloud/pipeline/pkg/cluster/acsk"
 	"github.com/banzaicloud/pipeline/pkg/cluster/aks"
@@ -148,10 +149,14 @@ type GetClusterStatusResponse struct {
 
 	// ONLY in case of GKE
 	Region string `json:"region,omitempty"`
+
+	// ONLY in case of OKE
+	NextStatusRefresh *time.Time `json:"nextStatusRefresh,omitempty"`
 }
 
 // NodePoolStatus describes cluster's node status
 type NodePoolStatus struct {
+	pkgCommon.CreatorBaseFields
 	Autoscaling  bool   `json:"autoscaling,omitempty"`
 	Count        int    `json:"count,omitempty"`
 	InstanceType string `json:"instanceType,omitempty"`
@@ -160,6 +165,18 @@ type NodePoolStatus struct {
 	MaxCount     int    `json:"maxCount,omitempty"`
 	Image        string `json:"image,omitempty"`
 	Version      string `json:"version,omitempty"`
+	ReadyCount   int    `json:"readyCount,omitempty"`
+
+	// ONLY in case of OKE
+	ImageOCID string          `json:"imageOcid,omitempty"`
+	Warnings  []Warning       `json:"warnings,omitempty"`
+	WarmPool  *WarmPoolStatus `json:"warmPool,omitempty"`
+}
+
+// WarmPoolStatus describes the cordoned standby nodes of a node pool
+type WarmPoolStatus struct {
+	Size       int `json:"size"`
+	ReadyCount int `json:"readyCount"`
 }
 
 // GetClusterConfigResponse describes Pipeline's GetConfig API response
@@ -510,9 +527,276 @@ type DetailsResponse struct {
 	Master        map[string]ResourceSummary `json:"master,omitempty"`
 	TotalSummary  *ResourceSummary           `json:"totalSummary,omitempty"`
 	Status        string                     `json:"status"`
+	Warnings      []Warning                  `json:"warnings,omitempty"`
 
-	// ONLY in case of GKE
+	// ONLY in case of GKE and OKE
 	Region string `json:"region,omitempty"`
+
+	// ONLY in case of OKE
+	VCNID          string            `json:"vcnId,omitempty"`
+	PodCIDR        string            `json:"podCidr,omitempty"`
+	ServiceCIDR    string            `json:"serviceCidr,omitempty"`
+	FeatureFlags   map[string]bool   `json:"featureFlags,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	ProgressEvents []ProgressEvent   `json:"progressEvents,omitempty"`
+}
+
+// ProgressEvent describes a step of a long running operation of a cluster
+type ProgressEvent struct {
+	Phase     string    `json:"phase"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Warning describes a non-fatal advisory about the cluster's configuration
+type Warning struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	NodePool string `json:"nodePool,omitempty"`
+}
+
+// NodeImageUpdate describes a node pool running an outdated node image
+type NodeImageUpdate struct {
+	NodePool        string   `json:"nodePool"`
+	CurrentImage    string   `json:"currentImage"`
+	TargetImage     string   `json:"targetImage"`
+	TargetImageOCID string   `json:"targetImageOcid"`
+	OutdatedNodes   []string `json:"outdatedNodes,omitempty"`
+}
+
+// ComponentHealth describes the readiness of a kube-system DaemonSet or Deployment
+type ComponentHealth struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Healthy bool   `json:"healthy"`
+	Desired int    `json:"desired"`
+	Ready   int    `json:"ready"`
+	Message string `json:"message,omitempty"`
+}
+
+// Statuses of HealthCheckResult
+const (
+	HealthStatusHealthy     = "HEALTHY"
+	HealthStatusDegraded    = "DEGRADED"
+	HealthStatusUnreachable = "UNREACHABLE"
+)
+
+// HealthCheckResult describes whether the Kubernetes API server of a cluster is serving requests
+type HealthCheckResult struct {
+	Status    string        `json:"status"`
+	Endpoint  string        `json:"endpoint,omitempty"`
+	Detail    string        `json:"detail,omitempty"`
+	Checks    []HealthCheck `json:"checks,omitempty"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+// HealthCheck describes an individual check of the API server health endpoint, e.g. etcd
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// Warning codes
+const (
+	WarningUnevenSubnetSpread = "UNEVEN_SUBNET_SPREAD"
+	WarningVersionSkew        = "VERSION_SKEW"
+	WarningIPExhaustion       = "IP_EXHAUSTION"
+	WarningConfigDrift        = "CONFIG_DRIFT"
+)
+
+// Condition describes an aspect of the state of a node pool, a True status means the problem is present
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Node pool condition types
+const (
+	ConditionSubnetCapacityLow  = "SubnetCapacityLow"
+	ConditionUnevenSubnetSpread = "UnevenSubnetSpread"
+	ConditionVersionSkew        = "VersionSkew"
+	ConditionImageOutdated      = "ImageOutdated"
+	ConditionUnhealthy          = "Unhealthy"
+)
+
+// Condition statuses
+const (
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+)
+
+// RBACBinding describes a ClusterRoleBinding or RoleBinding managed by Pipeline
+type RBACBinding struct {
+	Kind      string        `json:"kind"`
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace,omitempty"`
+	RoleKind  string        `json:"roleKind"`
+	RoleName  string        `json:"roleName"`
+	Subjects  []RBACSubject `json:"subjects"`
+}
+
+// RBACSubject describes a subject of an RBACBinding
+type RBACSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RBACBundleVersion is the version of the RBAC bundle format
+const RBACBundleVersion = "v1"
+
+// RBACBundle describes the Pipeline managed RBAC bindings of a cluster in a portable form
+type RBACBundle struct {
+	Version  string        `json:"version"`
+	Bindings []RBACBinding `json:"bindings"`
+}
+
+// ClusterCapacity describes the schedulable pod capacity of a cluster
+type ClusterCapacity struct {
+	NodePools map[string]*NodePoolCapacity `json:"nodePools"`
+	MaxPods   int                          `json:"maxPods"`
+}
+
+// NodePoolCapacity describes the schedulable pod capacity of a node pool
+type NodePoolCapacity struct {
+	Nodes          int `json:"nodes"`
+	MaxPodsPerNode int `json:"maxPodsPerNode"`
+	MaxPods        int `json:"maxPods"`
+}
+
+// Actions of the scaling recommendations
+const (
+	ScaleUp   = "scaleUp"
+	ScaleDown = "scaleDown"
+)
+
+// ScalingRecommendation describes a suggested change of the node count of a node pool based on its utilization
+type ScalingRecommendation struct {
+	NodePool         string  `json:"nodePool"`
+	Action           string  `json:"action"`
+	CurrentCount     int     `json:"currentCount"`
+	RecommendedCount int     `json:"recommendedCount"`
+	Utilization      float64 `json:"utilization"` // 95th percentile of the CPU or memory utilization, whichever is higher
+	Rationale        string  `json:"rationale"`
+	HeadroomLimited  bool    `json:"headroomLimited"` // the recommended count is limited by the free capacity of the subnets
+}
+
+// ClusterSpec describes the desired state of a cluster
+type ClusterSpec struct {
+	MasterVersion string                   `json:"masterVersion,omitempty"`
+	NodePools     map[string]*NodePoolSpec `json:"nodePools"`
+}
+
+// NodePoolSpec describes the desired state of a node pool
+type NodePoolSpec struct {
+	Count        int               `json:"count"`
+	Version      string            `json:"version,omitempty"`
+	InstanceType string            `json:"instanceType,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// ReconcilePlan describes the ordered actions needed to reach the desired state of a cluster
+type ReconcilePlan struct {
+	Desired *ClusterSpec      `json:"desired"`
+	Actions []ReconcileAction `json:"actions"`
+
+	Fingerprint string `json:"fingerprint"` // identifies the state of the cluster the plan was made from
+}
+
+// ReconcileAction describes a single action of a ReconcilePlan
+type ReconcileAction struct {
+	Type       string `json:"type"`
+	NodePool   string `json:"nodePool,omitempty"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to,omitempty"`
+	Disruptive bool   `json:"disruptive"`
+}
+
+// DriftReport describes the changes made to a cluster outside of Pipeline, the changes lead from the state
+// stored by Pipeline to the state observed at the cloud provider
+type DriftReport struct {
+	ClusterID        uint                `json:"clusterId"`
+	ClusterName      string              `json:"clusterName"`
+	CheckedAt        time.Time           `json:"checkedAt"`
+	Changes          []ReconcileAction   `json:"changes,omitempty"`
+	NodesPendingRoll map[string][]string `json:"nodesPendingRoll,omitempty"`
+}
+
+// Drifted returns true if the cluster differs from the desired state
+func (r *DriftReport) Drifted() bool {
+	return len(r.Changes) > 0 || len(r.NodesPendingRoll) > 0
+}
+
+// Reconcile action types
+const (
+	ActionUpgradeControlPlane = "UpgradeControlPlane"
+	ActionAddNodePool         = "AddNodePool"
+	ActionScaleNodePool       = "ScaleNodePool"
+	ActionUpgradeNodePool     = "UpgradeNodePool"
+	ActionReplaceNodePool     = "ReplaceNodePool"
+	ActionDeleteNodePool      = "DeleteNodePool"
+)
+
+// ClusterDescription describes a snapshot of a cluster for troubleshooting
+type ClusterDescription struct {
+	Details  *DetailsResponse    `json:"details"`
+	Subnets  []SubnetDescription `json:"subnets,omitempty"`
+	Events   []ClusterEvent      `json:"events,omitempty"`
+	Warnings []Warning           `json:"warnings,omitempty"`
+}
+
+// SubnetDescription describes a subnet of a cluster's network
+type SubnetDescription struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	CIDR               string   `json:"cidr"`
+	AvailabilityDomain string   `json:"availabilityDomain,omitempty"`
+	Role               string   `json:"role"`
+	NodePools          []string `json:"nodePools,omitempty"`
+}
+
+// CreateDryRunReport describes the resources which would be provisioned by a create cluster request
+type CreateDryRunReport struct {
+	VCNName   string                     `json:"vcnName"`
+	VCNID     string                     `json:"vcnId,omitempty"`
+	VCNCIDR   string                     `json:"vcnCidr,omitempty"`
+	ReuseVCN  bool                       `json:"reuseVcn"`
+	Subnets   []SubnetDescription        `json:"subnets"`
+	NodePools map[string]*DryRunNodePool `json:"nodePools"`
+	Warnings  []Warning                  `json:"warnings,omitempty"`
+}
+
+// DryRunNodePool describes a node pool which would be provisioned by a create cluster request
+type DryRunNodePool struct {
+	Version           string   `json:"version"`
+	Image             string   `json:"image"`
+	Shape             string   `json:"shape"`
+	Count             uint     `json:"count"`
+	QuantityPerSubnet uint     `json:"quantityPerSubnet"`
+	Subnets           []string `json:"subnets"`
+}
+
+// Subnet roles
+const (
+	SubnetRoleWorker       = "worker"
+	SubnetRoleLoadBalancer = "loadbalancer"
+	SubnetRoleEndpoint     = "endpoint"
+)
+
+// ClusterEvent describes a Kubernetes event of a cluster
+type ClusterEvent struct {
+	Namespace     string    `json:"namespace"`
+	Object        string    `json:"object"`
+	Type          string    `json:"type"`
+	Reason        string    `json:"reason"`
+	Message       string    `json:"message"`
+	Count         int32     `json:"count"`
+	LastTimestamp time.Time `json:"lastTimestamp"`
 }
 
 // PodDetailsResponse describes a pod
@@ -534,6 +818,14 @@ type NodeDetails struct {
 	Count           int                        `json:"count,omitempty"`
 	MinCount        int                        `json:"minCount,omitempty"`
 	MaxCount        int                        `json:"maxCount,omitempty"`
+
+	// ONLY in case of OKE
+	InstanceType     string `json:"instanceType,omitempty"`
+	VolumesEncrypted bool   `json:"volumesEncrypted,omitempty"`
+	VolumeKMSKeyID   string `json:"volumeKmsKeyId,omitempty"`
+
+	BootVolumeVPUsPerGB int64 `json:"bootVolumeVpusPerGB,omitempty"`
+	UserData            bool  `json:"userData,omitempty"` // whether cloud-init user data is configured
 }
 
 // ResourceSummary describes a node's resource summary with CPU and Memory capacity/request/limit/allocatable
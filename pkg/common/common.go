@@ -13,6 +13,14 @@ type ErrorResponse struct {
 	Error   string `json:"error,omitempty"`
 }
 
+// VersionResponse describes the version of the Pipeline server and the API it serves
+type VersionResponse struct {
+	Version    string `json:"version"`
+	GitRev     string `json:"gitRev,omitempty"`
+	BuildDate  string `json:"buildDate,omitempty"`
+	APIVersion string `json:"apiVersion"`
+}
+
 // CreatorBaseFields describes all field which contains info about who created the cluster/application etc
 type CreatorBaseFields struct {
 	CreatedAt   string `json:"createdAt,omitempty"`
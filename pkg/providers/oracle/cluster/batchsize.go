@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultUpdateBatchSize is the number of nodes updated at the same time when it is not set for a node pool
+const DefaultUpdateBatchSize = "1"
+
+// ParseUpdateBatchSize gives back the number of nodes to update at the same time in a node pool of the given size,
+// the batch size is either an absolute number of nodes or a percentage of the node pool size (e.g. 25%)
+func ParseUpdateBatchSize(batchSize string, poolSize uint) (uint, error) {
+
+	if batchSize == "" {
+		batchSize = DefaultUpdateBatchSize
+	}
+
+	if strings.HasSuffix(batchSize, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(batchSize, "%"))
+		if err != nil || percent < 1 || percent > 100 {
+			return 0, fmt.Errorf("Invalid update batch size: %s, percentage must be between 1%% and 100%%", batchSize)
+		}
+
+		// rounded down, but at least one node is updated at a time
+		size := poolSize * uint(percent) / 100
+		if size == 0 {
+			size = 1
+		}
+
+		return size, nil
+	}
+
+	size, err := strconv.Atoi(batchSize)
+	if err != nil || size < 1 {
+		return 0, fmt.Errorf("Invalid update batch size: %s", batchSize)
+	}
+
+	if poolSize > 0 && uint(size) > poolSize {
+		return 0, fmt.Errorf("Invalid update batch size: %s, it is larger than the node pool size %d", batchSize, poolSize)
+	}
+
+	return uint(size), nil
+}
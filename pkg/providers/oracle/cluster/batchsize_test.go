@@ -0,0 +1,47 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+func TestParseUpdateBatchSize(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		batchSize string
+		poolSize  uint
+		size      uint
+		err       bool
+	}{
+		{"default", "", 6, 1, false},
+		{"absolute", "2", 6, 2, false},
+		{"whole pool", "6", 6, 6, false},
+		{"larger than pool", "7", 6, 0, true},
+		{"percentage", "50%", 6, 3, false},
+		{"percentage rounded down", "25%", 6, 1, false},
+		{"small percentage", "10%", 3, 1, false},
+		{"zero", "0", 6, 0, true},
+		{"invalid percentage", "150%", 6, 0, true},
+		{"invalid", "two", 6, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := cluster.ParseUpdateBatchSize(tc.batchSize, tc.poolSize)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+			if size != tc.size {
+				t.Errorf("Expected batch size: %d, got: %d", tc.size, size)
+			}
+		})
+	}
+}
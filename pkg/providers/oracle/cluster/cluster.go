@@ -3,32 +3,118 @@ package cluster
 import (
 	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 )
 
 // Cluster describes Pipeline's Oracle fields of a Create/Update request
 type Cluster struct {
-	Version   string               `json:"version"`
-	NodePools map[string]*NodePool `json:"nodePools,omitempty"`
+	Version     string               `json:"version"`
+	NodePools   map[string]*NodePool `json:"nodePools,omitempty"`
+	PodCIDR     string               `json:"podCidr,omitempty"`
+	ServiceCIDR string               `json:"serviceCidr,omitempty"`
+
+	ResourceQuotas map[string]*ResourceQuota `json:"resourceQuotas,omitempty"`
+	LogLevel       string                    `json:"logLevel,omitempty"`
+
+	PrivateEndpoint bool `json:"privateEndpoint,omitempty"`
+
+	LBSubnetCount uint `json:"lbSubnetCount,omitempty"` // load balancer subnets of the cluster, DefaultLBSubnetCount if 0
+
+	VCNCIDR string `json:"vcnCidr,omitempty"` // CIDR block of the preconfigured VCN, network.PreconfiguredVCNCIDR if empty
+
+	NetworkPolicies *NetworkPolicies `json:"networkPolicies,omitempty"`
+
+	DriftCheckInterval uint `json:"driftCheckInterval,omitempty"` // in seconds, the configured default is used if 0
+
+	Tags map[string]string `json:"tags,omitempty"` // set on the cluster, node pools, VCN and instances as freeform tags
 
-	vcnID       string
-	lbSubnetID1 string
-	lbSubnetID2 string
+	BaseProfile string `json:"baseProfile,omitempty"` // profiles only, the profile the node pools inherit from
+
+	vcnID            string
+	lbSubnetID1      string
+	lbSubnetID2      string
+	endpointSubnetID string
 }
 
 // NodePool describes Oracle's node fields of a Create/Update request
 type NodePool struct {
-	Version string            `json:"version,omitempty"`
-	Count   uint              `json:"count,omitempty"`
-	Labels  map[string]string `json:"labels,omitempty"`
-	Image   string            `json:"image,omitempty"`
-	Shape   string            `json:"shape,omitempty"`
+	Version string `json:"version,omitempty"`
+	Count   uint   `json:"count,omitempty"`
+
+	Autoscaling bool `json:"autoscaling,omitempty"`
+	MinCount    int  `json:"minCount,omitempty"`
+	MaxCount    int  `json:"maxCount,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+	Image  string            `json:"image,omitempty"`
+	Shape  string            `json:"shape,omitempty"`
+
+	HealthCheckGracePeriod *uint `json:"healthCheckGracePeriod,omitempty"` // in seconds, 0 disables the grace period
+
+	StartupTaints         []Taint `json:"startupTaints,omitempty"`
+	StartupReadyCondition string  `json:"startupReadyCondition,omitempty"` // node condition, Ready by default
+
+	UpdateBatchSize string `json:"updateBatchSize,omitempty"` // number or percentage of nodes, 1 by default
+
+	VolumeKMSKeyID    string `json:"volumeKmsKeyId,omitempty"`    // OCID of the KMS key of the boot and block volumes
+	VolumeKMSEndpoint string `json:"volumeKmsEndpoint,omitempty"` // management endpoint of the vault of the key
+
+	BootVolumeVPUsPerGB int64 `json:"bootVolumeVpusPerGB,omitempty"` // boot volume performance, the OCI default if 0
+
+	WarmPoolSize uint `json:"warmPoolSize,omitempty"` // cordoned standby nodes provisioned besides Count
+
+	PriorityClass *PriorityClass `json:"priorityClass,omitempty"`
+
+	UserData string `json:"userData,omitempty"` // cloud-init user data of the nodes, plain or base64 encoded
 
 	subnetIds         []string
 	quantityPerSubnet uint
 }
 
+// PriorityClass describes the PriorityClass created for the workloads of a node pool, the nodes of the pool are
+// labelled with its name so the pods of the class can target them
+type PriorityClass struct {
+	Name        string `json:"name"`
+	Value       int32  `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// PriorityClassLabelKey is the node label holding the name of the PriorityClass of the node pool
+const PriorityClassLabelKey = "pipeline-nodepool-priority-class"
+
+// maxPriorityClassValue is the highest priority a user defined PriorityClass can have
+const maxPriorityClassValue = 1000000000
+
+// Taint describes a taint put on the nodes of a node pool until they are ready
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// ResourceQuota describes the default CPU/memory limits of a namespace
+type ResourceQuota struct {
+	CPU           string `json:"cpu,omitempty"`
+	Memory        string `json:"memory,omitempty"`
+	DefaultCPU    string `json:"defaultCpu,omitempty"`
+	DefaultMemory string `json:"defaultMemory,omitempty"`
+}
+
+// NetworkPolicies describes the NetworkPolicies applied when the cluster is ready
+type NetworkPolicies struct {
+	DefaultDenyNamespaces []string                     `json:"defaultDenyNamespaces,omitempty"`
+	Policies              []networkingv1.NetworkPolicy `json:"policies,omitempty"`
+}
+
 // SetVCNID sets VCNID
 func (c *Cluster) SetVCNID(id string) {
 
@@ -41,6 +127,16 @@ func (c *Cluster) GetVCNID() (id string) {
 	return c.vcnID
 }
 
+// GetLBSubnetCount gives back the number of load balancer subnets of the cluster
+func (c *Cluster) GetLBSubnetCount() uint {
+
+	if c.LBSubnetCount == 0 {
+		return DefaultLBSubnetCount
+	}
+
+	return c.LBSubnetCount
+}
+
 // SetLBSubnetID1 sets LBSubnetID1
 func (c *Cluster) SetLBSubnetID1(id string) {
 
@@ -65,6 +161,18 @@ func (c *Cluster) GetLBSubnetID2() (id string) {
 	return c.lbSubnetID2
 }
 
+// SetEndpointSubnetID sets EndpointSubnetID
+func (c *Cluster) SetEndpointSubnetID(id string) {
+
+	c.endpointSubnetID = id
+}
+
+// GetEndpointSubnetID gets EndpointSubnetID
+func (c *Cluster) GetEndpointSubnetID() (id string) {
+
+	return c.endpointSubnetID
+}
+
 // SetQuantityPerSubnet sets QuantityPerSubnet
 func (np *NodePool) SetQuantityPerSubnet(q uint) {
 
@@ -77,6 +185,16 @@ func (np *NodePool) GetQuantityPerSubnet() (q uint) {
 	return np.quantityPerSubnet
 }
 
+// GetHealthCheckGracePeriod gets the health check grace period in seconds, the default one if it is not set
+func (np *NodePool) GetHealthCheckGracePeriod() uint {
+
+	if np.HealthCheckGracePeriod == nil {
+		return defaultHealthCheckGracePeriod
+	}
+
+	return *np.HealthCheckGracePeriod
+}
+
 // SetSubnetIDs sets SubnetIDs
 func (np *NodePool) SetSubnetIDs(ids []string) {
 
@@ -109,9 +227,8 @@ func (c *Cluster) AddDefaults() error {
 
 		np.Labels[pkgCommon.LabelKey] = name
 
-		// set default image
-		if len(np.Image) == 0 {
-			np.Image = defaultImage
+		if np.PriorityClass != nil {
+			np.Labels[PriorityClassLabelKey] = np.PriorityClass.Name
 		}
 
 		// set default version
@@ -119,6 +236,12 @@ func (c *Cluster) AddDefaults() error {
 			np.Version = defaultVersion
 		}
 
+		// set default health check grace period
+		if np.HealthCheckGracePeriod == nil {
+			gracePeriod := uint(defaultHealthCheckGracePeriod)
+			np.HealthCheckGracePeriod = &gracePeriod
+		}
+
 	}
 
 	return nil
@@ -139,16 +262,194 @@ func (c *Cluster) Validate(update bool) error {
 		return fmt.Errorf("At least 1 node pool must be specified")
 	}
 
-	for name, nodePool := range c.NodePools {
-		if nodePool.Version != c.Version {
-			return fmt.Errorf("NodePool[%s]: Different k8s versions were specified for master and nodes", name)
+	if err := c.validateCIDRs(); err != nil {
+		return err
+	}
+
+	if c.LBSubnetCount > DefaultLBSubnetCount {
+		return fmt.Errorf("Invalid load balancer subnet count: %d, at most %d subnets are supported", c.LBSubnetCount, DefaultLBSubnetCount)
+	}
+
+	if c.LogLevel != "" {
+		if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+			return fmt.Errorf("Invalid log level: %s", c.LogLevel)
 		}
-		if nodePool.Image == "" && !update {
-			return fmt.Errorf("NodePool[%s]: Node image must be specified", name)
+	}
+
+	// the cluster is not provisioned if the policies would not be enforced
+	if c.NetworkPolicies != nil && (len(c.NetworkPolicies.DefaultDenyNamespaces) > 0 || len(c.NetworkPolicies.Policies) > 0) {
+		return fmt.Errorf("NetworkPolicies are not supported, they are not enforced by the %s network plugin of the cluster", CNIFlannel)
+	}
+
+	for key := range c.Tags {
+		if key == "" {
+			return fmt.Errorf("Tag key must be specified")
+		}
+	}
+
+	for namespace, quota := range c.ResourceQuotas {
+		if err := quota.Validate(); err != nil {
+			return fmt.Errorf("ResourceQuota[%s]: %s", namespace, err.Error())
 		}
+	}
+
+	nodePoolVersions := make(map[string]string)
+	for name, nodePool := range c.NodePools {
+		nodePoolVersions[name] = nodePool.Version
+	}
+
+	if err := ValidateVersionSkew(c.Version, nodePoolVersions); err != nil {
+		return err
+	}
+
+	for name, nodePool := range c.NodePools {
 		if nodePool.Shape == "" && !update {
 			return fmt.Errorf("NodePool[%s]: Node shape must be specified", name)
 		}
+		if nodePool.UpdateBatchSize != "" {
+			if _, err := ParseUpdateBatchSize(nodePool.UpdateBatchSize, nodePool.Count); err != nil {
+				return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+			}
+		}
+		if nodePool.VolumeKMSKeyID != "" {
+			if !strings.HasPrefix(nodePool.VolumeKMSKeyID, "ocid1.key.") {
+				return fmt.Errorf("NodePool[%s]: Invalid volume KMS key OCID: %s", name, nodePool.VolumeKMSKeyID)
+			}
+			if nodePool.VolumeKMSEndpoint == "" {
+				return fmt.Errorf("NodePool[%s]: Vault management endpoint must be specified for the volume KMS key", name)
+			}
+		}
+		if nodePool.BootVolumeVPUsPerGB != 0 {
+			vpus := nodePool.BootVolumeVPUsPerGB
+			if vpus < oci.MinBootVolumeVPUsPerGB || vpus > oci.MaxBootVolumeVPUsPerGB || vpus%oci.BootVolumeVPUsPerGBStep != 0 {
+				return fmt.Errorf("NodePool[%s]: Boot volume VPUs/GB must be between %d and %d in steps of %d", name, oci.MinBootVolumeVPUsPerGB, oci.MaxBootVolumeVPUsPerGB, oci.BootVolumeVPUsPerGBStep)
+			}
+		}
+		taints := make(map[string]bool)
+		for _, taint := range nodePool.StartupTaints {
+			if err := taint.Validate(); err != nil {
+				return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+			}
+			// the same key may be used with different effects only
+			if taints[taint.Key+":"+taint.Effect] {
+				return fmt.Errorf("NodePool[%s]: Duplicate taint: %s:%s", name, taint.Key, taint.Effect)
+			}
+			taints[taint.Key+":"+taint.Effect] = true
+		}
+		if nodePool.Autoscaling && (nodePool.MinCount < 1 || nodePool.MinCount > nodePool.MaxCount) {
+			return fmt.Errorf("NodePool[%s]: Invalid autoscaling bounds: min count must be at least 1 and not greater than max count", name)
+		}
+		priorityClassName := ""
+		if nodePool.PriorityClass != nil {
+			priorityClassName = nodePool.PriorityClass.Name
+		}
+		if err := ValidateNodePoolLabels(name, priorityClassName, nodePool.Labels); err != nil {
+			return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+		}
+		if err := ValidateUserData(nodePool.UserData); err != nil {
+			return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+		}
+		if nodePool.PriorityClass != nil {
+			if err := nodePool.PriorityClass.Validate(); err != nil {
+				return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the taint key and effect
+func (t Taint) Validate() error {
+
+	if t.Key == "" {
+		return fmt.Errorf("Taint key must be specified")
+	}
+
+	switch t.Effect {
+	case "NoSchedule", "PreferNoSchedule", "NoExecute":
+		return nil
+	default:
+		return fmt.Errorf("Invalid taint effect: %s", t.Effect)
+	}
+}
+
+// Validate validates the name and value of the PriorityClass
+func (p *PriorityClass) Validate() error {
+
+	if errs := validation.IsDNS1123Subdomain(p.Name); len(errs) > 0 {
+		return fmt.Errorf("Invalid PriorityClass name %q: %s", p.Name, strings.Join(errs, ", "))
+	}
+
+	if p.Value > maxPriorityClassValue {
+		return fmt.Errorf("PriorityClass value must not be greater than %d", maxPriorityClassValue)
+	}
+
+	return nil
+}
+
+// validateCIDRs validates the VCN, POD and service CIDR blocks
+func (c *Cluster) validateCIDRs() error {
+
+	if c.VCNCIDR != "" {
+		if err := network.ValidatePreconfiguredVCNCIDR(c.VCNCIDR); err != nil {
+			return err
+		}
+		for name, CIDR := range map[string]string{"POD": c.PodCIDR, "service": c.ServiceCIDR} {
+			if CIDR == "" {
+				continue
+			}
+			overlaps, err := network.CIDRsOverlap(c.VCNCIDR, CIDR)
+			if err != nil {
+				return err
+			}
+			if overlaps {
+				return fmt.Errorf("VCN CIDR %s overlaps with %s CIDR %s", c.VCNCIDR, name, CIDR)
+			}
+		}
+	}
+
+	for _, CIDR := range []string{c.PodCIDR, c.ServiceCIDR} {
+		if CIDR == "" {
+			continue
+		}
+		if _, err := network.ParseIPv4CIDR(CIDR); err != nil {
+			return err
+		}
+	}
+
+	if c.PodCIDR != "" && c.ServiceCIDR != "" {
+		overlaps, err := network.CIDRsOverlap(c.PodCIDR, c.ServiceCIDR)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return fmt.Errorf("POD CIDR %s overlaps with service CIDR %s", c.PodCIDR, c.ServiceCIDR)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the quantities of the resource quota
+func (q *ResourceQuota) Validate() error {
+
+	if q == nil {
+		return fmt.Errorf("ResourceQuota is <nil>")
+	}
+
+	for name, value := range map[string]string{
+		"cpu":           q.CPU,
+		"memory":        q.Memory,
+		"defaultCpu":    q.DefaultCPU,
+		"defaultMemory": q.DefaultMemory,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("Invalid %s quantity: %s", name, value)
+		}
 	}
 
 	return nil
@@ -1,6 +1,19 @@
 package cluster
 
 const (
-	defaultImage   = "Oracle-Linux-7.5" // todo needs to be refactor out in change where defaults came from config
-	defaultVersion = "v1.10.3"          // todo needs to be refactor out in change where defaults came from config
+	defaultVersion = "v1.10.3" // todo needs to be refactor out in change where defaults came from config
+
+	defaultHealthCheckGracePeriod = 300 // seconds
+)
+
+// DefaultLBSubnetCount is the number of load balancer subnets of a cluster if it is not specified,
+// flexible load balancers need only one subnet
+const DefaultLBSubnetCount = 2
+
+// CNI types
+const (
+	CNIFlannel = "flannel"
 )
+
+// FlannelMaxPodsPerNode is the max pods per node of flannel based clusters (kubelet default)
+const FlannelMaxPodsPerNode = 110
@@ -0,0 +1,39 @@
+package cluster
+
+// NodeDistribution describes how the nodes of a node pool are placed into the worker subnets of the VCN
+type NodeDistribution struct {
+	QuantityPerSubnet uint
+	SubnetCount       int
+	Counts            []uint // number of nodes by worker subnet, in the order of the worker subnets
+}
+
+// DistributeNodeCount spreads count nodes across the given number of worker subnets as evenly as possible, OKE places
+// the same number of nodes into each subnet of a node pool, so the nodes are spread across the largest number of
+// subnets which divides the count, the subnets without nodes have zero count
+//
+// With 3 subnets this gives the same placement as the original algorithm: counts divisible by 3 are spread across
+// 3 subnets, even counts across 2 subnets and the rest is placed into the first subnet.
+func DistributeNodeCount(count uint, subnetCount int) NodeDistribution {
+
+	distribution := NodeDistribution{
+		Counts: make([]uint, subnetCount),
+	}
+
+	if count == 0 || subnetCount == 0 {
+		return distribution
+	}
+
+	for n := subnetCount; n > 0; n-- {
+		if count%uint(n) == 0 {
+			distribution.SubnetCount = n
+			distribution.QuantityPerSubnet = count / uint(n)
+			break
+		}
+	}
+
+	for i := 0; i < distribution.SubnetCount; i++ {
+		distribution.Counts[i] = distribution.QuantityPerSubnet
+	}
+
+	return distribution
+}
@@ -0,0 +1,38 @@
+package cluster_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+func TestDistributeNodeCount(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		count       uint
+		subnetCount int
+		counts      []uint
+	}{
+		{"3 subnets, divisible by 3", 6, 3, []uint{2, 2, 2}},
+		{"3 subnets, even", 4, 3, []uint{2, 2, 0}},
+		{"3 subnets, odd", 5, 3, []uint{5, 0, 0}},
+		{"5 subnets", 10, 5, []uint{2, 2, 2, 2, 2}},
+		{"5 subnets, divisible by 4", 8, 5, []uint{2, 2, 2, 2, 0}},
+		{"more subnets than nodes", 2, 5, []uint{1, 1, 0, 0, 0}},
+		{"zero count", 0, 3, []uint{0, 0, 0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			distribution := cluster.DistributeNodeCount(tc.count, tc.subnetCount)
+			if !reflect.DeepEqual(distribution.Counts, tc.counts) {
+				t.Errorf("Expected counts: %v, got: %v", tc.counts, distribution.Counts)
+			}
+			if distribution.QuantityPerSubnet*uint(distribution.SubnetCount) != tc.count {
+				t.Errorf("Expected %d nodes in total, got: %d x %d", tc.count, distribution.SubnetCount, distribution.QuantityPerSubnet)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+// ReservedLabelPrefix is the prefix of the node labels managed by pipeline, the node pool of a node is identified
+// by such a label so user labels must not use it
+const ReservedLabelPrefix = "pipeline-nodepool-"
+
+// ValidateNodePoolLabels checks that the labels of the node pool do not use the reserved label prefix, only the
+// node pool name and the priority class labels set by pipeline are allowed
+func ValidateNodePoolLabels(nodePoolName, priorityClassName string, labels map[string]string) error {
+
+	for key, value := range labels {
+		switch {
+		case key == pkgCommon.LabelKey:
+			if value != nodePoolName {
+				return fmt.Errorf("Label %s is reserved for the node pool name", key)
+			}
+		case key == PriorityClassLabelKey:
+			if priorityClassName == "" || value != priorityClassName {
+				return fmt.Errorf("Label %s is reserved for the priority class of the node pool", key)
+			}
+		case strings.HasPrefix(key, ReservedLabelPrefix):
+			return fmt.Errorf("Label %s uses the reserved prefix %s", key, ReservedLabelPrefix)
+		}
+	}
+
+	return nil
+}
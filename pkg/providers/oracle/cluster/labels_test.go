@@ -0,0 +1,37 @@
+package cluster_test
+
+import (
+	"testing"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+func TestValidateNodePoolLabels(t *testing.T) {
+
+	cases := []struct {
+		name          string
+		priorityClass string
+		labels        map[string]string
+		err           bool
+	}{
+		{"user labels", "", map[string]string{"team": "backend", "pipeline": "ci"}, false},
+		{"node pool name label", "", map[string]string{pkgCommon.LabelKey: "pool1"}, false},
+		{"other node pool name", "", map[string]string{pkgCommon.LabelKey: "pool2"}, true},
+		{"priority class label", "high", map[string]string{cluster.PriorityClassLabelKey: "high"}, false},
+		{"priority class label without priority class", "", map[string]string{cluster.PriorityClassLabelKey: "high"}, true},
+		{"reserved prefix", "", map[string]string{cluster.ReservedLabelPrefix + "custom": "x"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := cluster.ValidateNodePoolLabels("pool1", tc.priorityClass, tc.labels)
+			if tc.err && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
@@ -1,22 +1,25 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/containerengine"
 )
 
 // CreateCluster creates a new cluster
-func (cm *ClusterManager) CreateCluster(clusterModel *model.Cluster) error {
+func (cm *ClusterManager) CreateCluster(ctx context.Context, clusterModel *model.Cluster) error {
 
 	ce, err := cm.oci.NewContainerEngineClient()
 	if err != nil {
 		return err
 	}
 
-	clusters, err := ce.GetClustersByName(clusterModel.Name)
+	clusters, err := ce.GetClustersByName(ctx, clusterModel.Name)
 	if err != nil {
 		return err
 	}
@@ -33,24 +36,46 @@ func (cm *ClusterManager) CreateCluster(clusterModel *model.Cluster) error {
 	req.VcnId = &clusterModel.VCNID
 	req.KubernetesVersion = &clusterModel.Version
 	req.Options = &containerengine.ClusterCreateOptions{
-		ServiceLbSubnetIds: []string{clusterModel.LBSubnetID1, clusterModel.LBSubnetID2},
+		ServiceLbSubnetIds: clusterModel.GetLBSubnetIDs(),
+	}
+	if clusterModel.PodCIDR != "" || clusterModel.ServiceCIDR != "" {
+		req.Options.KubernetesNetworkConfig = &containerengine.KubernetesNetworkConfig{}
+		if clusterModel.PodCIDR != "" {
+			req.Options.KubernetesNetworkConfig.PodsCidr = common.String(clusterModel.PodCIDR)
+		}
+		if clusterModel.ServiceCIDR != "" {
+			req.Options.KubernetesNetworkConfig.ServicesCidr = common.String(clusterModel.ServiceCIDR)
+		}
 	}
 
 	cm.oci.GetLogger().Infof("Creating cluster[%s]", clusterModel.Name)
-	clusterOCID, err := ce.CreateCluster(req)
+	details := make(map[string]interface{})
+	if clusterModel.PrivateEndpoint && clusterModel.EndpointSubnetID != "" {
+		details = oci.GetPrivateEndpointConfigDetails(clusterModel.EndpointSubnetID)
+	}
+	if len(clusterModel.Tags) > 0 {
+		details["freeformTags"] = clusterModel.Tags
+	}
+
+	var clusterOCID string
+	if len(details) > 0 {
+		clusterOCID, err = ce.CreateClusterWithDetails(ctx, req, details)
+	} else {
+		clusterOCID, err = ce.CreateCluster(ctx, req)
+	}
 	if err != nil {
 		return err
 	}
 
 	clusterModel.OCID = clusterOCID
 
-	return cm.SyncNodePools(clusterModel)
+	return cm.SyncNodePools(ctx, clusterModel)
 }
 
 // UpdateCluster updates the cluster
-func (cm *ClusterManager) UpdateCluster(clusterModel *model.Cluster) error {
+func (cm *ClusterManager) UpdateCluster(ctx context.Context, clusterModel *model.Cluster) error {
 
-	cluster, err := cm.GetCluster(&clusterModel.OCID)
+	cluster, err := cm.GetCluster(ctx, &clusterModel.OCID)
 	if err != nil {
 		return err
 	}
@@ -70,9 +95,12 @@ func (cm *ClusterManager) UpdateCluster(clusterModel *model.Cluster) error {
 		UpdateClusterDetails: containerengine.UpdateClusterDetails{},
 	}
 
+	// the control plane is upgraded first, the node pools follow it
 	if *cluster.KubernetesVersion != clusterModel.Version {
-		update = true
-		req.KubernetesVersion = common.String(clusterModel.Version)
+		err = cm.UpgradeMaster(ctx, clusterModel)
+		if err != nil {
+			return err
+		}
 	}
 	if *cluster.Name != clusterModel.Name {
 		update = true
@@ -82,24 +110,58 @@ func (cm *ClusterManager) UpdateCluster(clusterModel *model.Cluster) error {
 	cm.oci.GetLogger().Infof("Updating cluster[%s]", *cluster.Name)
 
 	if update {
-		_, err := ce.UpdateCluster(req)
+		_, err := ce.UpdateCluster(ctx, req)
 		if err != nil {
 			return err
 		}
 	}
 
-	return cm.SyncNodePools(clusterModel)
+	return cm.SyncNodePools(ctx, clusterModel)
+}
+
+// UpgradeMaster upgrades the control plane of the cluster to the version of the model, the node pools are not changed
+func (cm *ClusterManager) UpgradeMaster(ctx context.Context, clusterModel *model.Cluster) error {
+
+	cluster, err := cm.GetCluster(ctx, &clusterModel.OCID)
+	if err != nil {
+		return err
+	}
+
+	if *cluster.KubernetesVersion == clusterModel.Version {
+		return nil
+	}
+
+	err = oracle.ValidateUpgradePath(*cluster.KubernetesVersion, clusterModel.Version, cluster.AvailableKubernetesUpgrades)
+	if err != nil {
+		return err
+	}
+
+	ce, err := cm.oci.NewContainerEngineClient()
+	if err != nil {
+		return err
+	}
+
+	cm.oci.GetLogger().Infof("Upgrading control plane of cluster[%s] from %s to %s", *cluster.Name, *cluster.KubernetesVersion, clusterModel.Version)
+
+	_, err = ce.UpdateCluster(ctx, containerengine.UpdateClusterRequest{
+		ClusterId: cluster.Id,
+		UpdateClusterDetails: containerengine.UpdateClusterDetails{
+			KubernetesVersion: common.String(clusterModel.Version),
+		},
+	})
+
+	return err
 }
 
 // DeleteCluster deletes a cluster
-func (cm *ClusterManager) DeleteCluster(clusterModel *model.Cluster) error {
+func (cm *ClusterManager) DeleteCluster(ctx context.Context, clusterModel *model.Cluster) error {
 
 	ce, err := cm.oci.NewContainerEngineClient()
 	if err != nil {
 		return err
 	}
 
-	cluster, err := cm.GetCluster(&clusterModel.OCID)
+	cluster, err := cm.GetCluster(ctx, &clusterModel.OCID)
 	if err != nil {
 		return err
 	}
@@ -114,16 +176,16 @@ func (cm *ClusterManager) DeleteCluster(clusterModel *model.Cluster) error {
 
 	cm.oci.GetLogger().Infof("Deleting cluster[%s]", *cluster.Name)
 
-	return ce.DeleteCluster(req)
+	return ce.DeleteCluster(ctx, req)
 }
 
 // GetCluster gets cluster info by id
-func (cm *ClusterManager) GetCluster(id *string) (cluster containerengine.Cluster, err error) {
+func (cm *ClusterManager) GetCluster(ctx context.Context, id *string) (cluster containerengine.Cluster, err error) {
 
 	ce, err := cm.oci.NewContainerEngineClient()
 	if err != nil {
 		return cluster, err
 	}
 
-	return ce.GetCluster(id)
+	return ce.GetCluster(ctx, id)
 }
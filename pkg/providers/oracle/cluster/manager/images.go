@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// defaultNodeImage is the image of the node pools without an image, the latest available version of its operating
+// system is used
+const defaultNodeImage = "Oracle-Linux-7.5"
+
+// ResolveNodeImage gives back the node image for the k8s version in the region of the cluster manager, the image can
+// be given by name, OCID or '<operating system> <version>', the default image is resolved if it is empty
+func (cm *ClusterManager) ResolveNodeImage(k8sVersion, image string) (oci.NodeImage, error) {
+
+	images, err := cm.getNodeImages(k8sVersion)
+	if err != nil {
+		return oci.NodeImage{}, err
+	}
+
+	if image != "" {
+		return oci.ResolveNodeImage(images, image)
+	}
+
+	if latest, found := oci.LatestNodeImage(images, defaultNodeImage); found {
+		return latest, nil
+	}
+
+	return oci.ResolveNodeImage(images, defaultNodeImage)
+}
+
+// ValidateNodeImage checks that the image of the node pool is available for the k8s version of the node pool
+func (cm *ClusterManager) ValidateNodeImage(np *model.NodePool) error {
+
+	images, err := cm.getNodeImages(np.Version)
+	if err != nil {
+		return fmt.Errorf("NodePool[%s]: %s", np.Name, err.Error())
+	}
+
+	if _, err := oci.ResolveNodeImage(images, np.Image); err != nil {
+		return fmt.Errorf("NodePool[%s]: Node image '%s' is not compatible with k8s version %s", np.Name, np.Image, np.Version)
+	}
+
+	return nil
+}
+
+// getNodeImages gives back the node images of the k8s version, the images are listed once per version
+func (cm *ClusterManager) getNodeImages(k8sVersion string) ([]oci.NodeImage, error) {
+
+	if images, ok := cm.nodeImages[k8sVersion]; ok {
+		return images, nil
+	}
+
+	images, err := cm.oci.ListNodePoolImages(k8sVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if cm.nodeImages == nil {
+		cm.nodeImages = make(map[string][]oci.NodeImage)
+	}
+	cm.nodeImages[k8sVersion] = images
+
+	return images, nil
+}
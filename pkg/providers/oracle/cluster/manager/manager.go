@@ -1,6 +1,8 @@
 package manager
 
 import (
+	"context"
+
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 )
@@ -8,6 +10,11 @@ import (
 // ClusterManager for managing Cluster state
 type ClusterManager struct {
 	oci *oci.OCI
+
+	// node images by k8s version
+	nodeImages map[string][]oci.NodeImage
+	// number of node pools synced in parallel
+	nodePoolConcurrency int
 }
 
 // NewClusterManager creates a new ClusterManager
@@ -18,19 +25,24 @@ func NewClusterManager(oci *oci.OCI) *ClusterManager {
 	}
 }
 
-// ManageOKECluster manages an OKE cluster specified in a model.Cluster
-func (cm *ClusterManager) ManageOKECluster(clusterModel *model.Cluster) error {
+// ManageOKECluster manages an OKE cluster specified in a model.Cluster,
+// the OCI API calls are made with the given context
+func (cm *ClusterManager) ManageOKECluster(ctx context.Context, clusterModel *model.Cluster) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Creating
 	if clusterModel.OCID == "" && !clusterModel.Delete {
-		return cm.CreateCluster(clusterModel)
+		return cm.CreateCluster(ctx, clusterModel)
 	}
 
 	// Deleting
 	if clusterModel.Delete {
-		return cm.DeleteCluster(clusterModel)
+		return cm.DeleteCluster(ctx, clusterModel)
 	}
 
 	// Updating
-	return cm.UpdateCluster(clusterModel)
+	return cm.UpdateCluster(ctx, clusterModel)
 }
@@ -1,31 +1,60 @@
 package manager
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/containerengine"
 )
 
-// SyncNodePools keeps the cluster node pools in state with the model
-func (cm *ClusterManager) SyncNodePools(clusterModel *model.Cluster) error {
+// NodePoolErrors is returned when some node pools could not be synced, the errors are keyed by node pool name
+type NodePoolErrors struct {
+	Errors map[string]error
+}
+
+func (e *NodePoolErrors) Error() string {
+
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("NodePool[%s]: %s", name, e.Errors[name].Error()))
+	}
+
+	return fmt.Sprintf("error syncing %d node pool(s): %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// SetNodePoolConcurrency sets the number of node pools which are synced in parallel
+func (cm *ClusterManager) SetNodePoolConcurrency(concurrency int) {
+
+	cm.nodePoolConcurrency = concurrency
+}
+
+// SyncNodePools keeps the cluster node pools in state with the model, the node pools are synced in parallel.
+// A failing node pool does not stop the others, every node pool is synced before the errors are returned.
+func (cm *ClusterManager) SyncNodePools(ctx context.Context, clusterModel *model.Cluster) error {
 
 	cm.oci.GetLogger().Infof("Syncing Node Pools states of Cluster[%s]", clusterModel.Name)
 
-	for _, np := range clusterModel.NodePools {
-		if np.Add {
-			if err := cm.AddNodePool(clusterModel, np); err != nil {
-				return err
-			}
-		} else if np.Delete {
-			if err := cm.DeleteNodePool(clusterModel, np); err != nil {
-				return err
-			}
-		} else {
-			if err := cm.UpdateNodePool(clusterModel, np); err != nil {
-				return err
-			}
+	err := syncNodePoolsInParallel(clusterModel.NodePools, cm.nodePoolConcurrency, func(np *model.NodePool) error {
+		err := cm.syncNodePool(ctx, clusterModel, np)
+		if err != nil {
+			cm.oci.GetLogger().Errorf("NodePool[%s]: %s", np.Name, err.Error())
 		}
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	ce, err := cm.oci.NewContainerEngineClient()
@@ -33,18 +62,79 @@ func (cm *ClusterManager) SyncNodePools(clusterModel *model.Cluster) error {
 		return err
 	}
 
-	return ce.WaitingForClusterNodePoolActiveState(&clusterModel.OCID)
+	err = ce.WaitingForClusterNodePoolActiveState(ctx, &clusterModel.OCID)
+	if err != nil {
+		return err
+	}
+
+	err = cm.SyncNodeVolumes(ctx, clusterModel)
+	if err != nil {
+		return err
+	}
+
+	return cm.SyncNodeTags(ctx, clusterModel)
+}
+
+// syncNodePoolsInParallel calls syncNodePool for every node pool, at most concurrency of them at a time,
+// the errors of the failing node pools are collected into a NodePoolErrors
+func syncNodePoolsInParallel(nodePools []*model.NodePool, concurrency int, syncNodePool func(np *model.NodePool) error) error {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+
+	sem := make(chan struct{}, concurrency)
+	for _, np := range nodePools {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(np *model.NodePool) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := syncNodePool(np); err != nil {
+				mu.Lock()
+				errs[np.Name] = err
+				mu.Unlock()
+			}
+		}(np)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &NodePoolErrors{Errors: errs}
+	}
+
+	return nil
+}
+
+// syncNodePool adds, deletes or updates the node pool according to the model
+func (cm *ClusterManager) syncNodePool(ctx context.Context, clusterModel *model.Cluster, np *model.NodePool) error {
+
+	if np.Add {
+		return cm.AddNodePool(ctx, clusterModel, np)
+	}
+	if np.Delete {
+		return cm.DeleteNodePool(ctx, clusterModel, np)
+	}
+
+	return cm.UpdateNodePool(ctx, clusterModel, np)
 }
 
 // UpdateNodePool updates node pool in a cluster
-func (cm *ClusterManager) UpdateNodePool(clusterModel *model.Cluster, np *model.NodePool) error {
+func (cm *ClusterManager) UpdateNodePool(ctx context.Context, clusterModel *model.Cluster, np *model.NodePool) error {
 
 	ce, err := cm.oci.NewContainerEngineClient()
 	if err != nil {
 		return err
 	}
 
-	nodePool, err := ce.GetNodePoolByName(&clusterModel.OCID, np.Name)
+	nodePool, err := ce.GetNodePoolByName(ctx, &clusterModel.OCID, np.Name)
 	if err != nil && !oci.IsEntityNotFoundError(err) {
 		return err
 	}
@@ -72,7 +162,7 @@ func (cm *ClusterManager) UpdateNodePool(clusterModel *model.Cluster, np *model.
 		})
 	}
 
-	_, err = ce.UpdateNodePool(request)
+	_, err = ce.UpdateNodePool(ctx, request)
 	if err != nil {
 		return err
 	}
@@ -81,7 +171,7 @@ func (cm *ClusterManager) UpdateNodePool(clusterModel *model.Cluster, np *model.
 }
 
 // DeleteNodePool deletes a node pool from a cluster
-func (cm *ClusterManager) DeleteNodePool(clusterModel *model.Cluster, np *model.NodePool) error {
+func (cm *ClusterManager) DeleteNodePool(ctx context.Context, clusterModel *model.Cluster, np *model.NodePool) error {
 
 	cm.oci.GetLogger().Infof("Deleting NodePool[%s]", np.Name)
 
@@ -90,18 +180,18 @@ func (cm *ClusterManager) DeleteNodePool(clusterModel *model.Cluster, np *model.
 		return err
 	}
 
-	return ce.DeleteNodePoolByName(&clusterModel.OCID, np.Name)
+	return ce.DeleteNodePoolByName(ctx, &clusterModel.OCID, np.Name)
 }
 
 // AddNodePool creates a new node pool in a cluster
-func (cm *ClusterManager) AddNodePool(clusterModel *model.Cluster, np *model.NodePool) error {
+func (cm *ClusterManager) AddNodePool(ctx context.Context, clusterModel *model.Cluster, np *model.NodePool) error {
 
 	ce, err := cm.oci.NewContainerEngineClient()
 	if err != nil {
 		return err
 	}
 
-	nodePool, err := ce.GetNodePoolByName(&clusterModel.OCID, np.Name)
+	nodePool, err := ce.GetNodePoolByName(ctx, &clusterModel.OCID, np.Name)
 	if err != nil && !oci.IsEntityNotFoundError(err) {
 		return err
 	}
@@ -110,6 +200,11 @@ func (cm *ClusterManager) AddNodePool(clusterModel *model.Cluster, np *model.Nod
 		return nil
 	}
 
+	tags, err := getClusterTags(clusterModel)
+	if err != nil {
+		return err
+	}
+
 	cm.oci.GetLogger().Infof("Adding Node Pool[%s] to Cluster[%s]", np.Name, clusterModel.Name)
 
 	// create NodePool
@@ -131,7 +226,22 @@ func (cm *ClusterManager) AddNodePool(clusterModel *model.Cluster, np *model.Nod
 		})
 	}
 
-	nodepoolOCID, err := ce.CreateNodePool(createNodePoolReq)
+	details := make(map[string]interface{})
+	if np.UserData != "" {
+		details["nodeMetadata"] = map[string]string{
+			oci.NodeMetadataUserData: np.UserData,
+		}
+	}
+	if len(tags) > 0 {
+		details["freeformTags"] = tags
+	}
+
+	var nodepoolOCID string
+	if len(details) > 0 {
+		nodepoolOCID, err = ce.CreateNodePoolWithDetails(ctx, createNodePoolReq, details)
+	} else {
+		nodepoolOCID, err = ce.CreateNodePool(ctx, createNodePoolReq)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+func TestSyncNodePoolsInParallel(t *testing.T) {
+
+	nodePools := []*model.NodePool{
+		{Name: "pool1"},
+		{Name: "pool2"},
+		{Name: "pool3"},
+		{Name: "pool4"},
+	}
+
+	cases := []struct {
+		name        string
+		concurrency int
+		failing     map[string]bool
+	}{
+		{name: "all synced", concurrency: 4, failing: map[string]bool{}},
+		{name: "one failing", concurrency: 4, failing: map[string]bool{"pool2": true}},
+		{name: "all failing", concurrency: 2, failing: map[string]bool{"pool1": true, "pool2": true, "pool3": true, "pool4": true}},
+		{name: "concurrency 1", concurrency: 1, failing: map[string]bool{"pool3": true}},
+		{name: "concurrency 0", concurrency: 0, failing: map[string]bool{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var mu sync.Mutex
+			synced := make(map[string]bool)
+			running, maxRunning := 0, 0
+
+			err := syncNodePoolsInParallel(nodePools, tc.concurrency, func(np *model.NodePool) error {
+				mu.Lock()
+				synced[np.Name] = true
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+
+				if tc.failing[np.Name] {
+					return errors.New("sync failed")
+				}
+				return nil
+			})
+
+			for _, np := range nodePools {
+				if !synced[np.Name] {
+					t.Errorf("NodePool[%s] was not synced", np.Name)
+				}
+			}
+
+			limit := tc.concurrency
+			if limit < 1 {
+				limit = 1
+			}
+			if maxRunning > limit {
+				t.Errorf("expected at most %d node pools synced at a time, got: %d", limit, maxRunning)
+			}
+
+			if len(tc.failing) == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got: %s", err.Error())
+				}
+				return
+			}
+
+			nodePoolErrors, ok := err.(*NodePoolErrors)
+			if !ok {
+				t.Fatalf("expected NodePoolErrors, got: %v", err)
+			}
+			if len(nodePoolErrors.Errors) != len(tc.failing) {
+				t.Errorf("expected %d node pool errors, got: %d", len(tc.failing), len(nodePoolErrors.Errors))
+			}
+			for name := range tc.failing {
+				if nodePoolErrors.Errors[name] == nil {
+					t.Errorf("expected error for NodePool[%s]", name)
+				}
+			}
+		})
+	}
+}
+
+func TestNodePoolErrorsError(t *testing.T) {
+
+	err := &NodePoolErrors{Errors: map[string]error{
+		"pool2": errors.New("quota exceeded"),
+		"pool1": errors.New("not found"),
+	}}
+
+	expected := "error syncing 2 node pool(s): NodePool[pool1]: not found; NodePool[pool2]: quota exceeded"
+	if err.Error() != expected {
+		t.Errorf("expected: %s, got: %s", expected, err.Error())
+	}
+}
@@ -0,0 +1,74 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+)
+
+// SyncNodeTags sets the tags of the cluster as freeform tags on the instances of its nodes, the cluster and the
+// node pools are tagged at creation but OCI doesn't propagate their tags to the instances; only the instances
+// missing a tag are updated
+func (cm *ClusterManager) SyncNodeTags(ctx context.Context, clusterModel *model.Cluster) error {
+
+	tags, err := getClusterTags(clusterModel)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	ce, err := cm.oci.NewContainerEngineClient()
+	if err != nil {
+		return err
+	}
+
+	compute, err := cm.oci.NewComputeClient()
+	if err != nil {
+		return err
+	}
+
+	instanceTags, err := compute.GetInstanceFreeformTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, np := range clusterModel.NodePools {
+		if np.Delete {
+			continue
+		}
+
+		summary, err := ce.GetNodePoolByName(ctx, &clusterModel.OCID, np.Name)
+		if err != nil {
+			return err
+		}
+
+		nodePool, err := ce.GetNodePool(ctx, summary.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range nodePool.Nodes {
+			if node.Id == nil {
+				continue
+			}
+			if err := compute.UpdateInstanceFreeformTags(ctx, *node.Id, instanceTags[*node.Id], tags); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getClusterTags gives back the tags of the cluster, the stored ones if the model doesn't hold them
+func getClusterTags(clusterModel *model.Cluster) (map[string]string, error) {
+
+	if clusterModel.Tags != nil || clusterModel.ID == 0 {
+		return clusterModel.Tags, nil
+	}
+
+	return clusterModel.GetClusterTags()
+}
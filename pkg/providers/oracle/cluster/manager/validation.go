@@ -1,9 +1,13 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 )
 
 // ValidateModel validates model configuration
@@ -16,11 +20,6 @@ func (cm *ClusterManager) ValidateModel(clusterModel *model.Cluster) error {
 		return err
 	}
 
-	ce, err := cm.oci.NewContainerEngineClient()
-	if err != nil {
-		return err
-	}
-
 	if m.Delete && m.OCID == "" {
 		return fmt.Errorf("Cannot delete cluster without Cluster OCID specified")
 	}
@@ -30,6 +29,19 @@ func (cm *ClusterManager) ValidateModel(clusterModel *model.Cluster) error {
 		return fmt.Errorf("Invalid VCN OCID: %s", m.VCNID)
 	}
 
+	for name, CIDR := range map[string]string{"POD": m.PodCIDR, "service": m.ServiceCIDR} {
+		if CIDR == "" {
+			continue
+		}
+		overlaps, err := network.CIDRsOverlap(CIDR, *vcn.CidrBlock)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return fmt.Errorf("Invalid %s CIDR: %s overlaps with VCN CIDR %s", name, CIDR, *vcn.CidrBlock)
+		}
+	}
+
 	subnet, err := vn.GetSubnet(&m.LBSubnetID1)
 	if err != nil {
 		return fmt.Errorf("Invalid LB 1 Subnet OCID: %s", m.LBSubnetID1)
@@ -38,15 +50,70 @@ func (cm *ClusterManager) ValidateModel(clusterModel *model.Cluster) error {
 		return fmt.Errorf("Invalid LB 1 Subnet OCID: %s not in VCN[%s]", m.LBSubnetID1, *vcn.Id)
 	}
 
-	subnet, err = vn.GetSubnet(&m.LBSubnetID2)
+	if m.GetLBSubnetCount() > 1 {
+		subnet, err = vn.GetSubnet(&m.LBSubnetID2)
+		if err != nil {
+			return fmt.Errorf("Invalid LB 2 Subnet OCID: %s", m.LBSubnetID2)
+		}
+		if *subnet.VcnId != *vcn.Id {
+			return fmt.Errorf("Invalid LB 2 Subnet OCID: %s not in VCN[%s]", m.LBSubnetID2, *vcn.Id)
+		}
+	}
+
+	if m.EndpointSubnetID != "" {
+		subnet, err = vn.GetSubnet(&m.EndpointSubnetID)
+		if err != nil {
+			return fmt.Errorf("Invalid Endpoint Subnet OCID: %s", m.EndpointSubnetID)
+		}
+		if *subnet.VcnId != *vcn.Id {
+			return fmt.Errorf("Invalid Endpoint Subnet OCID: %s not in VCN[%s]", m.EndpointSubnetID, *vcn.Id)
+		}
+		if m.EndpointSubnetID == m.LBSubnetID1 || m.EndpointSubnetID == m.LBSubnetID2 {
+			return fmt.Errorf("Invalid Endpoint Subnet OCID: %s is a loadbalancer subnet", m.EndpointSubnetID)
+		}
+		for _, np := range m.NodePools {
+			for _, s := range np.Subnets {
+				if s.SubnetID == m.EndpointSubnetID {
+					return fmt.Errorf("Invalid Endpoint Subnet OCID: %s is a worker subnet of NodePool[%s]", m.EndpointSubnetID, np.Name)
+				}
+			}
+		}
+	} else if m.PrivateEndpoint {
+		return fmt.Errorf("Endpoint subnet must be specified for private endpoint")
+	}
+
+	err = cm.ValidateNodePoolOptions(clusterModel)
 	if err != nil {
-		return fmt.Errorf("Invalid LB 2 Subnet OCID: %s", m.LBSubnetID2)
+		return err
 	}
-	if *subnet.VcnId != *vcn.Id {
-		return fmt.Errorf("Invalid LB 2 Subnet OCID: %s not in VCN[%s]", m.LBSubnetID2, *vcn.Id)
+
+	for _, np := range m.NodePools {
+		if len(np.Subnets) < 1 {
+			return fmt.Errorf("There must be at least 1 subnet specified")
+		}
+
+		for _, subnet := range np.Subnets {
+			if _, err := vn.GetSubnet(&subnet.SubnetID); err != nil {
+				return fmt.Errorf("Invalid Subnet OCID: %s", subnet.SubnetID)
+			}
+		}
 	}
 
-	k8sVersions, err := ce.GetAvailableKubernetesVersions()
+	return nil
+}
+
+// ValidateNodePoolOptions validates the k8s versions, images and shapes of the model against the options of OKE,
+// it does not depend on the network of the cluster
+func (cm *ClusterManager) ValidateNodePoolOptions(clusterModel *model.Cluster) error {
+
+	m := clusterModel
+
+	ce, err := cm.oci.NewContainerEngineClient()
+	if err != nil {
+		return err
+	}
+
+	k8sVersions, err := ce.GetAvailableKubernetesVersions(context.Background())
 	if err != nil {
 		return err
 	}
@@ -59,7 +126,23 @@ func (cm *ClusterManager) ValidateModel(clusterModel *model.Cluster) error {
 		return fmt.Errorf("At least 1 node pool must be specified")
 	}
 
-	nodeOptions, err := ce.GetDefaultNodePoolOptions()
+	nodeOptions, err := ce.GetDefaultNodePoolOptions(context.Background())
+	if err != nil {
+		return err
+	}
+
+	nodePoolVersions := make(map[string]string)
+	for _, np := range m.NodePools {
+		if !np.Delete {
+			nodePoolVersions[np.Name] = np.Version
+		}
+	}
+
+	if err := oracle.ValidateVersionSkew(m.Version, nodePoolVersions); err != nil {
+		return err
+	}
+
+	regionShapes, err := cm.getRegionShapes()
 	if err != nil {
 		return err
 	}
@@ -68,23 +151,72 @@ func (cm *ClusterManager) ValidateModel(clusterModel *model.Cluster) error {
 		if !nodeOptions.Images.Has(np.Image) {
 			return fmt.Errorf("Invalid node image '%s' at '%s'", np.Image, np.Name)
 		}
+		if !np.Delete {
+			if err := cm.ValidateNodeImage(np); err != nil {
+				return err
+			}
+		}
 		if !nodeOptions.Shapes.Has(np.Shape) {
 			return fmt.Errorf("Invalid shape '%s' at '%s'", np.Shape, np.Name)
 		}
-		if len(np.Subnets) < 1 {
-			return fmt.Errorf("There must be at least 1 subnet specified")
-		}
-
-		if m.Version != np.Version {
-			return fmt.Errorf("NodePool[%s]: Different k8s versions were specified for master[%s] and nodes[%s]", np.Name, m.Version, np.Version)
+		if !np.Delete && !regionShapes[np.Shape] {
+			return &oci.ShapeNotAvailableError{
+				NodePool: np.Name,
+				Shape:    np.Shape,
+				Region:   cm.getRegion(),
+			}
 		}
 
-		for _, subnet := range np.Subnets {
-			if _, err := vn.GetSubnet(&subnet.SubnetID); err != nil {
-				return fmt.Errorf("Invalid Subnet OCID: %s", subnet.SubnetID)
+		if np.VolumeKMSKeyID != "" && !np.Delete {
+			if err := cm.validateVolumeKMSKey(np); err != nil {
+				return err
 			}
 		}
 	}
 
 	return nil
 }
+
+// validateVolumeKMSKey checks that the volume KMS key of the node pool exists in the tenancy and can be used
+func (cm *ClusterManager) validateVolumeKMSKey(np *model.NodePool) error {
+
+	kms, err := cm.oci.NewKMSClient(np.VolumeKMSEndpoint)
+	if err != nil {
+		return err
+	}
+
+	key, err := kms.GetKey(np.VolumeKMSKeyID)
+	if err != nil {
+		return fmt.Errorf("NodePool[%s]: Invalid volume KMS key '%s': %s", np.Name, np.VolumeKMSKeyID, err.Error())
+	}
+
+	if key.LifecycleState != oci.KMSKeyLifecycleStateEnabled {
+		return fmt.Errorf("NodePool[%s]: Volume KMS key '%s' is %s", np.Name, np.VolumeKMSKeyID, key.LifecycleState)
+	}
+
+	return nil
+}
+
+// getRegionShapes gives back the compute shapes offered in the region of the cluster
+func (cm *ClusterManager) getRegionShapes() (map[string]bool, error) {
+
+	names, err := cm.oci.GetRegionShapeNames()
+	if err != nil {
+		return nil, err
+	}
+
+	shapes := make(map[string]bool, len(names))
+	for _, name := range names {
+		shapes[name] = true
+	}
+
+	return shapes, nil
+}
+
+// getRegion gives back the region of the OCI API calls
+func (cm *ClusterManager) getRegion() string {
+
+	region, _ := cm.oci.GetConfig().Region()
+
+	return region
+}
@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/model"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// SyncNodeVolumes sets the KMS key of the node pools on the boot and block volumes of their nodes and the
+// performance of the boot volumes, OKE cannot be given these at node pool creation so they are set on the
+// volumes of the existing nodes
+func (cm *ClusterManager) SyncNodeVolumes(ctx context.Context, clusterModel *model.Cluster) error {
+
+	ce, err := cm.oci.NewContainerEngineClient()
+	if err != nil {
+		return err
+	}
+
+	var compute *oci.Compute
+	var bs *oci.BlockStorage
+
+	for _, np := range clusterModel.NodePools {
+		if np.Delete || (np.VolumeKMSKeyID == "" && np.BootVolumeVPUsPerGB == 0) {
+			continue
+		}
+
+		summary, err := ce.GetNodePoolByName(ctx, &clusterModel.OCID, np.Name)
+		if err != nil {
+			return err
+		}
+
+		nodePool, err := ce.GetNodePool(ctx, summary.Id)
+		if err != nil {
+			return err
+		}
+
+		if compute == nil {
+			if compute, err = cm.oci.NewComputeClient(); err != nil {
+				return err
+			}
+			if bs, err = cm.oci.NewBlockStorageClient(); err != nil {
+				return err
+			}
+		}
+
+		for _, node := range nodePool.Nodes {
+			if node.Id == nil || node.AvailabilityDomain == nil {
+				continue
+			}
+
+			bootVolumeIDs, volumeIDs, err := compute.GetInstanceVolumeIDs(ctx, *node.Id, *node.AvailabilityDomain)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range bootVolumeIDs {
+				if err := cm.syncBootVolume(ctx, bs, np, id); err != nil {
+					return err
+				}
+			}
+
+			if np.VolumeKMSKeyID == "" {
+				continue
+			}
+
+			for _, id := range volumeIDs {
+				keyID, err := bs.GetVolumeKMSKeyID(ctx, id)
+				if err != nil {
+					return err
+				}
+				if keyID == np.VolumeKMSKeyID {
+					continue
+				}
+				cm.oci.GetLogger().Infof("Setting KMS key of block volume %s of NodePool[%s]", id, np.Name)
+				if err := bs.UpdateVolumeKMSKey(ctx, id, np.VolumeKMSKeyID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncBootVolume sets the KMS key and the performance of the node pool on the boot volume
+func (cm *ClusterManager) syncBootVolume(ctx context.Context, bs *oci.BlockStorage, np *model.NodePool, id string) error {
+
+	if np.VolumeKMSKeyID != "" {
+		keyID, err := bs.GetBootVolumeKMSKeyID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if keyID != np.VolumeKMSKeyID {
+			cm.oci.GetLogger().Infof("Setting KMS key of boot volume %s of NodePool[%s]", id, np.Name)
+			if err := bs.UpdateBootVolumeKMSKey(ctx, id, np.VolumeKMSKeyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if np.BootVolumeVPUsPerGB != 0 {
+		vpus, err := bs.GetBootVolumeVPUsPerGB(ctx, id)
+		if err != nil {
+			return err
+		}
+		if vpus != np.BootVolumeVPUsPerGB {
+			cm.oci.GetLogger().Infof("Setting performance of boot volume %s of NodePool[%s] to %d VPUs/GB", id, np.Name, np.BootVolumeVPUsPerGB)
+			if err := bs.UpdateBootVolumeVPUsPerGB(ctx, id, np.BootVolumeVPUsPerGB); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
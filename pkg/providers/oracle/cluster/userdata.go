@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+// EncodeUserData gives back the user data of the nodes base64 encoded as OCI expects it, the user data is
+// considered to be already encoded if it can be decoded as base64
+func EncodeUserData(userData string) string {
+
+	if userData == "" {
+		return ""
+	}
+
+	trimmed := strings.TrimSpace(userData)
+	if _, err := base64.StdEncoding.DecodeString(trimmed); err == nil {
+		return trimmed
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(userData))
+}
+
+// ValidateUserData checks that the encoded user data of the nodes fits in the user data limit of OCI
+func ValidateUserData(userData string) error {
+
+	if size := len(EncodeUserData(userData)); size > oci.MaxNodeUserDataSize {
+		return fmt.Errorf("User data must not be larger than %d bytes base64 encoded, got %d bytes", oci.MaxNodeUserDataSize, size)
+	}
+
+	return nil
+}
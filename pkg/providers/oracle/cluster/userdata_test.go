@@ -0,0 +1,62 @@
+package cluster_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+func TestEncodeUserData(t *testing.T) {
+
+	plain := "#cloud-config\nruncmd:\n  - echo hello\n"
+	encoded := base64.StdEncoding.EncodeToString([]byte(plain))
+
+	cases := []struct {
+		name     string
+		userData string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", plain, encoded},
+		{"encoded", encoded, encoded},
+		{"encoded with newline", encoded + "\n", encoded},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := cluster.EncodeUserData(tc.userData); actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateUserData(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		userData string
+		err      bool
+	}{
+		{"empty", "", false},
+		{"small", "#!/bin/bash\necho hello\n", false},
+		{"encoded at the limit", strings.Repeat("A", oci.MaxNodeUserDataSize), false},
+		{"encoded over the limit", strings.Repeat("A", oci.MaxNodeUserDataSize+4), true},
+		{"plain over the limit when encoded", "#" + strings.Repeat("x", oci.MaxNodeUserDataSize), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := cluster.ValidateUserData(tc.userData)
+			if tc.err && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("Expected no error, got: %s", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxNodePoolMinorVersionSkew is the number of minor versions a node pool can lag behind the control plane
+const maxNodePoolMinorVersionSkew = 2
+
+// VersionSkewError is returned when node pool versions violate OKE's version skew policy
+type VersionSkewError struct {
+	MasterVersion string
+	// node pool name -> version
+	NodePools map[string]string
+}
+
+func (e *VersionSkewError) Error() string {
+
+	names := make([]string, 0, len(e.NodePools))
+	for name := range e.NodePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pools := make([]string, 0, len(names))
+	for _, name := range names {
+		pools = append(pools, fmt.Sprintf("%s (%s)", name, e.NodePools[name]))
+	}
+
+	return fmt.Sprintf("Node pool versions must not be newer than the control plane version %s and must be within %d minor versions of it: %s",
+		e.MasterVersion, maxNodePoolMinorVersionSkew, strings.Join(pools, ", "))
+}
+
+// InvalidUpgradePathError is returned when the control plane can't be upgraded from its version to the requested one
+type InvalidUpgradePathError struct {
+	CurrentVersion   string
+	RequestedVersion string
+	// versions OKE offers as upgrades of the current version
+	AvailableUpgrades []string
+}
+
+func (e *InvalidUpgradePathError) Error() string {
+
+	if len(e.AvailableUpgrades) == 0 {
+		return fmt.Sprintf("Control plane version %s can't be upgraded to %s, no upgrades are available",
+			e.CurrentVersion, e.RequestedVersion)
+	}
+
+	return fmt.Sprintf("Control plane version %s can't be upgraded to %s, available upgrades: %s",
+		e.CurrentVersion, e.RequestedVersion, strings.Join(e.AvailableUpgrades, ", "))
+}
+
+// ValidateUpgradePath validates that the requested control plane version is the current version
+// or one of the upgrades OKE offers for it
+func ValidateUpgradePath(currentVersion, requestedVersion string, availableUpgrades []string) error {
+
+	if requestedVersion == currentVersion {
+		return nil
+	}
+
+	for _, version := range availableUpgrades {
+		if version == requestedVersion {
+			return nil
+		}
+	}
+
+	return &InvalidUpgradePathError{
+		CurrentVersion:    currentVersion,
+		RequestedVersion:  requestedVersion,
+		AvailableUpgrades: availableUpgrades,
+	}
+}
+
+// IsVersionOnlyChange returns true if the requested cluster differs from the current one only in the control plane version
+func IsVersionOnlyChange(current, requested *Cluster) bool {
+
+	if current == nil || requested == nil || current.Version == requested.Version {
+		return false
+	}
+
+	c, r := *current, *requested
+	c.Version, r.Version = "", ""
+
+	return reflect.DeepEqual(c, r)
+}
+
+// ValidateVersionSkew validates the given node pool versions (node pool name -> version) against the control plane version
+func ValidateVersionSkew(masterVersion string, nodePoolVersions map[string]string) error {
+
+	masterMajor, masterMinor, err := parseMinorVersion(masterVersion)
+	if err != nil {
+		return err
+	}
+
+	violating := make(map[string]string)
+	for name, version := range nodePoolVersions {
+		major, minor, err := parseMinorVersion(version)
+		if err != nil {
+			return fmt.Errorf("NodePool[%s]: %s", name, err.Error())
+		}
+		if major != masterMajor || minor > masterMinor || masterMinor-minor > maxNodePoolMinorVersionSkew {
+			violating[name] = version
+		}
+	}
+
+	if len(violating) > 0 {
+		return &VersionSkewError{
+			MasterVersion: masterVersion,
+			NodePools:     violating,
+		}
+	}
+
+	return nil
+}
+
+// IsVersionSkewNearLimit returns true if the given node pool version is at the oldest minor version
+// supported by the control plane version, so the next control plane upgrade would make it unsupported
+func IsVersionSkewNearLimit(masterVersion, version string) bool {
+
+	masterMajor, masterMinor, err := parseMinorVersion(masterVersion)
+	if err != nil {
+		return false
+	}
+
+	major, minor, err := parseMinorVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return major == masterMajor && masterMinor-minor == maxNodePoolMinorVersionSkew
+}
+
+// parseMinorVersion gives back the major and minor parts of a vX.Y.Z version string
+func parseMinorVersion(version string) (major int, minor int, err error) {
+
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("Invalid k8s version: %s", version)
+	}
+
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("Invalid k8s version: %s", version)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("Invalid k8s version: %s", version)
+	}
+
+	return major, minor, nil
+}
@@ -0,0 +1,90 @@
+package cluster_test
+
+import (
+	"testing"
+
+	oracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/cluster"
+)
+
+func TestValidateUpgradePath(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		current   string
+		requested string
+		available []string
+		valid     bool
+	}{
+		{name: "same version", current: "v1.10.3", requested: "v1.10.3", valid: true},
+		{name: "available upgrade", current: "v1.10.3", requested: "v1.11.1", available: []string{"v1.11.1"}, valid: true},
+		{name: "not available upgrade", current: "v1.9.7", requested: "v1.11.1", available: []string{"v1.10.3"}},
+		{name: "no upgrades", current: "v1.11.1", requested: "v1.12.7"},
+		{name: "downgrade", current: "v1.11.1", requested: "v1.10.3", available: []string{"v1.12.7"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := oracle.ValidateUpgradePath(test.current, test.requested, test.available)
+			if test.valid {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			if _, ok := err.(*oracle.InvalidUpgradePathError); !ok {
+				t.Errorf("expected InvalidUpgradePathError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsVersionOnlyChange(t *testing.T) {
+
+	current := &oracle.Cluster{
+		Version: "v1.10.3",
+		NodePools: map[string]*oracle.NodePool{
+			"pool1": {Version: "v1.10.3", Count: 3},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		requested *oracle.Cluster
+		expected  bool
+	}{
+		{
+			name: "version changed",
+			requested: &oracle.Cluster{
+				Version:   "v1.11.1",
+				NodePools: map[string]*oracle.NodePool{"pool1": {Version: "v1.10.3", Count: 3}},
+			},
+			expected: true,
+		},
+		{
+			name: "nothing changed",
+			requested: &oracle.Cluster{
+				Version:   "v1.10.3",
+				NodePools: map[string]*oracle.NodePool{"pool1": {Version: "v1.10.3", Count: 3}},
+			},
+		},
+		{
+			name: "version and node pool changed",
+			requested: &oracle.Cluster{
+				Version:   "v1.11.1",
+				NodePools: map[string]*oracle.NodePool{"pool1": {Version: "v1.11.1", Count: 3}},
+			},
+		},
+		{
+			name:      "nil request",
+			requested: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := oracle.IsVersionOnlyChange(current, test.requested); result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -1,8 +1,15 @@
 package model
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/jinzhu/gorm"
+
 	"github.com/banzaicloud/pipeline/config"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
@@ -15,42 +22,77 @@ const (
 	ClustersNodePoolsTableName       = "oracle_clusters_nodepools"
 	ClustersNodePoolSubnetsTableName = "oracle_clusters_nodepools_subnets"
 	ClustersNodePoolLabelsTableName  = "oracle_clusters_nodepools_labels"
+	ClustersNodePoolTaintsTableName  = "oracle_clusters_nodepools_taints"
 )
 
+// InstanceConfigHashLabelKey is the node label holding the instance configuration hash of the node
+const InstanceConfigHashLabelKey = "pipeline-nodepool-config-hash"
+
+const instanceConfigHashLength = 16
+
+// WarmPoolLabelKey is the node label marking the cordoned standby nodes of a node pool
+const WarmPoolLabelKey = "pipeline-nodepool-warm-pool"
+
 // Cluster describes the Oracle cluster model
 type Cluster struct {
-	ID             uint   `gorm:"primary_key"`
-	Name           string `gorm:"unique_index:idx_modelid_name"`
-	Version        string
-	VCNID          string
-	LBSubnetID1    string
-	LBSubnetID2    string
-	OCID           string `gorm:"column:ocid"`
-	ClusterModelID uint
-	NodePools      []*NodePool
-	CreatedBy      uint
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Delete         bool `gorm:"-"`
+	ID                 uint   `gorm:"primary_key"`
+	Name               string `gorm:"unique_index:idx_modelid_name"`
+	Version            string
+	VCNID              string
+	LBSubnetID1        string
+	LBSubnetID2        string
+	LBSubnetCount      uint
+	PodCIDR            string
+	ServiceCIDR        string
+	PrivateEndpoint    bool
+	EndpointSubnetID   string
+	PrivateAPIEndpoint bool
+	DriftCheckInterval uint
+	LogLevel           string
+	Tags               map[string]string `gorm:"-"`
+	OCID               string            `gorm:"column:ocid"`
+	ClusterModelID     uint
+	NodePools          []*NodePool
+	CreatedBy          uint
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Delete             bool `gorm:"-"`
 }
 
 // NodePool describes Oracle node pools model of a cluster
 type NodePool struct {
-	ID                uint   `gorm:"primary_key"`
-	Name              string `gorm:"unique_index:idx_clusterid_name"`
-	Image             string `gorm:"default:'Oracle-Linux-7.4'"`
-	Shape             string `gorm:"default:'VM.Standard1.1'"`
-	Version           string `gorm:"default:'v1.10.3'"`
-	QuantityPerSubnet uint   `gorm:"default:1"`
-	OCID              string `gorm:"column:ocid"`
-	ClusterID         uint   `gorm:"unique_index:idx_clusterid_name"`
-	Subnets           []*NodePoolSubnet
-	Labels            []*NodePoolLabel
-	CreatedBy         uint
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	Delete            bool `gorm:"-"`
-	Add               bool `gorm:"-"`
+	ID                       uint   `gorm:"primary_key"`
+	Name                     string `gorm:"unique_index:idx_clusterid_name"`
+	Image                    string `gorm:"default:'Oracle-Linux-7.4'"`
+	ImageOCID                string `gorm:"column:image_ocid"`
+	Shape                    string `gorm:"default:'VM.Standard1.1'"`
+	Version                  string `gorm:"default:'v1.10.3'"`
+	QuantityPerSubnet        uint   `gorm:"default:1"`
+	OCID                     string `gorm:"column:ocid"`
+	ClusterID                uint   `gorm:"unique_index:idx_clusterid_name"`
+	HealthCheckGracePeriod   uint
+	InstanceConfigHash       string
+	Subnets                  []*NodePoolSubnet
+	Labels                   []*NodePoolLabel
+	StartupTaints            []*NodePoolTaint
+	StartupReadyCondition    string
+	UpdateBatchSize          string `gorm:"default:'1'"`
+	VolumeKMSKeyID           string `gorm:"column:volume_kms_key_id"`
+	VolumeKMSEndpoint        string `gorm:"column:volume_kms_endpoint"`
+	BootVolumeVPUsPerGB      int64  `gorm:"column:boot_volume_vpus_per_gb"`
+	WarmPoolSize             uint
+	PriorityClassName        string
+	PriorityClassValue       int32
+	PriorityClassDescription string
+	UserData                 string `gorm:"type:text"`
+	Autoscaling              bool   `gorm:"default:false"`
+	MinCount                 int    `gorm:"default:0"`
+	MaxCount                 int    `gorm:"default:0"`
+	CreatedBy                uint
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	Delete                   bool `gorm:"-"`
+	Add                      bool `gorm:"-"`
 }
 
 // NodePoolSubnet describes subnets for a NodePool
@@ -72,6 +114,17 @@ type NodePoolLabel struct {
 	UpdatedAt  time.Time
 }
 
+// NodePoolTaint stores startup taints for node pools
+type NodePoolTaint struct {
+	ID         uint   `gorm:"primary_key"`
+	Key        string `gorm:"unique_index:idx_nodepoolid_key_effect"`
+	Value      string
+	Effect     string `gorm:"unique_index:idx_nodepoolid_key_effect"`
+	NodePoolID uint   `gorm:"unique_index:idx_nodepoolid_key_effect"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
 // TableName sets the Clusters table name
 func (Cluster) TableName() string {
 	return ClustersTableName
@@ -92,6 +145,11 @@ func (NodePoolLabel) TableName() string {
 	return ClustersNodePoolLabelsTableName
 }
 
+// TableName sets the NodePoolTaints table name
+func (NodePoolTaint) TableName() string {
+	return ClustersNodePoolTaintsTableName
+}
+
 // CreateModelFromCreateRequest create model from create request
 func CreateModelFromCreateRequest(r *pkgCluster.CreateClusterRequest, userId uint) (cluster Cluster, err error) {
 
@@ -111,13 +169,21 @@ func CreateModelFromRequest(model Cluster, r *cluster.Cluster, userID uint) (clu
 
 	model.Version = r.Version
 	model.CreatedBy = userID
+	model.DriftCheckInterval = r.DriftCheckInterval
+	model.LogLevel = r.LogLevel
 
 	// reqest values only used when creating
 	if model.ID == 0 {
 		model.VCNID = r.GetVCNID()
 		model.LBSubnetID1 = r.GetLBSubnetID1()
 		model.LBSubnetID2 = r.GetLBSubnetID2()
+		model.LBSubnetCount = r.GetLBSubnetCount()
+		model.PodCIDR = r.PodCIDR
+		model.ServiceCIDR = r.ServiceCIDR
+		model.PrivateEndpoint = r.PrivateEndpoint
+		model.EndpointSubnetID = r.GetEndpointSubnetID()
 		model.CreatedBy = userID
+		model.Tags = r.Tags
 	}
 
 	// there should be at least 1 node pool defined
@@ -132,14 +198,34 @@ func CreateModelFromRequest(model Cluster, r *cluster.Cluster, userID uint) (clu
 			nodePool.Name = name
 			nodePool.Image = data.Image
 			nodePool.Shape = data.Shape
+			nodePool.UserData = cluster.EncodeUserData(data.UserData)
 			nodePool.Add = true
+			nodePool.CreatedBy = userID
 		} else {
 			nodePool.Subnets = make([]*NodePoolSubnet, 0)
 			nodePool.Labels = make([]*NodePoolLabel, 0)
+			nodePool.StartupTaints = make([]*NodePoolTaint, 0)
 		}
-		nodePool.CreatedBy = userID
 		nodePool.Version = data.Version
 		nodePool.QuantityPerSubnet = data.GetQuantityPerSubnet()
+		nodePool.HealthCheckGracePeriod = data.GetHealthCheckGracePeriod()
+		nodePool.StartupReadyCondition = data.StartupReadyCondition
+		nodePool.UpdateBatchSize = data.UpdateBatchSize
+		nodePool.VolumeKMSKeyID = data.VolumeKMSKeyID
+		nodePool.VolumeKMSEndpoint = data.VolumeKMSEndpoint
+		nodePool.BootVolumeVPUsPerGB = data.BootVolumeVPUsPerGB
+		nodePool.WarmPoolSize = data.WarmPoolSize
+		nodePool.Autoscaling = data.Autoscaling
+		nodePool.MinCount = data.MinCount
+		nodePool.MaxCount = data.MaxCount
+		nodePool.PriorityClassName = ""
+		nodePool.PriorityClassValue = 0
+		nodePool.PriorityClassDescription = ""
+		if data.PriorityClass != nil {
+			nodePool.PriorityClassName = data.PriorityClass.Name
+			nodePool.PriorityClassValue = data.PriorityClass.Value
+			nodePool.PriorityClassDescription = data.PriorityClass.Description
+		}
 
 		for _, subnetID := range data.GetSubnetIDs() {
 			nodePool.Subnets = append(nodePool.Subnets, &NodePoolSubnet{
@@ -154,6 +240,21 @@ func CreateModelFromRequest(model Cluster, r *cluster.Cluster, userID uint) (clu
 			})
 		}
 
+		for _, taint := range data.StartupTaints {
+			nodePool.StartupTaints = append(nodePool.StartupTaints, &NodePoolTaint{
+				Key:    taint.Key,
+				Value:  taint.Value,
+				Effect: taint.Effect,
+			})
+		}
+
+		// nodes get the hash of the instance configuration they were created with as a label
+		nodePool.InstanceConfigHash = nodePool.GetInstanceConfigHash()
+		nodePool.Labels = append(nodePool.Labels, &NodePoolLabel{
+			Name:  InstanceConfigHashLabelKey,
+			Value: nodePool.InstanceConfigHash,
+		})
+
 		nodePools = append(nodePools, nodePool)
 	}
 
@@ -169,6 +270,24 @@ func CreateModelFromRequest(model Cluster, r *cluster.Cluster, userID uint) (clu
 	return model, err
 }
 
+// GetInstanceConfigHash calculates the hash of the node pool's instance configuration (shape, image,
+// version and node labels), nodes created with a different configuration are pending a roll
+func (d *NodePool) GetInstanceConfigHash() string {
+
+	labels := make([]string, 0)
+	for _, l := range d.Labels {
+		if l.Name != InstanceConfigHashLabelKey {
+			labels = append(labels, l.Name+"="+l.Value)
+		}
+	}
+	sort.Strings(labels)
+
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", d.Shape, d.Image, d.Version, strings.Join(labels, ","))
+
+	return hex.EncodeToString(h.Sum(nil))[:instanceConfigHashLength]
+}
+
 // GetNodePoolByName gets a NodePool from the []NodePools by name
 func (c *Cluster) GetNodePoolByName(name string) *NodePool {
 
@@ -195,12 +314,13 @@ func (c *Cluster) Cleanup() error {
 	return db.Delete(&c).Error
 }
 
-// BeforeDelete deletes all subnets and labels belongs to the nodepool
+// BeforeDelete deletes all subnets, labels and taints belongs to the nodepool
 func (d *NodePool) BeforeDelete() error {
-	log.Info("BeforeDelete oracle nodepool... delete all subnets and labels")
+	log.Info("BeforeDelete oracle nodepool... delete all subnets, labels and taints")
 
 	var nodePoolSubnets []*NodePoolSubnet
 	var nodePoolLabels []*NodePoolLabel
+	var nodePoolTaints []*NodePoolTaint
 
 	err := config.DB().Where(NodePoolSubnet{
 		NodePoolID: d.ID,
@@ -209,9 +329,16 @@ func (d *NodePool) BeforeDelete() error {
 		return err
 	}
 
-	return config.DB().Where(NodePoolLabel{
+	err = config.DB().Where(NodePoolLabel{
 		NodePoolID: d.ID,
 	}).Find(&nodePoolLabels).Delete(&nodePoolLabels).Error
+	if err != nil {
+		return err
+	}
+
+	return config.DB().Where(NodePoolTaint{
+		NodePoolID: d.ID,
+	}).Find(&nodePoolTaints).Delete(&nodePoolTaints).Error
 }
 
 // RemoveNodePools delete node pool records from the database
@@ -232,6 +359,42 @@ func (c *Cluster) RemoveNodePools() error {
 	return nil
 }
 
+// GetLBSubnetCount gives back the number of load balancer subnets of the cluster, clusters stored before the count
+// was configurable have 2
+func (c *Cluster) GetLBSubnetCount() uint {
+
+	if c.LBSubnetCount == 0 {
+		return cluster.DefaultLBSubnetCount
+	}
+
+	return c.LBSubnetCount
+}
+
+// GetLBSubnetIDs gives back the load balancer subnets of the cluster
+func (c *Cluster) GetLBSubnetIDs() []string {
+
+	subnetIDs := make([]string, 0, 2)
+	for _, id := range []string{c.LBSubnetID1, c.LBSubnetID2} {
+		if id != "" {
+			subnetIDs = append(subnetIDs, id)
+		}
+	}
+
+	return subnetIDs
+}
+
+// SetPrivateAPIEndpoint stores whether the Kubernetes API endpoint of the cluster in use is its private endpoint,
+// the column is updated without the save hooks
+func (c *Cluster) SetPrivateAPIEndpoint(private bool) error {
+
+	c.PrivateAPIEndpoint = private
+	if c.ID == 0 {
+		return nil
+	}
+
+	return config.DB().Model(c).UpdateColumn("private_api_endpoint", private).Error
+}
+
 // BeforeSave clears nodepools
 func (c *Cluster) BeforeSave() error {
 	log.Info("BeforeSave oracle cluster...")
@@ -243,27 +406,118 @@ func (c *Cluster) BeforeSave() error {
 	return nil
 }
 
+// AfterSave saves the tags given at creation within the transaction of the save, only the changed tags are written
+func (c *Cluster) AfterSave(scope *gorm.Scope) error {
+
+	if len(c.Tags) == 0 {
+		return nil
+	}
+
+	var stored []ClusterTag
+	err := scope.DB().Where(ClusterTag{ClusterID: c.ID}).Find(&stored).Error
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]ClusterTag, len(stored))
+	for _, tag := range stored {
+		tags[tag.Key] = tag
+	}
+
+	for key, value := range c.Tags {
+		tag, ok := tags[key]
+		if ok && tag.Value == value {
+			continue
+		}
+		if !ok {
+			tag = ClusterTag{ClusterID: c.ID, Key: key}
+		}
+
+		tag.Value = value
+		if err := scope.DB().Save(&tag).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetActiveNodeCount gives back the number of provisioned nodes of the node pool which are not in its warm pool
+func (np *NodePool) GetActiveNodeCount() uint {
+
+	count := np.QuantityPerSubnet * uint(len(np.Subnets))
+	if count < np.WarmPoolSize {
+		return 0
+	}
+
+	return count - np.WarmPoolSize
+}
+
+// GetNodeCountBounds gives back the autoscaling bounds of the active nodes of the node pool, both are the
+// active node count if autoscaling is disabled
+func (np *NodePool) GetNodeCountBounds() (minCount, maxCount int) {
+
+	if !np.Autoscaling {
+		count := int(np.GetActiveNodeCount())
+		return count, count
+	}
+
+	return np.MinCount, np.MaxCount
+}
+
 // GetClusterRequestFromModel converts cluster model from database and to Cluster
 func (c *Cluster) GetClusterRequestFromModel() *cluster.Cluster {
 
 	nodePools := make(map[string]*cluster.NodePool)
 	if c.NodePools != nil {
 		for _, np := range c.NodePools {
+			healthCheckGracePeriod := np.HealthCheckGracePeriod
 			nodePools[np.Name] = &cluster.NodePool{
 				Version: np.Version,
 				Image:   np.Image,
-				Count:   uint(int(np.QuantityPerSubnet) * len(np.Subnets)),
+				Count:   np.GetActiveNodeCount(),
 				Shape:   np.Shape,
+
+				HealthCheckGracePeriod: &healthCheckGracePeriod,
+				StartupReadyCondition:  np.StartupReadyCondition,
+				UpdateBatchSize:        np.UpdateBatchSize,
+				VolumeKMSKeyID:         np.VolumeKMSKeyID,
+				VolumeKMSEndpoint:      np.VolumeKMSEndpoint,
+				BootVolumeVPUsPerGB:    np.BootVolumeVPUsPerGB,
+				WarmPoolSize:           np.WarmPoolSize,
+				UserData:               np.UserData,
+				Autoscaling:            np.Autoscaling,
+				MinCount:               np.MinCount,
+				MaxCount:               np.MaxCount,
+			}
+			if np.PriorityClassName != "" {
+				nodePools[np.Name].PriorityClass = &cluster.PriorityClass{
+					Name:        np.PriorityClassName,
+					Value:       np.PriorityClassValue,
+					Description: np.PriorityClassDescription,
+				}
+			}
+			for _, t := range np.StartupTaints {
+				nodePools[np.Name].StartupTaints = append(nodePools[np.Name].StartupTaints, cluster.Taint{
+					Key:    t.Key,
+					Value:  t.Value,
+					Effect: t.Effect,
+				})
 			}
 			nodePools[np.Name].Labels = make(map[string]string, 0)
 			for _, l := range np.Labels {
+				if l.Name == InstanceConfigHashLabelKey {
+					continue
+				}
 				nodePools[np.Name].Labels[l.Name] = l.Value
 			}
 		}
 	}
 
 	return &cluster.Cluster{
-		Version:   c.Version,
-		NodePools: nodePools,
+		Version:            c.Version,
+		NodePools:          nodePools,
+		DriftCheckInterval: c.DriftCheckInterval,
+		LogLevel:           c.LogLevel,
 	}
 }
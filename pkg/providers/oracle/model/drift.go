@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// DriftReportsTableName is the table name of DriftReport
+const DriftReportsTableName = "oracle_clusters_drift_reports"
+
+// DriftReport stores a detected drift of a cluster from its desired state
+type DriftReport struct {
+	ID        uint      `gorm:"primary_key" json:"-"`
+	ClusterID uint      `gorm:"index:idx_clusterid_checkedat" json:"-"`
+	CheckedAt time.Time `gorm:"index:idx_clusterid_checkedat" json:"checkedAt"`
+	Report    string    `gorm:"type:text" json:"report"` // JSON encoded drift report
+}
+
+// TableName overrides DriftReport table name
+func (DriftReport) TableName() string {
+	return DriftReportsTableName
+}
+
+// Save saves the drift report into database
+func (r *DriftReport) Save() error {
+
+	return config.DB().Save(r).Error
+}
+
+// GetDriftReports gets the latest drift reports of the cluster, newest first
+func GetDriftReports(clusterID uint, limit int) (reports []DriftReport, err error) {
+
+	err = config.DB().
+		Where(DriftReport{ClusterID: clusterID}).
+		Order("checked_at desc").
+		Limit(limit).
+		Find(&reports).Error
+
+	return reports, err
+}
+
+// DeleteDriftReports deletes every drift report of the cluster
+func DeleteDriftReports(clusterID uint) error {
+
+	if clusterID == 0 {
+		return nil
+	}
+
+	return config.DB().Where(DriftReport{ClusterID: clusterID}).Delete(DriftReport{}).Error
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// ClusterFeatureFlagsTableName is the table name of ClusterFeatureFlags
+const ClusterFeatureFlagsTableName = "oracle_clusters_feature_flags"
+
+// Feature flag names
+const (
+	FeatureAdminBindingSelfHeal = "adminBindingSelfHeal"
+	FeatureStatusRefresher      = "statusRefresher"
+)
+
+// ClusterFeatureFlags describes the per-cluster feature flags model
+type ClusterFeatureFlags struct {
+	ID                   uint `gorm:"primary_key"`
+	ClusterID            uint `gorm:"unique_index"`
+	AdminBindingSelfHeal bool
+	StatusRefresher      bool
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// TableName overrides ClusterFeatureFlags table name
+func (ClusterFeatureFlags) TableName() string {
+	return ClusterFeatureFlagsTableName
+}
+
+// GetClusterFeatureFlags gets the feature flags of the given cluster,
+// every feature is enabled if no flags were stored before
+func GetClusterFeatureFlags(clusterID uint) (flags ClusterFeatureFlags, err error) {
+
+	flags = ClusterFeatureFlags{
+		ClusterID:            clusterID,
+		AdminBindingSelfHeal: true,
+		StatusRefresher:      true,
+	}
+
+	if clusterID == 0 {
+		return flags, nil
+	}
+
+	db := config.DB().Where(ClusterFeatureFlags{ClusterID: clusterID}).First(&flags)
+	if db.RecordNotFound() {
+		return flags, nil
+	}
+
+	return flags, db.Error
+}
+
+// Save saves the feature flags into database
+func (f *ClusterFeatureFlags) Save() error {
+
+	return config.DB().Save(f).Error
+}
+
+// Delete deletes the feature flags from database
+func (f *ClusterFeatureFlags) Delete() error {
+
+	if f.ID == 0 {
+		return nil
+	}
+
+	return config.DB().Delete(f).Error
+}
+
+// Set sets the feature flag with the given name
+func (f *ClusterFeatureFlags) Set(name string, enabled bool) error {
+
+	switch name {
+	case FeatureAdminBindingSelfHeal:
+		f.AdminBindingSelfHeal = enabled
+	case FeatureStatusRefresher:
+		f.StatusRefresher = enabled
+	default:
+		return fmt.Errorf("Unknown feature flag: %s", name)
+	}
+
+	return nil
+}
+
+// ToMap gives back the feature flags as a name -> enabled map
+func (f *ClusterFeatureFlags) ToMap() map[string]bool {
+
+	return map[string]bool{
+		FeatureAdminBindingSelfHeal: f.AdminBindingSelfHeal,
+		FeatureStatusRefresher:      f.StatusRefresher,
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// NodePoolMetricSamplesTableName is the table name of NodePoolMetricSample
+const NodePoolMetricSamplesTableName = "oracle_clusters_nodepools_metric_samples"
+
+// NodePoolMetricSample describes the CPU/memory usage of a node pool at a point in time
+type NodePoolMetricSample struct {
+	ID                uint      `gorm:"primary_key" json:"-"`
+	ClusterID         uint      `gorm:"index:idx_clusterid_nodepool_sampledat" json:"-"`
+	NodePool          string    `gorm:"index:idx_clusterid_nodepool_sampledat" json:"nodePool"`
+	SampledAt         time.Time `gorm:"index:idx_clusterid_nodepool_sampledat" json:"sampledAt"`
+	NodeCount         int       `json:"nodeCount"`
+	CPUUsage          int64     `json:"cpuUsage"`          // in millicores
+	CPUAllocatable    int64     `json:"cpuAllocatable"`    // in millicores
+	MemoryUsage       int64     `json:"memoryUsage"`       // in bytes
+	MemoryAllocatable int64     `json:"memoryAllocatable"` // in bytes
+}
+
+// TableName overrides NodePoolMetricSample table name
+func (NodePoolMetricSample) TableName() string {
+	return NodePoolMetricSamplesTableName
+}
+
+// Save saves the metric sample into database
+func (s *NodePoolMetricSample) Save() error {
+
+	return config.DB().Save(s).Error
+}
+
+// GetNodePoolMetricSamples gets the metric samples of the node pool taken between from and to, ordered by time
+func GetNodePoolMetricSamples(clusterID uint, nodePool string, from, to time.Time) (samples []NodePoolMetricSample, err error) {
+
+	err = config.DB().
+		Where(NodePoolMetricSample{ClusterID: clusterID, NodePool: nodePool}).
+		Where("sampled_at >= ? AND sampled_at <= ?", from, to).
+		Order("sampled_at").
+		Find(&samples).Error
+
+	return samples, err
+}
+
+// DeleteNodePoolMetricSamples deletes every metric sample of the cluster
+func DeleteNodePoolMetricSamples(clusterID uint) error {
+
+	if clusterID == 0 {
+		return nil
+	}
+
+	return config.DB().Where(NodePoolMetricSample{ClusterID: clusterID}).Delete(NodePoolMetricSample{}).Error
+}
+
+// DeleteNodePoolMetricSamplesBefore deletes the metric samples of every cluster taken before the given time
+func DeleteNodePoolMetricSamplesBefore(t time.Time) error {
+
+	return config.DB().Where("sampled_at < ?", t).Delete(NodePoolMetricSample{}).Error
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/banzaicloud/pipeline/config"
@@ -15,15 +17,27 @@ const (
 	ProfileNodePoolLabelTableName = "profiles_nodepools_oke_labels"
 )
 
+// profile node pool fields which can be inherited from the base profile
+const (
+	inheritedCount   = "count"
+	inheritedImage   = "image"
+	inheritedShape   = "shape"
+	inheritedVersion = "version"
+)
+
+// maxProfileInheritanceDepth is the maximum number of base profiles of a profile
+const maxProfileInheritanceDepth = 10
+
 // Profile describes the Oracle cluster profile model
 type Profile struct {
-	ID        uint   `gorm:"primary_key"`
-	Name      string `gorm:"unique_index:idx_modelid_name"`
-	Location  string `gorm:"default:'eu-frankfurt-1'"`
-	Version   string `gorm:"default:'v1.10.3'"`
-	NodePools []*ProfileNodePool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              uint   `gorm:"primary_key"`
+	Name            string `gorm:"unique_index:idx_modelid_name"`
+	Location        string `gorm:"default:'eu-frankfurt-1'"`
+	Version         string `gorm:"default:'v1.10.3'"`
+	BaseProfileName string // the node pools inherit the unset fields and the labels of the node pools of the base profile
+	NodePools       []*ProfileNodePool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // ProfileNodePool describes Oracle node pool profile model of a cluster
@@ -38,6 +52,10 @@ type ProfileNodePool struct {
 	ProfileID uint `gorm:"unique_index:idx_modelid_name; foreignKey"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// comma separated list of the fields taken from the base profile, the stored values of these fields are the
+	// column defaults
+	InheritedFields string
 }
 
 // ProfileNodePoolLabel stores labels for node pools
@@ -128,13 +146,145 @@ func (d *Profile) GetProfile() *pkgCluster.ClusterProfileResponse {
 		Cloud:    pkgCluster.Oracle,
 		Properties: &pkgCluster.ClusterProfileProperties{
 			OKE: &oracle.Cluster{
-				Version:   d.Version,
-				NodePools: nodePools,
+				Version:     d.Version,
+				NodePools:   nodePools,
+				BaseProfile: d.BaseProfileName,
 			},
 		},
 	}
 }
 
+// GetResolvedProfile gives back the profile with the node pools inherited from its base profiles, it is used
+// to create clusters from the profile
+func (d *Profile) GetResolvedProfile() (*pkgCluster.ClusterProfileResponse, error) {
+
+	resolved, err := d.resolve(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved.GetProfile(), nil
+}
+
+// resolve gives back a copy of the profile with the fields and labels of the node pools of its base profiles
+func (d *Profile) resolve(depth int) (*Profile, error) {
+
+	if d.BaseProfileName == "" {
+		return d, nil
+	}
+
+	if depth == maxProfileInheritanceDepth {
+		return nil, fmt.Errorf("Profile %s has more than %d base profiles", d.Name, maxProfileInheritanceDepth)
+	}
+
+	base, err := GetProfileByName(d.BaseProfileName)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting base profile %s of profile %s: %s", d.BaseProfileName, d.Name, err.Error())
+	}
+
+	resolvedBase, err := base.resolve(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *d
+	resolved.BaseProfileName = ""
+	resolved.NodePools = make([]*ProfileNodePool, 0, len(d.NodePools)+len(resolvedBase.NodePools))
+
+	overridden := make(map[string]bool)
+	for _, np := range d.NodePools {
+		overridden[np.Name] = true
+		resolved.NodePools = append(resolved.NodePools, np.inherit(resolvedBase.getNodePoolByName(np.Name)))
+	}
+	for _, np := range resolvedBase.NodePools {
+		if !overridden[np.Name] {
+			resolved.NodePools = append(resolved.NodePools, np)
+		}
+	}
+
+	return &resolved, nil
+}
+
+// getNodePoolByName gives back the node pool of the profile by name, nil if the profile has no such node pool
+func (d *Profile) getNodePoolByName(name string) *ProfileNodePool {
+
+	for _, np := range d.NodePools {
+		if np.Name == name {
+			return np
+		}
+	}
+
+	return nil
+}
+
+// inherit gives back a copy of the node pool with the inherited fields and the labels of the base node pool,
+// the labels of the node pool override the labels of the base node pool
+func (d *ProfileNodePool) inherit(base *ProfileNodePool) *ProfileNodePool {
+
+	if base == nil {
+		return d
+	}
+
+	np := *d
+	for _, field := range strings.Split(d.InheritedFields, ",") {
+		switch field {
+		case inheritedCount:
+			np.Count = base.Count
+		case inheritedImage:
+			np.Image = base.Image
+		case inheritedShape:
+			np.Shape = base.Shape
+		case inheritedVersion:
+			np.Version = base.Version
+		}
+	}
+	np.InheritedFields = ""
+
+	np.Labels = make([]*ProfileNodePoolLabel, 0, len(base.Labels)+len(d.Labels))
+	for _, l := range base.Labels {
+		if !d.hasLabel(l.Name) {
+			np.Labels = append(np.Labels, l)
+		}
+	}
+	np.Labels = append(np.Labels, d.Labels...)
+
+	return &np
+}
+
+func (d *ProfileNodePool) hasLabel(name string) bool {
+
+	for _, l := range d.Labels {
+		if l.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateBaseProfile checks that the base profile exists and that inheriting from it does not create a cycle
+func ValidateBaseProfile(name, baseProfileName string) error {
+
+	visited := map[string]bool{name: true}
+	for next := baseProfileName; next != ""; {
+		if visited[next] {
+			return fmt.Errorf("Invalid base profile %s: profile %s would inherit from itself", baseProfileName, name)
+		}
+		if len(visited) > maxProfileInheritanceDepth {
+			return fmt.Errorf("Invalid base profile %s: profile %s would have more than %d base profiles", baseProfileName, name, maxProfileInheritanceDepth)
+		}
+		visited[next] = true
+
+		base, err := GetProfileByName(next)
+		if err != nil {
+			return fmt.Errorf("Invalid base profile %s: error getting profile %s: %s", baseProfileName, next, err.Error())
+		}
+		next = base.BaseProfileName
+	}
+
+	return nil
+}
+
 // UpdateProfile update profile's data with ClusterProfileRequest's data and if bool is true then update in the database
 func (d *Profile) UpdateProfile(r *pkgCluster.ClusterProfileRequest, withSave bool) error {
 
@@ -142,8 +292,15 @@ func (d *Profile) UpdateProfile(r *pkgCluster.ClusterProfileRequest, withSave bo
 
 		s := r.Properties.OKE
 
+		if s.BaseProfile != "" {
+			if err := ValidateBaseProfile(r.Name, s.BaseProfile); err != nil {
+				return err
+			}
+		}
+
 		d.Version = s.Version
 		d.Location = r.Location
+		d.BaseProfileName = s.BaseProfile
 
 		if len(s.NodePools) != 0 {
 			var nodePools []*ProfileNodePool
@@ -155,6 +312,9 @@ func (d *Profile) UpdateProfile(r *pkgCluster.ClusterProfileRequest, withSave bo
 					Shape:   np.Shape,
 					Name:    name,
 				}
+				if s.BaseProfile != "" {
+					nodePool.InheritedFields = getInheritedFields(np)
+				}
 				for name, value := range np.Labels {
 					nodePool.Labels = append(nodePool.Labels, &ProfileNodePoolLabel{
 						Name:  name,
@@ -177,8 +337,38 @@ func (d *Profile) UpdateProfile(r *pkgCluster.ClusterProfileRequest, withSave bo
 	return nil
 }
 
-// DeleteProfile deletes cluster profile from database
+// getInheritedFields gives back the fields of the node pool which are not set and are taken from the base profile
+func getInheritedFields(np *oracle.NodePool) string {
+
+	fields := make([]string, 0)
+	if np.Count == 0 {
+		fields = append(fields, inheritedCount)
+	}
+	if np.Image == "" {
+		fields = append(fields, inheritedImage)
+	}
+	if np.Shape == "" {
+		fields = append(fields, inheritedShape)
+	}
+	if np.Version == "" {
+		fields = append(fields, inheritedVersion)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// DeleteProfile deletes cluster profile from database, profiles which are the base of other profiles cannot be deleted
 func (d *Profile) DeleteProfile() error {
+
+	var count int
+	err := config.DB().Model(&Profile{}).Where(Profile{BaseProfileName: d.Name}).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("Profile %s is the base profile of %d profiles", d.Name, count)
+	}
+
 	return config.DB().Delete(&d).Error
 }
 
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// ProgressEventsTableName is the table name of ProgressEvent
+const ProgressEventsTableName = "oracle_clusters_progress_events"
+
+// ProgressEvent stores a step of a long running operation of a cluster, the events are only appended
+type ProgressEvent struct {
+	ID        uint `gorm:"primary_key"`
+	ClusterID uint `gorm:"index:idx_clusterid_createdat"`
+	Phase     string
+	Message   string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index:idx_clusterid_createdat"`
+}
+
+// TableName overrides ProgressEvent table name
+func (ProgressEvent) TableName() string {
+	return ProgressEventsTableName
+}
+
+// Create inserts the progress event into database
+func (e *ProgressEvent) Create() error {
+
+	return config.DB().Create(e).Error
+}
+
+// GetProgressEvents gets the latest progress events of the cluster, newest first
+func GetProgressEvents(clusterID uint, limit int) (events []ProgressEvent, err error) {
+
+	err = config.DB().
+		Where(ProgressEvent{ClusterID: clusterID}).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// DeleteProgressEvents deletes every progress event of the cluster
+func DeleteProgressEvents(clusterID uint) error {
+
+	if clusterID == 0 {
+		return nil
+	}
+
+	return config.DB().Where(ProgressEvent{ClusterID: clusterID}).Delete(ProgressEvent{}).Error
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/config"
+)
+
+// ClusterTagsTableName is the table name of ClusterTag
+const ClusterTagsTableName = "oracle_clusters_tags"
+
+// ClusterTag describes a key-value metadata of an Oracle cluster
+type ClusterTag struct {
+	ID        uint   `gorm:"primary_key"`
+	Key       string `gorm:"unique_index:idx_clusterid_key"`
+	Value     string
+	ClusterID uint `gorm:"unique_index:idx_clusterid_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName overrides ClusterTag table name
+func (ClusterTag) TableName() string {
+	return ClusterTagsTableName
+}
+
+// FindClustersByTag gets the Oracle clusters of the organization having the given tag
+func FindClustersByTag(db *gorm.DB, orgId uint, key, value string) (clusters []Cluster, err error) {
+
+	err = db.
+		Joins(fmt.Sprintf("JOIN %s ON %s.cluster_id = %s.id", ClusterTagsTableName, ClusterTagsTableName, ClustersTableName)).
+		Joins(fmt.Sprintf("JOIN clusters ON clusters.id = %s.cluster_model_id", ClustersTableName)).
+		Where("clusters.organization_id = ?", orgId).
+		Where(fmt.Sprintf("%s.key = ? AND %s.value = ?", ClusterTagsTableName, ClusterTagsTableName), key, value).
+		Preload("NodePools.Subnets").
+		Preload("NodePools.Labels").
+		Preload("NodePools.StartupTaints").
+		Find(&clusters).Error
+
+	return clusters, err
+}
+
+// GetClusterTags gets the tags of the cluster
+func (c *Cluster) GetClusterTags() (tags map[string]string, err error) {
+
+	tags = make(map[string]string)
+
+	if c.ID == 0 {
+		return tags, nil
+	}
+
+	var clusterTags []ClusterTag
+	err = config.DB().Where(ClusterTag{ClusterID: c.ID}).Find(&clusterTags).Error
+	if err != nil {
+		return tags, err
+	}
+
+	for _, tag := range clusterTags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return tags, nil
+}
+
+// SetClusterTag creates or updates a tag of the cluster
+func (c *Cluster) SetClusterTag(key, value string) error {
+
+	if key == "" {
+		return fmt.Errorf("Tag key must be specified")
+	}
+
+	if c.ID == 0 {
+		return fmt.Errorf("Cluster must be saved before tagging")
+	}
+
+	var tag ClusterTag
+	err := config.DB().Where(ClusterTag{ClusterID: c.ID, Key: key}).FirstOrInit(&tag).Error
+	if err != nil {
+		return err
+	}
+
+	tag.Value = value
+
+	return config.DB().Save(&tag).Error
+}
+
+// RemoveClusterTag removes a tag of the cluster
+func (c *Cluster) RemoveClusterTag(key string) error {
+
+	if key == "" {
+		return fmt.Errorf("Tag key must be specified")
+	}
+
+	if c.ID == 0 {
+		return nil
+	}
+
+	return config.DB().Where(ClusterTag{ClusterID: c.ID, Key: key}).Delete(ClusterTag{}).Error
+}
+
+// RemoveClusterTags removes all tags of the cluster
+func (c *Cluster) RemoveClusterTags() error {
+
+	if c.ID == 0 {
+		return nil
+	}
+
+	return config.DB().Where(ClusterTag{ClusterID: c.ID}).Delete(ClusterTag{}).Error
+}
@@ -0,0 +1,185 @@
+package network
+
+import (
+	"fmt"
+	"net"
+)
+
+// ParseIPv4CIDR parses and validates an IPv4 CIDR block
+func ParseIPv4CIDR(CIDR string) (*net.IPNet, error) {
+
+	ip, ipNet, err := net.ParseCIDR(CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CIDR block: %s", CIDR)
+	}
+
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("Invalid CIDR block: %s is not an IPv4 block", CIDR)
+	}
+
+	return ipNet, nil
+}
+
+// CIDRsOverlap checks whether the given CIDR blocks overlap
+func CIDRsOverlap(a, b string) (bool, error) {
+
+	netA, err := ParseIPv4CIDR(a)
+	if err != nil {
+		return false, err
+	}
+
+	netB, err := ParseIPv4CIDR(b)
+	if err != nil {
+		return false, err
+	}
+
+	return netA.Contains(netB.IP) || netB.Contains(netA.IP), nil
+}
+
+// NextFreeSubnetCIDR gives back the first block with the given prefix length within the VCN CIDR block
+// which does not overlap with any of the used CIDR blocks
+func NextFreeSubnetCIDR(VCNCIDR string, prefix int, used []string) (string, error) {
+
+	vcnNet, err := ParseIPv4CIDR(VCNCIDR)
+	if err != nil {
+		return "", err
+	}
+
+	ones, bits := vcnNet.Mask.Size()
+	if prefix < ones || prefix > bits {
+		return "", fmt.Errorf("Invalid subnet prefix length /%d for VCN CIDR %s", prefix, VCNCIDR)
+	}
+
+	base := ipv4ToUint(vcnNet.IP)
+	size := uint32(1) << uint(bits-prefix)
+	count := uint64(1) << uint(prefix-ones)
+
+	for i := uint64(0); i < count; i++ {
+		candidate := fmt.Sprintf("%s/%d", uintToIPv4(base+uint32(i)*size).String(), prefix)
+
+		free := true
+		for _, CIDR := range used {
+			overlaps, err := CIDRsOverlap(candidate, CIDR)
+			if err != nil {
+				return "", err
+			}
+			if overlaps {
+				free = false
+				break
+			}
+		}
+
+		if free {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("There is no free /%d block left in VCN CIDR %s", prefix, VCNCIDR)
+}
+
+// the preconfigured subnets are /24 blocks at fixed offsets up to 31 within the VCN CIDR block, so the block must
+// be at least a /19, OCI allows VCNs up to a /16
+const (
+	preconfiguredSubnetPrefix = 24
+	minVCNPrefix              = 16
+	maxPreconfiguredVCNPrefix = 19
+)
+
+// ValidatePreconfiguredVCNCIDR checks that the CIDR block is a well-formed IPv4 block which fits the preconfigured subnets
+func ValidatePreconfiguredVCNCIDR(CIDR string) error {
+
+	vcnNet, err := ParseIPv4CIDR(CIDR)
+	if err != nil {
+		return err
+	}
+
+	if vcnNet.String() != CIDR {
+		return fmt.Errorf("Invalid VCN CIDR block: %s has host bits set, use %s", CIDR, vcnNet.String())
+	}
+
+	ones, _ := vcnNet.Mask.Size()
+	if ones < minVCNPrefix || ones > maxPreconfiguredVCNPrefix {
+		return fmt.Errorf("Invalid VCN CIDR block: %s, the prefix length must be between /%d and /%d", CIDR, minVCNPrefix, maxPreconfiguredVCNPrefix)
+	}
+
+	return nil
+}
+
+// preconfiguredSubnetCIDR gives back the index-th /24 block of the validated VCN CIDR block
+func preconfiguredSubnetCIDR(VCNCIDR string, index int) string {
+
+	_, vcnNet, _ := net.ParseCIDR(VCNCIDR)
+	base := ipv4ToUint(vcnNet.IP)
+
+	return fmt.Sprintf("%s/%d", uintToIPv4(base+uint32(index)<<8).String(), preconfiguredSubnetPrefix)
+}
+
+// ValidateSubnetCIDRs checks that the subnets of the network values are within the VCN CIDR block and that they
+// do not overlap with each other, subnets without a known CIDR block are skipped
+func ValidateSubnetCIDRs(values NetworkValues) error {
+
+	subnetIDs := append(append([]string{}, values.LBSubnetIDs...), values.WNSubnetIDs...)
+	if values.EndpointSubnetID != "" {
+		subnetIDs = append(subnetIDs, values.EndpointSubnetID)
+	}
+
+	checked := make([]string, 0, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		CIDR, ok := values.SubnetCIDRs[subnetID]
+		if !ok || containsString(checked, subnetID) {
+			continue
+		}
+
+		if values.VCNCIDR != "" {
+			vcnNet, err := ParseIPv4CIDR(values.VCNCIDR)
+			if err != nil {
+				return err
+			}
+			subnetNet, err := ParseIPv4CIDR(CIDR)
+			if err != nil {
+				return err
+			}
+			vcnOnes, _ := vcnNet.Mask.Size()
+			subnetOnes, _ := subnetNet.Mask.Size()
+			if !vcnNet.Contains(subnetNet.IP) || subnetOnes < vcnOnes {
+				return fmt.Errorf("Invalid network config: subnet %s (%s) is outside of VCN CIDR %s", subnetID, CIDR, values.VCNCIDR)
+			}
+		}
+
+		for _, other := range checked {
+			overlaps, err := CIDRsOverlap(CIDR, values.SubnetCIDRs[other])
+			if err != nil {
+				return err
+			}
+			if overlaps {
+				return fmt.Errorf("Invalid network config: subnet %s (%s) overlaps with subnet %s (%s)", subnetID, CIDR, other, values.SubnetCIDRs[other])
+			}
+		}
+		checked = append(checked, subnetID)
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ipv4ToUint(ip net.IP) uint32 {
+
+	ip = ip.To4()
+
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func uintToIPv4(n uint32) net.IP {
+
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
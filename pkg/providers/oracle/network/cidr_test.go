@@ -0,0 +1,167 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+)
+
+func TestCIDRsOverlap(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		a        string
+		b        string
+		overlaps bool
+		err      bool
+	}{
+		{"same block", "10.0.0.0/16", "10.0.0.0/16", true, false},
+		{"contained block", "10.0.0.0/16", "10.0.11.0/24", true, false},
+		{"containing block", "10.0.11.0/24", "10.0.0.0/16", true, false},
+		{"distinct blocks", "10.0.0.0/16", "10.244.0.0/16", false, false},
+		{"invalid block", "10.0.0.0/33", "10.244.0.0/16", false, true},
+		{"ipv6 block", "fd00::/64", "10.244.0.0/16", false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			overlaps, err := network.CIDRsOverlap(tc.a, tc.b)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+			if overlaps != tc.overlaps {
+				t.Errorf("Expected overlap: %v, got: %v", tc.overlaps, overlaps)
+			}
+		})
+	}
+}
+
+func TestNextFreeSubnetCIDR(t *testing.T) {
+
+	preconfigured := []string{"10.0.11.0/24", "10.0.12.0/24", "10.0.13.0/24", "10.0.21.0/24", "10.0.22.0/24"}
+
+	cases := []struct {
+		name   string
+		vcn    string
+		prefix int
+		used   []string
+		CIDR   string
+		err    bool
+	}{
+		{"empty vcn", "10.0.0.0/16", 24, nil, "10.0.0.0/24", false},
+		{"preconfigured vcn", "10.0.0.0/16", 24, preconfigured, "10.0.0.0/24", false},
+		{"partially used vcn", "10.0.0.0/16", 24, append([]string{"10.0.0.0/20"}, preconfigured...), "10.0.16.0/24", false},
+		{"full vcn", "10.0.0.0/24", 24, []string{"10.0.0.0/24"}, "", true},
+		{"too large prefix", "10.0.0.0/24", 16, nil, "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			CIDR, err := network.NextFreeSubnetCIDR(tc.vcn, tc.prefix, tc.used)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+			if CIDR != tc.CIDR {
+				t.Errorf("Expected CIDR: %s, got: %s", tc.CIDR, CIDR)
+			}
+		})
+	}
+}
+
+func TestValidatePreconfiguredVCNCIDR(t *testing.T) {
+
+	cases := []struct {
+		name string
+		CIDR string
+		err  bool
+	}{
+		{"default block", "10.0.0.0/16", false},
+		{"smallest block", "172.16.32.0/19", false},
+		{"too small block", "172.16.0.0/20", true},
+		{"too large block", "10.0.0.0/15", true},
+		{"host bits set", "10.0.1.0/16", true},
+		{"invalid block", "10.0.0.0", true},
+		{"ipv6 block", "fd00::/48", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := network.ValidatePreconfiguredVCNCIDR(tc.CIDR)
+			if tc.err && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestGetPreconfiguredSubnets(t *testing.T) {
+
+	cases := []struct {
+		name    string
+		VCNCIDR string
+		CIDRs   map[string]string
+	}{
+		{"default block", "", map[string]string{"lb-1": "10.0.21.0/24", "wn-3": "10.0.13.0/24", "ep-1": "10.0.31.0/24"}},
+		{"custom block", "172.16.32.0/19", map[string]string{"lb-2": "172.16.54.0/24", "wn-1": "172.16.43.0/24", "ep-1": "172.16.63.0/24"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := network.GetPreconfiguredSubnets(tc.VCNCIDR, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+			for _, subnet := range subnets {
+				if CIDR, ok := tc.CIDRs[subnet.Name]; ok && CIDR != subnet.CIDR {
+					t.Errorf("Expected CIDR of %s: %s, got: %s", subnet.Name, CIDR, subnet.CIDR)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSubnetCIDRs(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		subnetCIDRs map[string]string
+		err         bool
+	}{
+		{"distinct subnets", map[string]string{"lb1": "10.0.21.0/24", "lb2": "10.0.22.0/24", "wn1": "10.0.11.0/24"}, false},
+		{"overlapping subnets", map[string]string{"lb1": "10.0.21.0/24", "lb2": "10.0.22.0/24", "wn1": "10.0.0.0/19"}, true},
+		{"subnet outside of the VCN", map[string]string{"lb1": "10.0.21.0/24", "lb2": "10.1.22.0/24", "wn1": "10.0.11.0/24"}, true},
+		{"unknown CIDRs", map[string]string{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := network.ValidateSubnetCIDRs(network.NetworkValues{
+				LBSubnetIDs: []string{"lb1", "lb2"},
+				WNSubnetIDs: []string{"wn1"},
+				VCNCIDR:     "10.0.0.0/16",
+				SubnetCIDRs: tc.subnetCIDRs,
+			})
+			if tc.err && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package network
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
@@ -11,6 +13,38 @@ import (
 	"github.com/oracle/oci-go-sdk/identity"
 )
 
+const endpointSubnetName = "ep-1"
+
+const workerSubnetNamePrefix = "wn-"
+
+// PreconfiguredVCNCIDR is the CIDR block of the preconfigured VCN
+const PreconfiguredVCNCIDR = "10.0.0.0/16"
+
+// Subnet roles of the preconfigured VCN
+const (
+	SubnetRoleLoadBalancer = "lb"
+	SubnetRoleWorker       = "worker"
+	SubnetRoleEndpoint     = "endpoint"
+	SubnetRolePod          = "pod"
+)
+
+// subnets created by pipeline are tagged with their role using this freeform tag
+const subnetRoleTagKey = "subnet-role"
+
+// PreconfiguredSubnet describes a subnet of the preconfigured VCN
+type PreconfiguredSubnet struct {
+	Name    string
+	CIDR    string
+	Role    string
+	ADIndex int
+}
+
+// VCNs created by pipeline are tagged with this freeform tag
+const (
+	createdByTagKey   = "created-by"
+	createdByTagValue = "pipeline"
+)
+
 // VCNManager for creating and deleting preconfigured VCN
 type VCNManager struct {
 	oci *oci.OCI
@@ -20,8 +54,11 @@ type VCNManager struct {
 
 // NetworkValues holds network related values used in cluster create/update requests
 type NetworkValues struct {
-	LBSubnetIDs []string
-	WNSubnetIDs []string
+	LBSubnetIDs      []string
+	WNSubnetIDs      []string
+	EndpointSubnetID string
+	VCNCIDR          string
+	SubnetCIDRs      map[string]string // by subnet ID
 }
 
 // NewVCNManager creates a new VCNManager
@@ -32,6 +69,38 @@ func NewVCNManager(oci *oci.OCI) *VCNManager {
 	}
 }
 
+// ValidateVCNAccess checks that the VCN is reachable with the credential and that it belongs to the compartment
+// of the credential, so clusters cannot be attached to networks outside of the organization's scope
+func (m *VCNManager) ValidateVCNAccess(vcnID string) error {
+
+	vn, err := m.oci.NewVirtualNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	vcn, err := vn.GetVCN(&vcnID)
+	if err != nil {
+		if serviceError, ok := common.IsServiceError(err); ok && (serviceError.GetHTTPStatusCode() == 401 || serviceError.GetHTTPStatusCode() == 404) {
+			return &oci.VCNAccessError{
+				VCNID:           vcnID,
+				CompartmentOCID: m.oci.CompartmentOCID,
+				Reason:          "VCN not found or not authorized",
+			}
+		}
+		return err
+	}
+
+	if vcn.CompartmentId == nil || *vcn.CompartmentId != m.oci.CompartmentOCID {
+		return &oci.VCNAccessError{
+			VCNID:           vcnID,
+			CompartmentOCID: m.oci.CompartmentOCID,
+			Reason:          "VCN belongs to a different compartment",
+		}
+	}
+
+	return nil
+}
+
 // GetNetworkValues gives back NetworkValues collected from OCI for a given VCN
 func (m *VCNManager) GetNetworkValues(vcnID string) (values NetworkValues, err error) {
 
@@ -45,34 +114,155 @@ func (m *VCNManager) GetNetworkValues(vcnID string) (values NetworkValues, err e
 		return values, err
 	}
 
-	var subnet core.Subnet
-	for i := 1; i < 3; i++ {
-		if subnet, err = vn.GetSubnetByName(fmt.Sprintf("lb-%d", i), vcn.Id); err != nil {
-			return values, err
+	subnets, err := vn.GetSubnets(vcn.Id)
+	if err != nil {
+		return values, err
+	}
+
+	// the preconfigured subnets come first, then the worker subnets added by subnet expansion
+	sortSubnetsByName(subnets)
+
+	if vcn.CidrBlock != nil {
+		values.VCNCIDR = *vcn.CidrBlock
+	}
+	values.SubnetCIDRs = make(map[string]string)
+
+	for _, subnet := range subnets {
+		if subnet.CidrBlock != nil {
+			values.SubnetCIDRs[*subnet.Id] = *subnet.CidrBlock
+		}
+		switch GetSubnetRole(subnet) {
+		case SubnetRoleLoadBalancer:
+			values.LBSubnetIDs = append(values.LBSubnetIDs, *subnet.Id)
+		case SubnetRoleWorker:
+			values.WNSubnetIDs = append(values.WNSubnetIDs, *subnet.Id)
+		case SubnetRoleEndpoint:
+			// endpoint subnet only exists in VCNs created for private endpoint clusters
+			if values.EndpointSubnetID == "" {
+				values.EndpointSubnetID = *subnet.Id
+			}
 		}
-		values.LBSubnetIDs = append(values.LBSubnetIDs, *subnet.Id)
 	}
 
-	for i := 1; i < 4; i++ {
-		if subnet, err = vn.GetSubnetByName(fmt.Sprintf("wn-%d", i), vcn.Id); err != nil {
-			return values, err
+	// the number of load balancer subnets required is configured per cluster
+	if len(values.LBSubnetIDs) < 1 {
+		return values, fmt.Errorf("VCN %s has no loadbalancer subnets, at least 1 required", vcnID)
+	}
+
+	if len(values.WNSubnetIDs) < 3 {
+		return values, fmt.Errorf("VCN %s has %d worker node subnets, 3 required", vcnID, len(values.WNSubnetIDs))
+	}
+
+	return values, nil
+}
+
+// GetSubnetRole gives back the role of a subnet by its role tag, subnets created before the subnets were
+// tagged are classified by the names of the preconfigured subnets
+func GetSubnetRole(subnet core.Subnet) string {
+
+	if role, ok := subnet.FreeformTags[subnetRoleTagKey]; ok {
+		return role
+	}
+
+	if subnet.DisplayName == nil {
+		return ""
+	}
+
+	name := *subnet.DisplayName
+	switch {
+	case name == endpointSubnetName:
+		return SubnetRoleEndpoint
+	case name == "lb-1" || name == "lb-2":
+		return SubnetRoleLoadBalancer
+	case strings.HasPrefix(name, workerSubnetNamePrefix):
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, workerSubnetNamePrefix)); err == nil {
+			return SubnetRoleWorker
+		}
+	}
+
+	return ""
+}
+
+// sortSubnetsByName sorts the subnets by name, numbered names in numeric order (wn-2 before wn-10)
+func sortSubnetsByName(subnets []core.Subnet) {
+
+	name := func(subnet core.Subnet) string {
+		if subnet.DisplayName == nil {
+			return ""
+		}
+		return *subnet.DisplayName
+	}
+
+	sort.SliceStable(subnets, func(i, j int) bool {
+		a, b := name(subnets[i]), name(subnets[j])
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+}
+
+// FindReusableVCN looks for an existing VCN with the given name. A complete preconfigured VCN created by pipeline
+// (e.g. by a prior failed create) is given back to be reused, otherwise a VCNNameConflictError is returned
+// so the VCN of a cluster is never ambiguous.
+func (m *VCNManager) FindReusableVCN(name string, endpointSubnet bool) (vcn core.Vcn, found bool, err error) {
+
+	vn, err := m.oci.NewVirtualNetworkClient()
+	if err != nil {
+		return vcn, false, err
+	}
+
+	vcns, err := vn.GetVCNsByName(name)
+	if err != nil {
+		return vcn, false, err
+	}
+
+	if len(vcns) == 0 {
+		return vcn, false, nil
+	}
+
+	for _, v := range vcns {
+		if v.FreeformTags[createdByTagKey] != createdByTagValue {
+			return vcn, false, &oci.VCNNameConflictError{Name: name, VCNID: *v.Id}
 		}
-		values.WNSubnetIDs = append(values.WNSubnetIDs, *subnet.Id)
 	}
 
-	return values, err
+	if len(vcns) > 1 {
+		return vcn, false, &oci.VCNNameConflictError{Name: name, VCNID: *vcns[1].Id, PipelineOwned: true}
+	}
+
+	vcn = vcns[0]
+	values, err := m.GetNetworkValues(*vcn.Id)
+	if err != nil || (endpointSubnet && values.EndpointSubnetID == "") {
+		return vcn, false, &oci.VCNNameConflictError{Name: name, VCNID: *vcn.Id, PipelineOwned: true}
+	}
+
+	return vcn, true, nil
 }
 
-// Create creates a preconfigured VCN with the given name
+// Create creates a preconfigured VCN with the given name and CIDR block (PreconfiguredVCNCIDR if empty)
 //
-// VCN CIDR: 10.0.0.0/16
+// VCN CIDR: 10.0.0.0/16 by default, the subnets are at the same offsets in a custom CIDR block
 // - 3 subnets for worker nodes each in different AD within the region
 //   10.0.11.0/24, 10.0.12.0/24, 10.0.13.0/24
 // - 2 subnets for loadbalancers each in different AD within the region
 //   10.0.21.0/24, 10.0.22.0/24
 // - 2 security lists
 //   workernodes, loadbalancers
-func (m *VCNManager) Create(name string) (vcn core.Vcn, err error) {
+// - 1 subnet and security list for the Kubernetes API endpoint if endpointSubnet is true
+//   10.0.31.0/24
+//
+// The given tags are set on the VCN as freeform tags
+func (m *VCNManager) Create(name string, CIDR string, endpointSubnet bool, tags map[string]string) (vcn core.Vcn, err error) {
+
+	if CIDR == "" {
+		CIDR = PreconfiguredVCNCIDR
+	}
+
+	subnets, err := GetPreconfiguredSubnets(CIDR, endpointSubnet)
+	if err != nil {
+		return vcn, err
+	}
 
 	vn, err := m.oci.NewVirtualNetworkClient()
 	if err != nil {
@@ -80,7 +270,7 @@ func (m *VCNManager) Create(name string) (vcn core.Vcn, err error) {
 	}
 	m.vn = vn
 
-	vcn, err = m.createVCN(name, "10.0.0.0/16")
+	vcn, err = m.createVCN(name, CIDR, tags)
 	if err != nil {
 		return vcn, err
 	}
@@ -96,34 +286,151 @@ func (m *VCNManager) Create(name string) (vcn core.Vcn, err error) {
 		return vcn, err
 	}
 
-	wnSecurityList, err := m.createWorkerNodesSecurityList("workernodes", "10.0.0.0/16")
+	securityLists := make(map[string]*string)
+
+	wnSecurityList, err := m.createWorkerNodesSecurityList("workernodes", CIDR)
 	if err != nil {
 		return vcn, err
 	}
+	securityLists[SubnetRoleWorker] = wnSecurityList.Id
 
 	lbSecurityList, err := m.createLoadBalancersSecurityList("loadbalancers")
 	if err != nil {
 		return vcn, err
 	}
+	securityLists[SubnetRoleLoadBalancer] = lbSecurityList.Id
+
+	if endpointSubnet {
+		epSecurityList, err := m.createEndpointSecurityList("endpoint", CIDR)
+		if err != nil {
+			return vcn, err
+		}
+		securityLists[SubnetRoleEndpoint] = epSecurityList.Id
+	}
 
 	ads, err := m.getAvailabilityDomains()
 	if err != nil {
 		return vcn, err
 	}
 
-	for i := 1; i < 3; i++ {
-		if _, err = m.createSubnet(fmt.Sprintf("lb-%d", i), fmt.Sprintf("10.0.2%d.0/24", i), ads[i-1].Name, vcn.DefaultDhcpOptionsId, vcn.DefaultRouteTableId, lbSecurityList.Id); err != nil {
+	for _, subnet := range subnets {
+		if _, err = m.createSubnet(subnet.Name, subnet.Role, subnet.CIDR, ads[subnet.ADIndex].Name, vcn.DefaultDhcpOptionsId, vcn.DefaultRouteTableId, securityLists[subnet.Role]); err != nil {
 			return vcn, err
 		}
 	}
 
-	for i := 1; i < 4; i++ {
-		if _, err = m.createSubnet(fmt.Sprintf("wn-%d", i), fmt.Sprintf("10.0.1%d.0/24", i), ads[i-1].Name, vcn.DefaultDhcpOptionsId, vcn.DefaultRouteTableId, wnSecurityList.Id); err != nil {
-			return vcn, err
+	return vcn, nil
+}
+
+// AddWorkerSubnet adds a new worker node subnet to the VCN with the next free /24 block of the VCN CIDR,
+// the subnets are spread over the availability domains the same way as the preconfigured ones
+func (m *VCNManager) AddWorkerSubnet(vcnID string) (subnet core.Subnet, err error) {
+
+	vn, err := m.oci.NewVirtualNetworkClient()
+	if err != nil {
+		return subnet, err
+	}
+	m.vn = vn
+
+	vcn, err := vn.GetVCN(&vcnID)
+	if err != nil {
+		return subnet, err
+	}
+	m.vcn = vcn
+
+	subnets, err := vn.GetSubnets(vcn.Id)
+	if err != nil {
+		return subnet, err
+	}
+
+	used := make([]string, 0)
+	for _, s := range subnets {
+		used = append(used, *s.CidrBlock)
+	}
+
+	CIDR, err := NextFreeSubnetCIDR(*vcn.CidrBlock, 24, used)
+	if err != nil {
+		return subnet, err
+	}
+
+	index := 1
+	for {
+		if _, found := getSubnetByName(subnets, fmt.Sprintf("%s%d", workerSubnetNamePrefix, index)); !found {
+			break
 		}
+		index++
 	}
 
-	return vcn, nil
+	securityList, err := vn.GetSecurityListByName("workernodes", vcn.Id)
+	if err != nil {
+		return subnet, err
+	}
+
+	ads, err := m.getAvailabilityDomains()
+	if err != nil {
+		return subnet, err
+	}
+	if len(ads) == 0 {
+		return subnet, fmt.Errorf("There are no availability domains")
+	}
+
+	return m.createSubnet(fmt.Sprintf("%s%d", workerSubnetNamePrefix, index), SubnetRoleWorker, CIDR, ads[(index-1)%len(ads)].Name, vcn.DefaultDhcpOptionsId, vcn.DefaultRouteTableId, securityList.Id)
+}
+
+// getSubnetByName gets a subnet from the given subnets by name
+func getSubnetByName(subnets []core.Subnet, name string) (core.Subnet, bool) {
+
+	for _, subnet := range subnets {
+		if subnet.DisplayName != nil && *subnet.DisplayName == name {
+			return subnet, true
+		}
+	}
+
+	return core.Subnet{}, false
+}
+
+// GetPreconfiguredSubnets gives back the subnets of the preconfigured VCN with the given CIDR block
+// (PreconfiguredVCNCIDR if empty)
+func GetPreconfiguredSubnets(VCNCIDR string, endpointSubnet bool) ([]PreconfiguredSubnet, error) {
+
+	if VCNCIDR == "" {
+		VCNCIDR = PreconfiguredVCNCIDR
+	}
+
+	if err := ValidatePreconfiguredVCNCIDR(VCNCIDR); err != nil {
+		return nil, err
+	}
+
+	subnets := make([]PreconfiguredSubnet, 0)
+
+	for i := 1; i < 3; i++ {
+		subnets = append(subnets, PreconfiguredSubnet{
+			Name:    fmt.Sprintf("lb-%d", i),
+			CIDR:    preconfiguredSubnetCIDR(VCNCIDR, 20+i),
+			Role:    SubnetRoleLoadBalancer,
+			ADIndex: i - 1,
+		})
+	}
+
+	for i := 1; i < 4; i++ {
+		subnets = append(subnets, PreconfiguredSubnet{
+			Name:    fmt.Sprintf("%s%d", workerSubnetNamePrefix, i),
+			CIDR:    preconfiguredSubnetCIDR(VCNCIDR, 10+i),
+			Role:    SubnetRoleWorker,
+			ADIndex: i - 1,
+		})
+	}
+
+	if endpointSubnet {
+		subnets = append(subnets, PreconfiguredSubnet{
+			Name:    endpointSubnetName,
+			CIDR:    preconfiguredSubnetCIDR(VCNCIDR, 31),
+			Role:    SubnetRoleEndpoint,
+			ADIndex: 0,
+		})
+	}
+
+	return subnets, nil
 }
 
 // Delete deletes a VCN and all related resources by id
@@ -185,7 +492,14 @@ func (m *VCNManager) addDefaultRoute(id *string, igw core.InternetGateway) (err
 	return err
 }
 
-func (m *VCNManager) createVCN(name string, CIDR string) (vcn core.Vcn, err error) {
+func (m *VCNManager) createVCN(name string, CIDR string, tags map[string]string) (vcn core.Vcn, err error) {
+
+	freeformTags := map[string]string{createdByTagKey: createdByTagValue}
+	for key, value := range tags {
+		if key != createdByTagKey {
+			freeformTags[key] = value
+		}
+	}
 
 	r := core.CreateVcnRequest{
 		CreateVcnDetails: core.CreateVcnDetails{
@@ -193,7 +507,7 @@ func (m *VCNManager) createVCN(name string, CIDR string) (vcn core.Vcn, err erro
 			CidrBlock:     common.String(CIDR),
 			CompartmentId: common.String(m.oci.CompartmentOCID),
 			DnsLabel:      common.String(CreateDNSLabel(name)),
-			FreeformTags:  map[string]string{"created-by": "pipeline"},
+			FreeformTags:  freeformTags,
 		},
 	}
 
@@ -292,6 +606,33 @@ func (m *VCNManager) createWorkerNodesSecurityList(name string, CIDR string) (li
 	return m.createSecurityList(name, egress, ingress)
 }
 
+func (m *VCNManager) createEndpointSecurityList(name string, CIDR string) (list core.SecurityList, err error) {
+
+	egress := []core.EgressSecurityRule{
+		{
+			Destination: common.String(CIDR),
+			Protocol:    common.String("6"),
+			IsStateless: common.Bool(false),
+		},
+	}
+
+	ingress := []core.IngressSecurityRule{
+		{
+			Source:      common.String(CIDR),
+			Protocol:    common.String("6"),
+			IsStateless: common.Bool(false),
+			TcpOptions: &core.TcpOptions{
+				DestinationPortRange: &core.PortRange{
+					Min: common.Int(6443),
+					Max: common.Int(6443),
+				},
+			},
+		},
+	}
+
+	return m.createSecurityList(name, egress, ingress)
+}
+
 func (m *VCNManager) createLoadBalancersSecurityList(name string) (list core.SecurityList, err error) {
 
 	egress := []core.EgressSecurityRule{
@@ -313,7 +654,7 @@ func (m *VCNManager) createLoadBalancersSecurityList(name string) (list core.Sec
 	return m.createSecurityList(name, egress, ingress)
 }
 
-func (m *VCNManager) createSubnet(name string, CIDR string, AD *string, DHCPOptionsID *string, RouteTableID *string, SecurityListID *string) (subnet core.Subnet, err error) {
+func (m *VCNManager) createSubnet(name string, role string, CIDR string, AD *string, DHCPOptionsID *string, RouteTableID *string, SecurityListID *string) (subnet core.Subnet, err error) {
 
 	r := core.CreateSubnetRequest{
 		CreateSubnetDetails: core.CreateSubnetDetails{
@@ -326,10 +667,14 @@ func (m *VCNManager) createSubnet(name string, CIDR string, AD *string, DHCPOpti
 			RouteTableId:       RouteTableID,
 			SecurityListIds:    []string{*SecurityListID},
 			DnsLabel:           common.String(CreateDNSLabel(name)),
+			FreeformTags: map[string]string{
+				createdByTagKey:  createdByTagValue,
+				subnetRoleTagKey: role,
+			},
 		},
 	}
 
-	m.oci.GetLogger().Debugf("Creating Subnet '%s'", name)
+	m.oci.GetLogger().Debugf("Creating Subnet '%s' with role %s", name, role)
 
 	return m.vn.CreateSubnet(r)
 }
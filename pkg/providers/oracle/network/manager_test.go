@@ -0,0 +1,37 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/core"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+)
+
+func TestGetSubnetRole(t *testing.T) {
+
+	cases := []struct {
+		name   string
+		subnet core.Subnet
+		role   string
+	}{
+		{"tagged lb", core.Subnet{DisplayName: common.String("renamed"), FreeformTags: map[string]string{"subnet-role": "lb"}}, network.SubnetRoleLoadBalancer},
+		{"tagged worker", core.Subnet{DisplayName: common.String("lb-1"), FreeformTags: map[string]string{"subnet-role": "worker"}}, network.SubnetRoleWorker},
+		{"tagged pod", core.Subnet{DisplayName: common.String("pods"), FreeformTags: map[string]string{"subnet-role": "pod"}}, network.SubnetRolePod},
+		{"untagged lb", core.Subnet{DisplayName: common.String("lb-2")}, network.SubnetRoleLoadBalancer},
+		{"untagged worker", core.Subnet{DisplayName: common.String("wn-4")}, network.SubnetRoleWorker},
+		{"untagged endpoint", core.Subnet{DisplayName: common.String("ep-1")}, network.SubnetRoleEndpoint},
+		{"unknown", core.Subnet{DisplayName: common.String("wn-custom")}, ""},
+		{"no name", core.Subnet{}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			role := network.GetSubnetRole(tc.subnet)
+			if role != tc.role {
+				t.Errorf("Expected role: %q, got: %q", tc.role, role)
+			}
+		})
+	}
+}
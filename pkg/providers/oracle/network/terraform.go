@@ -0,0 +1,318 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/core"
+)
+
+// ExportVCNTerraform serializes the VCN and its subnets, internet gateways, route tables and security lists
+// into Terraform HCL for the OCI provider, every resource is preceded by its `terraform import` command
+func (m *VCNManager) ExportVCNTerraform(vcnID string) ([]byte, error) {
+
+	vn, err := m.oci.NewVirtualNetworkClient()
+	if err != nil {
+		return nil, err
+	}
+
+	vcn, err := vn.GetVCN(&vcnID)
+	if err != nil {
+		return nil, err
+	}
+
+	igws, err := vn.GetInternetGateways(vcn.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := vn.GetRouteTables(vcn.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := vn.GetSecurityLists(vcn.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	subnets, err := vn.GetSubnets(vcn.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	hcl, err := VCNTerraform(vcn, igws, tables, lists, subnets)
+	if err != nil {
+		return nil, err
+	}
+
+	m.oci.GetLogger().Debugf("VCN '%s' exported as Terraform", *vcn.DisplayName)
+
+	return hcl, nil
+}
+
+// VCNTerraform serializes the given VCN and its subnets, internet gateways, route tables and security lists into
+// Terraform HCL, an error is returned if a reference of a resource has no target
+func VCNTerraform(vcn core.Vcn, igws []core.InternetGateway, tables []core.RouteTable, lists []core.SecurityList, subnets []core.Subnet) ([]byte, error) {
+
+	e := terraformExporter{
+		refs: make(map[string]string),
+	}
+
+	vcnName := e.resource("oci_core_vcn", vcn.DisplayName, vcn.Id)
+	e.attr("compartment_id", *vcn.CompartmentId)
+	e.attr("cidr_block", *vcn.CidrBlock)
+	e.attr("display_name", *vcn.DisplayName)
+	if vcn.DnsLabel != nil {
+		e.attr("dns_label", *vcn.DnsLabel)
+	}
+	e.end()
+
+	for _, igw := range igws {
+		e.resource("oci_core_internet_gateway", igw.DisplayName, igw.Id)
+		e.attr("compartment_id", *igw.CompartmentId)
+		e.ref("vcn_id", vcnName+".id")
+		e.attr("display_name", *igw.DisplayName)
+		if igw.IsEnabled != nil {
+			e.raw("enabled", fmt.Sprintf("%t", *igw.IsEnabled))
+		}
+		e.end()
+	}
+
+	for _, table := range tables {
+		if vcn.DefaultRouteTableId != nil && *table.Id == *vcn.DefaultRouteTableId {
+			e.resource("oci_core_default_route_table", table.DisplayName, table.Id)
+			e.ref("manage_default_resource_id", vcnName+".default_route_table_id")
+		} else {
+			e.resource("oci_core_route_table", table.DisplayName, table.Id)
+			e.attr("compartment_id", *table.CompartmentId)
+			e.ref("vcn_id", vcnName+".id")
+		}
+		e.attr("display_name", *table.DisplayName)
+		for _, rule := range table.RouteRules {
+			e.block("route_rules")
+			destination := rule.CidrBlock
+			if rule.Destination != nil {
+				destination = rule.Destination
+			}
+			if destination != nil {
+				e.attr("destination", *destination)
+			}
+			e.ref("network_entity_id", e.reference(rule.NetworkEntityId))
+			e.end()
+		}
+		e.end()
+	}
+
+	for _, list := range lists {
+		if vcn.DefaultSecurityListId != nil && *list.Id == *vcn.DefaultSecurityListId {
+			e.resource("oci_core_default_security_list", list.DisplayName, list.Id)
+			e.ref("manage_default_resource_id", vcnName+".default_security_list_id")
+		} else {
+			e.resource("oci_core_security_list", list.DisplayName, list.Id)
+			e.attr("compartment_id", *list.CompartmentId)
+			e.ref("vcn_id", vcnName+".id")
+		}
+		e.attr("display_name", *list.DisplayName)
+		for _, rule := range list.IngressSecurityRules {
+			e.block("ingress_security_rules")
+			e.attr("protocol", *rule.Protocol)
+			e.attr("source", *rule.Source)
+			e.stateless(rule.IsStateless)
+			e.portOptions(rule.TcpOptions, rule.UdpOptions)
+			e.end()
+		}
+		for _, rule := range list.EgressSecurityRules {
+			e.block("egress_security_rules")
+			e.attr("protocol", *rule.Protocol)
+			e.attr("destination", *rule.Destination)
+			e.stateless(rule.IsStateless)
+			e.portOptions(rule.TcpOptions, rule.UdpOptions)
+			e.end()
+		}
+		e.end()
+	}
+
+	for _, subnet := range subnets {
+		e.resource("oci_core_subnet", subnet.DisplayName, subnet.Id)
+		e.attr("compartment_id", *subnet.CompartmentId)
+		e.ref("vcn_id", vcnName+".id")
+		e.attr("availability_domain", *subnet.AvailabilityDomain)
+		e.attr("cidr_block", *subnet.CidrBlock)
+		e.attr("display_name", *subnet.DisplayName)
+		if subnet.DnsLabel != nil {
+			e.attr("dns_label", *subnet.DnsLabel)
+		}
+		e.ref("route_table_id", e.reference(subnet.RouteTableId))
+		if subnet.DhcpOptionsId != nil {
+			if vcn.DefaultDhcpOptionsId != nil && *subnet.DhcpOptionsId == *vcn.DefaultDhcpOptionsId {
+				e.ref("dhcp_options_id", vcnName+".default_dhcp_options_id")
+			} else {
+				e.ref("dhcp_options_id", e.reference(subnet.DhcpOptionsId))
+			}
+		}
+		securityListIDs := make([]string, 0)
+		for _, id := range subnet.SecurityListIds {
+			securityListIDs = append(securityListIDs, e.interpolation(e.reference(&id)))
+		}
+		e.raw("security_list_ids", fmt.Sprintf("[%s]", strings.Join(securityListIDs, ", ")))
+		if subnet.ProhibitPublicIpOnVnic != nil {
+			e.raw("prohibit_public_ip_on_vnic", fmt.Sprintf("%t", *subnet.ProhibitPublicIpOnVnic))
+		}
+		e.end()
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e.buf.Bytes(), nil
+}
+
+// terraformExporter writes Terraform HCL resources
+type terraformExporter struct {
+	buf    bytes.Buffer
+	indent int
+	// OCID -> terraform resource address
+	refs map[string]string
+	// address of the resource being written
+	current string
+	// the first error of the export
+	err error
+}
+
+// resource opens a resource block and gives back its address
+func (e *terraformExporter) resource(kind string, displayName, id *string) string {
+
+	name := terraformName(*displayName)
+	address := fmt.Sprintf("%s.%s", kind, name)
+	// avoid address collisions between resources with the same display name
+	for i := 1; e.hasAddress(address); i++ {
+		address = fmt.Sprintf("%s.%s_%d", kind, name, i)
+	}
+	e.refs[*id] = address
+	e.current = address
+
+	fmt.Fprintf(&e.buf, "# terraform import %s %s\n", address, *id)
+	fmt.Fprintf(&e.buf, "resource \"%s\" \"%s\" {\n", kind, strings.TrimPrefix(address, kind+"."))
+	e.indent++
+
+	return address
+}
+
+func (e *terraformExporter) hasAddress(address string) bool {
+
+	for _, a := range e.refs {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reference gives back the interpolation target of the given OCID, or the OCID itself
+// if the resource is not part of the export
+func (e *terraformExporter) reference(id *string) string {
+
+	if id == nil {
+		return ""
+	}
+
+	if address, ok := e.refs[*id]; ok {
+		return address + ".id"
+	}
+
+	return *id
+}
+
+func (e *terraformExporter) block(name string) {
+
+	fmt.Fprintf(&e.buf, "%s%s {\n", e.prefix(), name)
+	e.indent++
+}
+
+func (e *terraformExporter) end() {
+
+	e.indent--
+	fmt.Fprintf(&e.buf, "%s}\n", e.prefix())
+	if e.indent == 0 {
+		e.buf.WriteString("\n")
+	}
+}
+
+func (e *terraformExporter) attr(name, value string) {
+
+	e.raw(name, fmt.Sprintf("%q", value))
+}
+
+func (e *terraformExporter) ref(name, target string) {
+
+	if target == "" {
+		if e.err == nil {
+			e.err = fmt.Errorf("%s of %s has no target", name, e.current)
+		}
+		return
+	}
+
+	e.raw(name, e.interpolation(target))
+}
+
+// interpolation gives back the quoted reference of the target, OCIDs of resources which are not part of the
+// export are referenced as they are
+func (e *terraformExporter) interpolation(target string) string {
+
+	if strings.HasPrefix(target, "ocid1.") {
+		return fmt.Sprintf("%q", target)
+	}
+
+	return fmt.Sprintf("\"${%s}\"", target)
+}
+
+func (e *terraformExporter) raw(name, value string) {
+
+	fmt.Fprintf(&e.buf, "%s%s = %s\n", e.prefix(), name, value)
+}
+
+func (e *terraformExporter) stateless(isStateless *bool) {
+
+	if isStateless != nil {
+		e.raw("stateless", fmt.Sprintf("%t", *isStateless))
+	}
+}
+
+func (e *terraformExporter) portOptions(tcp *core.TcpOptions, udp *core.UdpOptions) {
+
+	if tcp != nil && tcp.DestinationPortRange != nil {
+		e.block("tcp_options")
+		e.raw("min", fmt.Sprintf("%d", *tcp.DestinationPortRange.Min))
+		e.raw("max", fmt.Sprintf("%d", *tcp.DestinationPortRange.Max))
+		e.end()
+	}
+
+	if udp != nil && udp.DestinationPortRange != nil {
+		e.block("udp_options")
+		e.raw("min", fmt.Sprintf("%d", *udp.DestinationPortRange.Min))
+		e.raw("max", fmt.Sprintf("%d", *udp.DestinationPortRange.Max))
+		e.end()
+	}
+}
+
+func (e *terraformExporter) prefix() string {
+
+	return strings.Repeat("  ", e.indent)
+}
+
+// terraformName creates a valid Terraform resource name from the given display name
+func terraformName(str string) string {
+
+	reg := regexp.MustCompile("[^a-zA-Z0-9_]+")
+	name := strings.ToLower(reg.ReplaceAllString(str, "_"))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+
+	return name
+}
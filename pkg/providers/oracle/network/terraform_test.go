@@ -0,0 +1,100 @@
+package network_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/core"
+
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/network"
+)
+
+func TestVCNTerraform(t *testing.T) {
+
+	vcn := core.Vcn{
+		Id:                    common.String("ocid1.vcn.oc1..vcn"),
+		CompartmentId:         common.String("ocid1.compartment.oc1..c"),
+		CidrBlock:             common.String("10.0.0.0/16"),
+		DisplayName:           common.String("oke-vcn"),
+		DefaultRouteTableId:   common.String("ocid1.routetable.oc1..default"),
+		DefaultSecurityListId: common.String("ocid1.securitylist.oc1..default"),
+		DefaultDhcpOptionsId:  common.String("ocid1.dhcpoptions.oc1..default"),
+	}
+	igws := []core.InternetGateway{
+		{Id: common.String("ocid1.internetgateway.oc1..igw"), CompartmentId: vcn.CompartmentId, DisplayName: common.String("gateway"), IsEnabled: common.Bool(true)},
+	}
+	tables := []core.RouteTable{
+		{Id: vcn.DefaultRouteTableId, DisplayName: common.String("default"), RouteRules: []core.RouteRule{
+			{CidrBlock: common.String("0.0.0.0/0"), NetworkEntityId: common.String("ocid1.internetgateway.oc1..igw")},
+		}},
+	}
+	lists := []core.SecurityList{
+		{Id: vcn.DefaultSecurityListId, DisplayName: common.String("default")},
+		{Id: common.String("ocid1.securitylist.oc1..sl"), CompartmentId: vcn.CompartmentId, DisplayName: common.String("workers")},
+	}
+	subnets := []core.Subnet{
+		{
+			Id:                 common.String("ocid1.subnet.oc1..sn"),
+			CompartmentId:      vcn.CompartmentId,
+			AvailabilityDomain: common.String("AD-1"),
+			CidrBlock:          common.String("10.0.10.0/24"),
+			DisplayName:        common.String("wn-1"),
+			RouteTableId:       vcn.DefaultRouteTableId,
+			DhcpOptionsId:      vcn.DefaultDhcpOptionsId,
+			SecurityListIds:    []string{"ocid1.securitylist.oc1..sl", "ocid1.securitylist.oc1..external"},
+		},
+	}
+
+	hcl, err := network.VCNTerraform(vcn, igws, tables, lists, subnets)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expected := []string{
+		"# terraform import oci_core_vcn.oke_vcn ocid1.vcn.oc1..vcn\n",
+		"resource \"oci_core_internet_gateway\" \"gateway\" {\n",
+		"  vcn_id = \"${oci_core_vcn.oke_vcn.id}\"\n",
+		"  manage_default_resource_id = \"${oci_core_vcn.oke_vcn.default_route_table_id}\"\n",
+		"    network_entity_id = \"${oci_core_internet_gateway.gateway.id}\"\n",
+		"resource \"oci_core_security_list\" \"workers\" {\n",
+		"  route_table_id = \"${oci_core_default_route_table.default.id}\"\n",
+		"  dhcp_options_id = \"${oci_core_vcn.oke_vcn.default_dhcp_options_id}\"\n",
+		"  security_list_ids = [\"${oci_core_security_list.workers.id}\", \"ocid1.securitylist.oc1..external\"]\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(string(hcl), line) {
+			t.Errorf("Expected %q in:\n%s", line, hcl)
+		}
+	}
+	if strings.Contains(string(hcl), "${}") {
+		t.Errorf("Unexpected empty reference in:\n%s", hcl)
+	}
+}
+
+func TestVCNTerraformEmptyReference(t *testing.T) {
+
+	vcn := core.Vcn{
+		Id:            common.String("ocid1.vcn.oc1..vcn"),
+		CompartmentId: common.String("ocid1.compartment.oc1..c"),
+		CidrBlock:     common.String("10.0.0.0/16"),
+		DisplayName:   common.String("oke-vcn"),
+	}
+	subnets := []core.Subnet{
+		{
+			Id:                 common.String("ocid1.subnet.oc1..sn"),
+			CompartmentId:      vcn.CompartmentId,
+			AvailabilityDomain: common.String("AD-1"),
+			CidrBlock:          common.String("10.0.10.0/24"),
+			DisplayName:        common.String("wn-1"),
+		},
+	}
+
+	_, err := network.VCNTerraform(vcn, nil, nil, nil, subnets)
+	if err == nil {
+		t.Fatal("Expected error for the subnet without route table")
+	}
+	if !strings.Contains(err.Error(), "route_table_id of oci_core_subnet.wn_1") {
+		t.Errorf("Unexpected error: %s", err.Error())
+	}
+}
@@ -0,0 +1,133 @@
+package oci
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/audit"
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// Audit is for managing Audit related calls of OCI
+type Audit struct {
+	CompartmentOCID string
+
+	oci    *OCI
+	client *audit.AuditClient
+}
+
+// OCIAuditEvent describes an OCI Audit event of a resource
+type OCIAuditEvent struct {
+	EventID        string    `json:"eventId"`
+	EventName      string    `json:"eventName"`
+	EventSource    string    `json:"eventSource"`
+	EventTime      time.Time `json:"eventTime"`
+	PrincipalID    string    `json:"principalId,omitempty"`
+	UserName       string    `json:"userName,omitempty"`
+	RequestAction  string    `json:"requestAction,omitempty"`
+	RequestOrigin  string    `json:"requestOrigin,omitempty"`
+	RequestAgent   string    `json:"requestAgent,omitempty"`
+	Resource       string    `json:"resource,omitempty"`
+	ResponseStatus string    `json:"responseStatus,omitempty"`
+}
+
+// NewAuditClient creates a new Audit
+func (oci *OCI) NewAuditClient() (client *Audit, err error) {
+
+	client = &Audit{}
+
+	oClient, err := audit.NewAuditClientWithConfigurationProvider(oci.config)
+	if err != nil {
+		return client, err
+	}
+
+	oci.configureClient(&oClient.BaseClient)
+
+	client.client = &oClient
+	client.oci = oci
+	client.CompartmentOCID = oci.CompartmentOCID
+
+	return client, nil
+}
+
+// GetEventsByResourceIDs gets the Audit events of the Compartment processed between from and to
+// which are related to any of the given resource OCIDs
+func (a *Audit) GetEventsByResourceIDs(from, to time.Time, resourceIDs []string) (events []OCIAuditEvent, err error) {
+
+	events = make([]OCIAuditEvent, 0)
+
+	// the Audit API accepts minute granularity only
+	request := audit.ListEventsRequest{
+		CompartmentId: common.String(a.CompartmentOCID),
+		StartTime:     &common.SDKTime{Time: from.UTC().Truncate(time.Minute)},
+		EndTime:       &common.SDKTime{Time: to.UTC().Add(time.Minute - time.Nanosecond).Truncate(time.Minute)},
+	}
+
+	listFunc := func(request audit.ListEventsRequest) (audit.ListEventsResponse, error) {
+		return a.client.ListEvents(context.Background(), request)
+	}
+
+	for response, err := listFunc(request); ; response, err = listFunc(request) {
+		if err != nil {
+			return events, err
+		}
+
+		for _, item := range response.Items {
+			if resource, ok := getAuditEventResource(item, resourceIDs); ok {
+				events = append(events, newOCIAuditEvent(item, resource))
+			}
+		}
+
+		if response.OpcNextPage != nil {
+			// if there are more items in next page, fetch items from next page
+			request.Page = response.OpcNextPage
+		} else {
+			// no more result, break the loop
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// getAuditEventResource returns the resource OCID the event is related to
+func getAuditEventResource(event audit.AuditEvent, resourceIDs []string) (string, bool) {
+
+	for _, id := range resourceIDs {
+		if id == "" {
+			continue
+		}
+		if event.RequestResource != nil && strings.Contains(*event.RequestResource, id) {
+			return id, true
+		}
+		for _, value := range event.ResponsePayload {
+			if s, ok := value.(string); ok && s == id {
+				return id, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func newOCIAuditEvent(event audit.AuditEvent, resource string) OCIAuditEvent {
+
+	e := OCIAuditEvent{
+		EventID:        stringValue(event.EventId),
+		EventName:      stringValue(event.EventName),
+		EventSource:    stringValue(event.EventSource),
+		PrincipalID:    stringValue(event.PrincipalId),
+		UserName:       stringValue(event.UserName),
+		RequestAction:  stringValue(event.RequestAction),
+		RequestOrigin:  stringValue(event.RequestOrigin),
+		RequestAgent:   stringValue(event.RequestAgent),
+		Resource:       resource,
+		ResponseStatus: stringValue(event.ResponseStatus),
+	}
+	if event.EventTime != nil {
+		e.EventTime = event.EventTime.Time
+	}
+
+	return e
+}
@@ -0,0 +1,153 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/core"
+)
+
+// BlockStorage is for managing Block Storage related calls of OCI
+type BlockStorage struct {
+	CompartmentOCID string
+
+	oci    *OCI
+	client *core.BlockstorageClient
+}
+
+// volumeKMSKey describes the KMS key of a boot or block volume, the SDK in use does not cover volume KMS keys
+type volumeKMSKey struct {
+	KMSKeyID *string `json:"kmsKeyId"`
+}
+
+// bootVolumePerformance describes the performance tier of a boot volume, which is not covered by the SDK in use
+type bootVolumePerformance struct {
+	VPUsPerGB *int64 `json:"vpusPerGB,omitempty"`
+}
+
+// Boot volume performance range in volume performance units per GB
+const (
+	MinBootVolumeVPUsPerGB  = 10
+	MaxBootVolumeVPUsPerGB  = 120
+	BootVolumeVPUsPerGBStep = 10
+)
+
+// NewBlockStorageClient creates a new BlockStorage
+func (oci *OCI) NewBlockStorageClient() (client *BlockStorage, err error) {
+
+	client = &BlockStorage{}
+
+	oClient, err := core.NewBlockstorageClientWithConfigurationProvider(oci.config)
+	if err != nil {
+		return client, err
+	}
+
+	oci.configureClient(&oClient.BaseClient)
+
+	client.client = &oClient
+	client.oci = oci
+	client.CompartmentOCID = oci.CompartmentOCID
+
+	return client, nil
+}
+
+// GetBootVolumeKMSKeyID gets the OCID of the KMS key of a boot volume, it is empty for Oracle managed keys
+func (b *BlockStorage) GetBootVolumeKMSKeyID(ctx context.Context, id string) (string, error) {
+
+	return b.getKMSKeyID(ctx, fmt.Sprintf("/bootVolumes/%s/kmsKey", id))
+}
+
+// UpdateBootVolumeKMSKey sets the KMS key of a boot volume
+func (b *BlockStorage) UpdateBootVolumeKMSKey(ctx context.Context, id, keyID string) error {
+
+	return b.updateKMSKey(ctx, fmt.Sprintf("/bootVolumes/%s/kmsKey", id), keyID)
+}
+
+// GetVolumeKMSKeyID gets the OCID of the KMS key of a block volume, it is empty for Oracle managed keys
+func (b *BlockStorage) GetVolumeKMSKeyID(ctx context.Context, id string) (string, error) {
+
+	return b.getKMSKeyID(ctx, fmt.Sprintf("/volumes/%s/kmsKey", id))
+}
+
+// UpdateVolumeKMSKey sets the KMS key of a block volume
+func (b *BlockStorage) UpdateVolumeKMSKey(ctx context.Context, id, keyID string) error {
+
+	return b.updateKMSKey(ctx, fmt.Sprintf("/volumes/%s/kmsKey", id), keyID)
+}
+
+// GetBootVolumeVPUsPerGB gets the performance tier of a boot volume in volume performance units per GB
+func (b *BlockStorage) GetBootVolumeVPUsPerGB(ctx context.Context, id string) (int64, error) {
+
+	var volume bootVolumePerformance
+	if err := b.get(ctx, fmt.Sprintf("/bootVolumes/%s", id), &volume); err != nil {
+		return 0, err
+	}
+
+	if volume.VPUsPerGB == nil {
+		return 0, nil
+	}
+
+	return *volume.VPUsPerGB, nil
+}
+
+// UpdateBootVolumeVPUsPerGB sets the performance tier of a boot volume
+func (b *BlockStorage) UpdateBootVolumeVPUsPerGB(ctx context.Context, id string, vpusPerGB int64) error {
+
+	return b.put(ctx, fmt.Sprintf("/bootVolumes/%s", id), bootVolumePerformance{VPUsPerGB: common.Int64(vpusPerGB)})
+}
+
+func (b *BlockStorage) getKMSKeyID(ctx context.Context, path string) (string, error) {
+
+	var key volumeKMSKey
+	if err := b.get(ctx, path, &key); err != nil {
+		return "", err
+	}
+
+	if key.KMSKeyID == nil {
+		return "", nil
+	}
+
+	return *key.KMSKeyID, nil
+}
+
+func (b *BlockStorage) updateKMSKey(ctx context.Context, path, keyID string) error {
+
+	return b.put(ctx, path, volumeKMSKey{KMSKeyID: common.String(keyID)})
+}
+
+// get makes a signed GET request to the block storage API and decodes the response into v
+func (b *BlockStorage) get(ctx context.Context, path string, v interface{}) error {
+
+	request := common.MakeDefaultHTTPRequest("GET", path)
+
+	response, err := b.client.Call(ctx, &request)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return err
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+// put makes a signed PUT request to the block storage API with the JSON encoded body
+func (b *BlockStorage) put(ctx context.Context, path string, body interface{}) error {
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request := common.MakeDefaultHTTPRequest("PUT", path)
+	request.Header.Set("Content-Type", "application/json")
+	request.ContentLength = int64(len(raw))
+	request.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+	response, err := b.client.Call(ctx, &request)
+	defer common.CloseBodyIfValid(response)
+
+	return err
+}
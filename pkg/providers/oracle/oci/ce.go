@@ -28,6 +28,8 @@ func (oci *OCI) NewContainerEngineClient() (client *ContainerEngine, err error)
 		return client, err
 	}
 
+	oci.configureClient(&oClient.BaseClient)
+
 	client.client = &oClient
 	client.oci = oci
 	client.CompartmentOCID = oci.CompartmentOCID
@@ -47,7 +49,7 @@ func (ce *ContainerEngine) getResourceID(resources []containerengine.WorkRequest
 }
 
 // wait until work request finish
-func (ce *ContainerEngine) waitUntilWorkRequestComplete(client containerengine.ContainerEngineClient, workReuqestID *string) (containerengine.GetWorkRequestResponse, error) {
+func (ce *ContainerEngine) waitUntilWorkRequestComplete(ctx context.Context, client containerengine.ContainerEngineClient, workReuqestID *string) (containerengine.GetWorkRequestResponse, error) {
 
 	// retry GetWorkRequest call until TimeFinished is set
 	policy := common.NewRetryPolicy(uint(180), func(r common.OCIOperationResponse) bool {
@@ -63,17 +65,17 @@ func (ce *ContainerEngine) waitUntilWorkRequestComplete(client containerengine.C
 		},
 	}
 
-	return client.GetWorkRequest(context.Background(), getWorkReq)
+	return client.GetWorkRequest(ctx, getWorkReq)
 }
 
 // GetAvailableKubernetesVersions gets available K8S versions
-func (ce *ContainerEngine) GetAvailableKubernetesVersions() (versions Strings, err error) {
+func (ce *ContainerEngine) GetAvailableKubernetesVersions(ctx context.Context) (versions Strings, err error) {
 
 	request := containerengine.GetClusterOptionsRequest{
 		ClusterOptionId: common.String("all"),
 	}
 
-	r, err := ce.client.GetClusterOptions(context.Background(), request)
+	r, err := ce.client.GetClusterOptions(ctx, request)
 
 	return Strings{
 		strings: r.KubernetesVersions,
@@ -81,9 +83,9 @@ func (ce *ContainerEngine) GetAvailableKubernetesVersions() (versions Strings, e
 }
 
 // GetK8SConfig generates and downloads K8S config
-func (ce *ContainerEngine) GetK8SConfig(OCID string) ([]byte, error) {
+func (ce *ContainerEngine) GetK8SConfig(ctx context.Context, OCID string) ([]byte, error) {
 
-	response, err := ce.client.CreateKubeconfig(context.Background(), containerengine.CreateKubeconfigRequest{
+	response, err := ce.client.CreateKubeconfig(ctx, containerengine.CreateKubeconfigRequest{
 		ClusterId: &OCID,
 	})
 
@@ -93,3 +95,32 @@ func (ce *ContainerEngine) GetK8SConfig(OCID string) ([]byte, error) {
 
 	return ioutil.ReadAll(response.Content)
 }
+
+// GetFailedWorkRequestErrors gets the errors of the failed work requests of a cluster
+func (ce *ContainerEngine) GetFailedWorkRequestErrors(ctx context.Context, clusterID *string) (workRequestErrors []containerengine.WorkRequestError, err error) {
+
+	request := containerengine.ListWorkRequestsRequest{
+		CompartmentId: common.String(ce.CompartmentOCID),
+		ClusterId:     clusterID,
+		Status:        []containerengine.ListWorkRequestsStatusEnum{containerengine.ListWorkRequestsStatusFailed},
+	}
+
+	response, err := ce.client.ListWorkRequests(ctx, request)
+	if err != nil {
+		return workRequestErrors, err
+	}
+
+	for _, workRequest := range response.Items {
+		r, err := ce.client.ListWorkRequestErrors(ctx, containerengine.ListWorkRequestErrorsRequest{
+			CompartmentId: common.String(ce.CompartmentOCID),
+			WorkRequestId: workRequest.Id,
+		})
+		if err != nil {
+			return workRequestErrors, err
+		}
+
+		workRequestErrors = append(workRequestErrors, r.Items...)
+	}
+
+	return workRequestErrors, nil
+}
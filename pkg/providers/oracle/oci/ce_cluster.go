@@ -1,23 +1,110 @@
 package oci
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/containerengine"
 )
 
+// ClusterEndpoints describes the Kubernetes API endpoints of an OKE cluster, the SDK in use only knows the
+// public one
+type ClusterEndpoints struct {
+	Kubernetes      string `json:"kubernetes"`
+	PublicEndpoint  string `json:"publicEndpoint"`
+	PrivateEndpoint string `json:"privateEndpoint"`
+}
+
+// GetAPIEndpoint gives back the public endpoint if there is one, the private endpoint otherwise, private is set if
+// the private endpoint is given back; the endpoint is empty if neither is available
+func (e ClusterEndpoints) GetAPIEndpoint() (endpoint string, private bool) {
+
+	if e.PublicEndpoint != "" {
+		return e.PublicEndpoint, false
+	}
+	if e.Kubernetes != "" {
+		return e.Kubernetes, false
+	}
+	if e.PrivateEndpoint != "" {
+		return e.PrivateEndpoint, true
+	}
+
+	return "", false
+}
+
+type clusterEndpointsResponse struct {
+	LifecycleState string            `json:"lifecycleState"`
+	Endpoints      *ClusterEndpoints `json:"endpoints"`
+}
+
 // CreateCluster creates an OKE cluster specified in the request
-func (ce *ContainerEngine) CreateCluster(request containerengine.CreateClusterRequest) (clusterOCID string, err error) {
+func (ce *ContainerEngine) CreateCluster(ctx context.Context, request containerengine.CreateClusterRequest) (clusterOCID string, err error) {
+
+	response, err := ce.client.CreateCluster(ctx, request)
+	if err != nil {
+		return clusterOCID, err
+	}
+
+	return ce.waitUntilClusterCreated(ctx, response.OpcWorkRequestId)
+}
+
+// GetPrivateEndpointConfigDetails gives back the cluster details placing the Kubernetes API endpoint into the
+// given subnet without a public IP
+func GetPrivateEndpointConfigDetails(endpointSubnetID string) map[string]interface{} {
+
+	return map[string]interface{}{
+		"endpointConfig": map[string]interface{}{
+			"subnetId":          endpointSubnetID,
+			"isPublicIpEnabled": false,
+		},
+	}
+}
+
+// CreateClusterWithDetails creates an OKE cluster specified in the request extended with the given details, e.g.
+// endpoint configs and freeform tags, the SDK in use does not cover them so the request is sent directly
+func (ce *ContainerEngine) CreateClusterWithDetails(ctx context.Context, request containerengine.CreateClusterRequest, extraDetails map[string]interface{}) (clusterOCID string, err error) {
+
+	raw, err := json.Marshal(request.CreateClusterDetails)
+	if err != nil {
+		return clusterOCID, err
+	}
 
-	response, err := ce.client.CreateCluster(context.Background(), request)
+	details := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return clusterOCID, err
+	}
+	for key, value := range extraDetails {
+		details[key] = value
+	}
+
+	raw, err = json.Marshal(details)
 	if err != nil {
 		return clusterOCID, err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	httpRequest := common.MakeDefaultHTTPRequest("POST", "/clusters")
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.ContentLength = int64(len(raw))
+	httpRequest.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+	response, err := ce.client.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return clusterOCID, err
+	}
+
+	return ce.waitUntilClusterCreated(ctx, common.String(response.Header.Get("opc-work-request-id")))
+}
+
+// waitUntilClusterCreated waits for the work request creating a cluster and gives back the OCID of the cluster
+func (ce *ContainerEngine) waitUntilClusterCreated(ctx context.Context, workRequestID *string) (clusterOCID string, err error) {
+
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, workRequestID)
 	if err != nil {
 		return clusterOCID, err
 	}
@@ -34,14 +121,14 @@ func (ce *ContainerEngine) CreateCluster(request containerengine.CreateClusterRe
 }
 
 // UpdateCluster updates an OKE cluster specified in the request
-func (ce *ContainerEngine) UpdateCluster(request containerengine.UpdateClusterRequest) (clusterOCID string, err error) {
+func (ce *ContainerEngine) UpdateCluster(ctx context.Context, request containerengine.UpdateClusterRequest) (clusterOCID string, err error) {
 
-	response, err := ce.client.UpdateCluster(context.Background(), request)
+	response, err := ce.client.UpdateCluster(ctx, request)
 	if err != nil {
 		return clusterOCID, err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, response.OpcWorkRequestId)
 	if err != nil {
 		return clusterOCID, err
 	}
@@ -58,14 +145,14 @@ func (ce *ContainerEngine) UpdateCluster(request containerengine.UpdateClusterRe
 }
 
 // DeleteCluster removes an OKE cluster specified in the request
-func (ce *ContainerEngine) DeleteCluster(request containerengine.DeleteClusterRequest) (err error) {
+func (ce *ContainerEngine) DeleteCluster(ctx context.Context, request containerengine.DeleteClusterRequest) (err error) {
 
-	response, err := ce.client.DeleteCluster(context.Background(), request)
+	response, err := ce.client.DeleteCluster(ctx, request)
 	if err != nil {
 		return err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, response.OpcWorkRequestId)
 	if err != nil {
 		return err
 	}
@@ -78,9 +165,9 @@ func (ce *ContainerEngine) DeleteCluster(request containerengine.DeleteClusterRe
 }
 
 // GetCluster gets a Cluster by id
-func (ce *ContainerEngine) GetCluster(id *string) (cluster containerengine.Cluster, err error) {
+func (ce *ContainerEngine) GetCluster(ctx context.Context, id *string) (cluster containerengine.Cluster, err error) {
 
-	response, err := ce.client.GetCluster(context.Background(), containerengine.GetClusterRequest{
+	response, err := ce.client.GetCluster(ctx, containerengine.GetClusterRequest{
 		ClusterId: id,
 	})
 	if err != nil {
@@ -90,10 +177,33 @@ func (ce *ContainerEngine) GetCluster(id *string) (cluster containerengine.Clust
 	return response.Cluster, nil
 }
 
+// GetClusterEndpoints gets the Kubernetes API endpoints and the lifecycle state of a Cluster by id
+func (ce *ContainerEngine) GetClusterEndpoints(ctx context.Context, id string) (endpoints ClusterEndpoints, lifecycleState string, err error) {
+
+	request := common.MakeDefaultHTTPRequest("GET", fmt.Sprintf("/clusters/%s", id))
+
+	response, err := ce.client.Call(ctx, &request)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return endpoints, lifecycleState, err
+	}
+
+	var cluster clusterEndpointsResponse
+	if err := json.NewDecoder(response.Body).Decode(&cluster); err != nil {
+		return endpoints, lifecycleState, err
+	}
+
+	if cluster.Endpoints != nil {
+		endpoints = *cluster.Endpoints
+	}
+
+	return endpoints, cluster.LifecycleState, nil
+}
+
 // GetClusterByName gets a Cluster by name within a Compartment
-func (ce *ContainerEngine) GetClusterByName(name string) (cluster containerengine.ClusterSummary, err error) {
+func (ce *ContainerEngine) GetClusterByName(ctx context.Context, name string) (cluster containerengine.ClusterSummary, err error) {
 
-	clusters, err := ce.GetClustersByName(name)
+	clusters, err := ce.GetClustersByName(ctx, name)
 	if err != nil {
 		return cluster, err
 	}
@@ -106,14 +216,14 @@ func (ce *ContainerEngine) GetClusterByName(name string) (cluster containerengin
 }
 
 // GetClustersByName gets all Clusters by name within a Compartment
-func (ce *ContainerEngine) GetClustersByName(name string) (clusters []containerengine.ClusterSummary, err error) {
+func (ce *ContainerEngine) GetClustersByName(ctx context.Context, name string) (clusters []containerengine.ClusterSummary, err error) {
 
 	request := containerengine.ListClustersRequest{
 		CompartmentId: common.String(ce.CompartmentOCID),
 		Name:          common.String(name),
 	}
 
-	response, err := ce.client.ListClusters(context.Background(), request)
+	response, err := ce.client.ListClusters(ctx, request)
 	if err != nil {
 		return clusters, err
 	}
@@ -126,7 +236,7 @@ func (ce *ContainerEngine) GetClustersByName(name string) (clusters []containere
 }
 
 // GetClusters gets all Clusters within the Compartment
-func (ce *ContainerEngine) GetClusters() (clusters []containerengine.ClusterSummary, err error) {
+func (ce *ContainerEngine) GetClusters(ctx context.Context) (clusters []containerengine.ClusterSummary, err error) {
 
 	request := containerengine.ListClustersRequest{
 		CompartmentId: common.String(ce.CompartmentOCID),
@@ -134,7 +244,7 @@ func (ce *ContainerEngine) GetClusters() (clusters []containerengine.ClusterSumm
 	request.Limit = common.Int(20)
 
 	listFunc := func(request containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error) {
-		return ce.client.ListClusters(context.Background(), request)
+		return ce.client.ListClusters(ctx, request)
 	}
 
 	for r, err := listFunc(request); ; r, err = listFunc(request) {
@@ -159,22 +269,26 @@ func (ce *ContainerEngine) GetClusters() (clusters []containerengine.ClusterSumm
 }
 
 // WaitingForClusterNodePoolActiveState waits until every node in the pool is in ACTIVE state
-func (ce *ContainerEngine) WaitingForClusterNodePoolActiveState(clusterID *string) error {
+func (ce *ContainerEngine) WaitingForClusterNodePoolActiveState(ctx context.Context, clusterID *string) error {
 
 	ce.oci.logger.Info("Waiting for all nodepools state to be ACTIVE on all nodes")
 
 	for i := 0; i <= 60; i++ {
 
-		time.Sleep(time.Duration(20) * time.Second)
+		select {
+		case <-time.After(time.Duration(20) * time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 
-		nodePools, err := ce.GetNodePools(clusterID)
+		nodePools, err := ce.GetNodePools(ctx, clusterID)
 		if err != nil {
 			return err
 		}
 
 		ok := true
 		for _, np := range nodePools {
-			if !ce.IsNodePoolActive(np.Id) {
+			if !ce.IsNodePoolActive(ctx, np.Id) {
 				ok = false
 			}
 		}
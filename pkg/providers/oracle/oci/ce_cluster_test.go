@@ -0,0 +1,27 @@
+package oci
+
+import "testing"
+
+func TestClusterEndpointsGetAPIEndpoint(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		endpoints ClusterEndpoints
+		endpoint  string
+		private   bool
+	}{
+		{"none", ClusterEndpoints{}, "", false},
+		{"legacy public", ClusterEndpoints{Kubernetes: "public:6443"}, "public:6443", false},
+		{"public preferred", ClusterEndpoints{PublicEndpoint: "public:6443", PrivateEndpoint: "10.0.0.2:6443"}, "public:6443", false},
+		{"private fallback", ClusterEndpoints{PrivateEndpoint: "10.0.0.2:6443"}, "10.0.0.2:6443", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint, private := tc.endpoints.GetAPIEndpoint()
+			if endpoint != tc.endpoint || private != tc.private {
+				t.Errorf("Expected %q (private: %t), got %q (private: %t)", tc.endpoint, tc.private, endpoint, private)
+			}
+		})
+	}
+}
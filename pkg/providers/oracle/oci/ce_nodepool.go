@@ -1,24 +1,73 @@
 package oci
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/containerengine"
 )
 
-// CreateNodePool creates node pool specified in the request
-func (ce *ContainerEngine) CreateNodePool(request containerengine.CreateNodePoolRequest) (nodepoolOCID string, err error) {
+// NodeMetadataUserData is the node metadata key of the base64 encoded cloud-init user data of the nodes
+const NodeMetadataUserData = "user_data"
+
+// MaxNodeUserDataSize is the maximum size of the base64 encoded user data of the nodes in bytes
+const MaxNodeUserDataSize = 32000
 
-	ctx := context.Background()
+// CreateNodePool creates node pool specified in the request
+func (ce *ContainerEngine) CreateNodePool(ctx context.Context, request containerengine.CreateNodePoolRequest) (nodepoolOCID string, err error) {
 
 	response, err := ce.client.CreateNodePool(ctx, request)
 	if err != nil {
 		return nodepoolOCID, err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	return ce.waitUntilNodePoolCreated(ctx, response.OpcWorkRequestId)
+}
+
+// CreateNodePoolWithDetails creates node pool specified in the request extended with the given details, e.g. node
+// metadata and freeform tags, the SDK doesn't support them so the request is sent directly
+func (ce *ContainerEngine) CreateNodePoolWithDetails(ctx context.Context, request containerengine.CreateNodePoolRequest, extraDetails map[string]interface{}) (nodepoolOCID string, err error) {
+
+	raw, err := json.Marshal(request.CreateNodePoolDetails)
+	if err != nil {
+		return nodepoolOCID, err
+	}
+
+	details := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return nodepoolOCID, err
+	}
+	for key, value := range extraDetails {
+		details[key] = value
+	}
+
+	raw, err = json.Marshal(details)
+	if err != nil {
+		return nodepoolOCID, err
+	}
+
+	httpRequest := common.MakeDefaultHTTPRequest("POST", "/nodePools")
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.ContentLength = int64(len(raw))
+	httpRequest.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+	response, err := ce.client.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return nodepoolOCID, err
+	}
+
+	return ce.waitUntilNodePoolCreated(ctx, common.String(response.Header.Get("opc-work-request-id")))
+}
+
+// waitUntilNodePoolCreated waits for the work request creating a node pool and gives back the OCID of the node pool
+func (ce *ContainerEngine) waitUntilNodePoolCreated(ctx context.Context, workRequestID *string) (nodepoolOCID string, err error) {
+
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, workRequestID)
 	if err != nil {
 		return nodepoolOCID, err
 	}
@@ -35,14 +84,14 @@ func (ce *ContainerEngine) CreateNodePool(request containerengine.CreateNodePool
 }
 
 // UpdateNodePool updates a node pool specified in a request
-func (ce *ContainerEngine) UpdateNodePool(request containerengine.UpdateNodePoolRequest) (nodepoolOCID string, err error) {
+func (ce *ContainerEngine) UpdateNodePool(ctx context.Context, request containerengine.UpdateNodePoolRequest) (nodepoolOCID string, err error) {
 
-	response, err := ce.client.UpdateNodePool(context.Background(), request)
+	response, err := ce.client.UpdateNodePool(ctx, request)
 	if err != nil {
 		return nodepoolOCID, err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, response.OpcWorkRequestId)
 	if err != nil {
 		return nodepoolOCID, err
 	}
@@ -59,16 +108,16 @@ func (ce *ContainerEngine) UpdateNodePool(request containerengine.UpdateNodePool
 }
 
 // DeleteNodePool deletes a node pool by id
-func (ce *ContainerEngine) DeleteNodePool(id *string) error {
+func (ce *ContainerEngine) DeleteNodePool(ctx context.Context, id *string) error {
 
-	response, err := ce.client.DeleteNodePool(context.Background(), containerengine.DeleteNodePoolRequest{
+	response, err := ce.client.DeleteNodePool(ctx, containerengine.DeleteNodePoolRequest{
 		NodePoolId: id,
 	})
 	if err != nil {
 		return err
 	}
 
-	workReqResp, err := ce.waitUntilWorkRequestComplete(*ce.client, response.OpcWorkRequestId)
+	workReqResp, err := ce.waitUntilWorkRequestComplete(ctx, *ce.client, response.OpcWorkRequestId)
 	if err != nil {
 		return err
 	}
@@ -81,9 +130,9 @@ func (ce *ContainerEngine) DeleteNodePool(id *string) error {
 }
 
 // DeleteNodePoolByName deletes a node pool in a cluster by name
-func (ce *ContainerEngine) DeleteNodePoolByName(clusterID *string, name string) error {
+func (ce *ContainerEngine) DeleteNodePoolByName(ctx context.Context, clusterID *string, name string) error {
 
-	nodePool, err := ce.GetNodePoolByName(clusterID, name)
+	nodePool, err := ce.GetNodePoolByName(ctx, clusterID, name)
 	if err != nil {
 		return err
 	}
@@ -93,15 +142,15 @@ func (ce *ContainerEngine) DeleteNodePoolByName(clusterID *string, name string)
 	}
 
 	ce.oci.GetLogger().Infof("Deleting NodePool[%s]", *nodePool.Name)
-	ce.DeleteNodePool(nodePool.Id)
+	ce.DeleteNodePool(ctx, nodePool.Id)
 
 	return nil
 }
 
 // GetNodePool gets a Node Pool by id
-func (ce *ContainerEngine) GetNodePool(id *string) (nodepool containerengine.NodePool, err error) {
+func (ce *ContainerEngine) GetNodePool(ctx context.Context, id *string) (nodepool containerengine.NodePool, err error) {
 
-	response, err := ce.client.GetNodePool(context.Background(), containerengine.GetNodePoolRequest{
+	response, err := ce.client.GetNodePool(ctx, containerengine.GetNodePoolRequest{
 		NodePoolId: id,
 	})
 
@@ -113,7 +162,7 @@ func (ce *ContainerEngine) GetNodePool(id *string) (nodepool containerengine.Nod
 }
 
 // GetNodePoolByName gets a Node Pool by name within a Cluster
-func (ce *ContainerEngine) GetNodePoolByName(clusterID *string, name string) (nodepool containerengine.NodePoolSummary, err error) {
+func (ce *ContainerEngine) GetNodePoolByName(ctx context.Context, clusterID *string, name string) (nodepool containerengine.NodePoolSummary, err error) {
 
 	request := containerengine.ListNodePoolsRequest{
 		CompartmentId: common.String(ce.CompartmentOCID),
@@ -121,7 +170,7 @@ func (ce *ContainerEngine) GetNodePoolByName(clusterID *string, name string) (no
 		Name:          common.String(name),
 	}
 
-	response, err := ce.client.ListNodePools(context.Background(), request)
+	response, err := ce.client.ListNodePools(ctx, request)
 	if err != nil {
 		return nodepool, err
 	}
@@ -137,7 +186,7 @@ func (ce *ContainerEngine) GetNodePoolByName(clusterID *string, name string) (no
 }
 
 // GetNodePools gets all Node Pools within a Cluster
-func (ce *ContainerEngine) GetNodePools(clusterID *string) (nodepools []containerengine.NodePoolSummary, err error) {
+func (ce *ContainerEngine) GetNodePools(ctx context.Context, clusterID *string) (nodepools []containerengine.NodePoolSummary, err error) {
 
 	request := containerengine.ListNodePoolsRequest{
 		CompartmentId: common.String(ce.CompartmentOCID),
@@ -146,7 +195,7 @@ func (ce *ContainerEngine) GetNodePools(clusterID *string) (nodepools []containe
 	request.Limit = common.Int(20)
 
 	listFunc := func(request containerengine.ListNodePoolsRequest) (containerengine.ListNodePoolsResponse, error) {
-		return ce.client.ListNodePools(context.Background(), request)
+		return ce.client.ListNodePools(ctx, request)
 	}
 
 	for r, err := listFunc(request); ; r, err = listFunc(request) {
@@ -171,9 +220,9 @@ func (ce *ContainerEngine) GetNodePools(clusterID *string) (nodepools []containe
 }
 
 // IsNodePoolActive checks whether every node is in ACTIVE not DELETED state in a node pool
-func (ce *ContainerEngine) IsNodePoolActive(id *string) bool {
+func (ce *ContainerEngine) IsNodePoolActive(ctx context.Context, id *string) bool {
 
-	np, err := ce.GetNodePool(id)
+	np, err := ce.GetNodePool(ctx, id)
 	if err != nil {
 		return false
 	}
@@ -202,19 +251,19 @@ func (ce *ContainerEngine) IsNodePoolActive(id *string) bool {
 }
 
 // GetDefaultNodePoolOptions gets default node pool options
-func (ce *ContainerEngine) GetDefaultNodePoolOptions() (options NodePoolOptions, err error) {
+func (ce *ContainerEngine) GetDefaultNodePoolOptions(ctx context.Context) (options NodePoolOptions, err error) {
 
-	return ce.GetNodePoolOptions("all")
+	return ce.GetNodePoolOptions(ctx, "all")
 }
 
 // GetNodePoolOptions gets available node pool options for a specified cluster OCID
-func (ce *ContainerEngine) GetNodePoolOptions(clusterID string) (options NodePoolOptions, err error) {
+func (ce *ContainerEngine) GetNodePoolOptions(ctx context.Context, clusterID string) (options NodePoolOptions, err error) {
 
 	request := containerengine.GetNodePoolOptionsRequest{
 		NodePoolOptionId: &clusterID,
 	}
 
-	r, err := ce.client.GetNodePoolOptions(context.Background(), request)
+	r, err := ce.client.GetNodePoolOptions(ctx, request)
 
 	return NodePoolOptions{
 		Images:             Strings{strings: r.Images},
@@ -0,0 +1,297 @@
+package oci
+
+import (
+	"context"
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/core"
+)
+
+// Compute is for managing Compute related calls of OCI
+type Compute struct {
+	CompartmentOCID string
+
+	oci    *OCI
+	client *core.ComputeClient
+}
+
+// NewComputeClient creates a new Compute
+func (oci *OCI) NewComputeClient() (client *Compute, err error) {
+
+	client = &Compute{}
+
+	oClient, err := core.NewComputeClientWithConfigurationProvider(oci.config)
+	if err != nil {
+		return client, err
+	}
+
+	oci.configureClient(&oClient.BaseClient)
+
+	client.client = &oClient
+	client.oci = oci
+	client.CompartmentOCID = oci.CompartmentOCID
+
+	return client, nil
+}
+
+// GetImages gets all available Images within the Compartment
+func (c *Compute) GetImages(ctx context.Context) (images []core.Image, err error) {
+
+	request := core.ListImagesRequest{
+		CompartmentId:  common.String(c.CompartmentOCID),
+		LifecycleState: core.ImageLifecycleStateAvailable,
+	}
+	request.Limit = common.Int(20)
+
+	listFunc := func(request core.ListImagesRequest) (core.ListImagesResponse, error) {
+		return c.client.ListImages(ctx, request)
+	}
+
+	for response, err := listFunc(request); ; response, err = listFunc(request) {
+		if err != nil {
+			return images, err
+		}
+
+		for _, item := range response.Items {
+			images = append(images, item)
+		}
+
+		if response.OpcNextPage != nil {
+			// if there are more items in next page, fetch items from next page
+			request.Page = response.OpcNextPage
+		} else {
+			// no more result, break the loop
+			break
+		}
+	}
+
+	return images, err
+}
+
+// GetShapeNamesInAvailabilityDomain gets the names of the shapes which can be launched in the availability domain
+func (c *Compute) GetShapeNamesInAvailabilityDomain(ctx context.Context, availabilityDomain string) (names []string, err error) {
+
+	return c.listShapeNames(ctx, common.String(availabilityDomain))
+}
+
+// GetShapeNames gets the names of the shapes which can be launched in any availability domain of the region
+func (c *Compute) GetShapeNames(ctx context.Context) (names []string, err error) {
+
+	return c.listShapeNames(ctx, nil)
+}
+
+func (c *Compute) listShapeNames(ctx context.Context, availabilityDomain *string) (names []string, err error) {
+
+	names = make([]string, 0)
+	seen := make(map[string]bool)
+
+	request := core.ListShapesRequest{
+		CompartmentId:      common.String(c.CompartmentOCID),
+		AvailabilityDomain: availabilityDomain,
+	}
+
+	for {
+		response, err := c.client.ListShapes(ctx, request)
+		if err != nil {
+			return names, err
+		}
+
+		// shapes are listed once per compatible image
+		for _, shape := range response.Items {
+			if shape.Shape != nil && !seen[*shape.Shape] {
+				seen[*shape.Shape] = true
+				names = append(names, *shape.Shape)
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	return names, nil
+}
+
+// TerminateInstance terminates the instance with the given OCID and deletes its boot volume
+func (c *Compute) TerminateInstance(ctx context.Context, id string) error {
+
+	_, err := c.client.TerminateInstance(ctx, core.TerminateInstanceRequest{
+		InstanceId:         common.String(id),
+		PreserveBootVolume: common.Bool(false),
+	})
+
+	return err
+}
+
+// GetInstanceVolumeIDs gets the OCIDs of the boot volumes and of the block volumes attached to the instance
+func (c *Compute) GetInstanceVolumeIDs(ctx context.Context, instanceID, availabilityDomain string) (bootVolumeIDs []string, volumeIDs []string, err error) {
+
+	bootRequest := core.ListBootVolumeAttachmentsRequest{
+		AvailabilityDomain: common.String(availabilityDomain),
+		CompartmentId:      common.String(c.CompartmentOCID),
+		InstanceId:         common.String(instanceID),
+	}
+
+	for {
+		response, err := c.client.ListBootVolumeAttachments(ctx, bootRequest)
+		if err != nil {
+			return bootVolumeIDs, volumeIDs, err
+		}
+
+		for _, attachment := range response.Items {
+			if attachment.LifecycleState == core.BootVolumeAttachmentLifecycleStateAttached {
+				bootVolumeIDs = append(bootVolumeIDs, *attachment.BootVolumeId)
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		bootRequest.Page = response.OpcNextPage
+	}
+
+	request := core.ListVolumeAttachmentsRequest{
+		CompartmentId: common.String(c.CompartmentOCID),
+		InstanceId:    common.String(instanceID),
+	}
+
+	for {
+		response, err := c.client.ListVolumeAttachments(ctx, request)
+		if err != nil {
+			return bootVolumeIDs, volumeIDs, err
+		}
+
+		for _, attachment := range response.Items {
+			if attachment.GetLifecycleState() == core.VolumeAttachmentLifecycleStateAttached {
+				volumeIDs = append(volumeIDs, *attachment.GetVolumeId())
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	return bootVolumeIDs, volumeIDs, nil
+}
+
+// GetInstance gets the instance with the given OCID
+func (c *Compute) GetInstance(ctx context.Context, id string) (instance core.Instance, err error) {
+
+	response, err := c.client.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId: common.String(id),
+	})
+	if err != nil {
+		return instance, err
+	}
+
+	return response.Instance, nil
+}
+
+// GetInstancePrivateIPs gets the private IP addresses of the VNICs attached to the instance
+func (c *Compute) GetInstancePrivateIPs(ctx context.Context, instanceID string) (ips []string, err error) {
+
+	vn, err := c.oci.NewVirtualNetworkClient()
+	if err != nil {
+		return ips, err
+	}
+
+	request := core.ListVnicAttachmentsRequest{
+		CompartmentId: common.String(c.CompartmentOCID),
+		InstanceId:    common.String(instanceID),
+	}
+
+	for {
+		response, err := c.client.ListVnicAttachments(ctx, request)
+		if err != nil {
+			return ips, err
+		}
+
+		for _, attachment := range response.Items {
+			if attachment.LifecycleState != core.VnicAttachmentLifecycleStateAttached || attachment.VnicId == nil {
+				continue
+			}
+			vnic, err := vn.client.GetVnic(ctx, core.GetVnicRequest{
+				VnicId: attachment.VnicId,
+			})
+			if err != nil {
+				return ips, err
+			}
+			if vnic.PrivateIp != nil {
+				ips = append(ips, *vnic.PrivateIp)
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	return ips, nil
+}
+
+// GetInstanceFreeformTags gets the freeform tags of the instances within the Compartment keyed by instance OCID
+func (c *Compute) GetInstanceFreeformTags(ctx context.Context) (tags map[string]map[string]string, err error) {
+
+	tags = make(map[string]map[string]string)
+
+	request := core.ListInstancesRequest{
+		CompartmentId: common.String(c.CompartmentOCID),
+	}
+
+	listFunc := func(request core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+		return c.client.ListInstances(ctx, request)
+	}
+
+	for response, err := listFunc(request); ; response, err = listFunc(request) {
+		if err != nil {
+			return tags, err
+		}
+
+		for _, item := range response.Items {
+			if item.Id != nil {
+				tags[*item.Id] = item.FreeformTags
+			}
+		}
+
+		if response.OpcNextPage != nil {
+			request.Page = response.OpcNextPage
+		} else {
+			break
+		}
+	}
+
+	return tags, nil
+}
+
+// UpdateInstanceFreeformTags sets the given freeform tags on the instance having the current freeform tags, the
+// other tags of the instance are kept and the instance is only updated if a tag changes
+func (c *Compute) UpdateInstanceFreeformTags(ctx context.Context, id string, current, tags map[string]string) error {
+
+	freeformTags := make(map[string]string, len(current)+len(tags))
+	for k, v := range current {
+		freeformTags[k] = v
+	}
+
+	changed := false
+	for k, v := range tags {
+		if current, ok := freeformTags[k]; !ok || current != v {
+			freeformTags[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	_, err := c.client.UpdateInstance(ctx, core.UpdateInstanceRequest{
+		InstanceId: common.String(id),
+		UpdateInstanceDetails: core.UpdateInstanceDetails{
+			FreeformTags: freeformTags,
+		},
+	})
+
+	return err
+}
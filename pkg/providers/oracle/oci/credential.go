@@ -0,0 +1,127 @@
+package oci
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/identity"
+	"github.com/sirupsen/logrus"
+)
+
+// Fields of the credential named by CredentialError
+const (
+	CredentialFieldUserOCID          = "user OCID"
+	CredentialFieldTenancyOCID       = "tenancy OCID"
+	CredentialFieldAPIKey            = "API key"
+	CredentialFieldAPIKeyFingerprint = "API key fingerprint"
+	CredentialFieldRegion            = "region"
+)
+
+// credentialValidationTimeout is the timeout of the authenticated call made by ValidateOCICredential
+const credentialValidationTimeout = 30 * time.Second
+
+// ValidateOCICredential checks that the API key of the credential can be parsed and matches the fingerprint, then
+// makes an authenticated call to list the regions of the tenancy and checks that the region is one of them
+func ValidateOCICredential(credential *Credential) error {
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{CredentialFieldUserOCID, credential.UserOCID},
+		{CredentialFieldTenancyOCID, credential.TenancyOCID},
+		{CredentialFieldAPIKey, credential.APIKey},
+		{CredentialFieldAPIKeyFingerprint, credential.APIKeyFingerprint},
+		{CredentialFieldRegion, credential.Region},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return &CredentialError{Field: r.field, Reason: "it must be specified"}
+		}
+	}
+
+	fingerprint, err := getAPIKeyFingerprint(credential.APIKey, credential.Password)
+	if err != nil {
+		return &CredentialError{Field: CredentialFieldAPIKey, Reason: err.Error()}
+	}
+
+	if !strings.EqualFold(fingerprint, strings.TrimSpace(credential.APIKeyFingerprint)) {
+		return &CredentialError{Field: CredentialFieldAPIKeyFingerprint, Reason: fmt.Sprintf("it does not match the fingerprint of the API key: %s", fingerprint)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialValidationTimeout)
+	defer cancel()
+
+	oci := &OCI{
+		config:      common.NewRawConfigurationProvider(credential.TenancyOCID, credential.UserOCID, credential.Region, credential.APIKeyFingerprint, credential.APIKey, common.String(credential.Password)),
+		logger:      logrus.New(),
+		credential:  credential,
+		retryPolicy: NoRetryPolicy(),
+	}
+
+	i, err := oci.NewIdentityClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := i.client.ListRegionSubscriptions(ctx, identity.ListRegionSubscriptionsRequest{
+		TenancyId: common.String(credential.TenancyOCID),
+	})
+	if err != nil {
+		serviceErr, ok := common.IsServiceError(err)
+		if !ok {
+			return &CredentialError{Field: CredentialFieldRegion, Reason: fmt.Sprintf("the identity service of region %s is not reachable: %s", credential.Region, err.Error())}
+		}
+
+		switch serviceErr.GetHTTPStatusCode() {
+		case http.StatusUnauthorized:
+			return &CredentialError{Field: CredentialFieldUserOCID, Reason: "authentication failed, the API key is not added to the user or the user is not in the tenancy"}
+		case http.StatusNotFound:
+			return &CredentialError{Field: CredentialFieldTenancyOCID, Reason: "the tenancy is not found or the user is not authorized to read its regions"}
+		}
+
+		return err
+	}
+
+	for _, region := range response.Items {
+		if region.RegionName != nil && *region.RegionName == credential.Region {
+			return nil
+		}
+	}
+
+	return &CredentialError{Field: CredentialFieldRegion, Reason: fmt.Sprintf("the tenancy is not subscribed to region %s", credential.Region)}
+}
+
+// getAPIKeyFingerprint gives back the fingerprint of the public key of the PEM encoded private key in the format
+// used by OCI: the MD5 hash of the DER encoded public key as colon separated hex bytes
+func getAPIKeyFingerprint(apiKey, password string) (string, error) {
+
+	var passphrase *string
+	if password != "" {
+		passphrase = common.String(password)
+	}
+
+	key, err := common.PrivateKeyFromBytes([]byte(apiKey), passphrase)
+	if err != nil {
+		return "", fmt.Errorf("it cannot be parsed as a PEM encoded RSA private key: %s", err.Error())
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	sum := md5.Sum(der)
+	bytes := make([]string, 0, len(sum))
+	for _, b := range sum {
+		bytes = append(bytes, fmt.Sprintf("%02x", b))
+	}
+
+	return strings.Join(bytes, ":"), nil
+}
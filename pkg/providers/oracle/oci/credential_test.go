@@ -0,0 +1,57 @@
+package oci
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestValidateOCICredential(t *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	fingerprint, err := getAPIKeyFingerprint(apiKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := Credential{
+		UserOCID:          "ocid1.user.oc1..user",
+		TenancyOCID:       "ocid1.tenancy.oc1..tenancy",
+		APIKey:            apiKey,
+		APIKeyFingerprint: fingerprint,
+		Region:            "us-phoenix-1",
+	}
+
+	cases := []struct {
+		name   string
+		modify func(c *Credential)
+		field  string
+	}{
+		{"missing user", func(c *Credential) { c.UserOCID = "" }, CredentialFieldUserOCID},
+		{"missing region", func(c *Credential) { c.Region = " " }, CredentialFieldRegion},
+		{"invalid API key", func(c *Credential) { c.APIKey = "key" }, CredentialFieldAPIKey},
+		{"other fingerprint", func(c *Credential) { c.APIKeyFingerprint = "00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff" }, CredentialFieldAPIKeyFingerprint},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			credential := valid
+			tc.modify(&credential)
+
+			err := ValidateOCICredential(&credential)
+			if !IsCredentialError(err) {
+				t.Fatalf("Expected credential error, got: %v", err)
+			}
+			if field := err.(*CredentialError).Field; field != tc.field {
+				t.Errorf("Expected invalid %s, got: %s", tc.field, field)
+			}
+		})
+	}
+}
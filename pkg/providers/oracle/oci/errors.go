@@ -1,6 +1,12 @@
 package oci
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
 
 // EntityNotFoundError specific error for not found entities
 type EntityNotFoundError struct {
@@ -17,3 +23,212 @@ func IsEntityNotFoundError(err error) (ok bool) {
 	_, ok = err.(*EntityNotFoundError)
 	return ok
 }
+
+// VCNAccessError is returned when a VCN is not reachable with a credential or it is outside of the
+// compartment the credential is allowed to use
+type VCNAccessError struct {
+	VCNID           string
+	CompartmentOCID string
+	Reason          string
+}
+
+func (e *VCNAccessError) Error() string {
+	return fmt.Sprintf("VCN %s is not accessible in compartment %s: %s", e.VCNID, e.CompartmentOCID, e.Reason)
+}
+
+// IsVCNAccessError returns false if the error is not VCNAccessError, otherwise true
+func IsVCNAccessError(err error) (ok bool) {
+	_, ok = err.(*VCNAccessError)
+	return ok
+}
+
+// VCNNameConflictError is returned when a VCN with the name of a new preconfigured VCN already exists
+type VCNNameConflictError struct {
+	Name          string
+	VCNID         string
+	PipelineOwned bool
+}
+
+func (e *VCNNameConflictError) Error() string {
+	if e.PipelineOwned {
+		return fmt.Sprintf("a VCN created by pipeline already exists with name %s which cannot be reused: %s", e.Name, e.VCNID)
+	}
+	return fmt.Sprintf("a VCN not created by pipeline already exists with name %s: %s", e.Name, e.VCNID)
+}
+
+// IsVCNNameConflictError returns false if the error is not VCNNameConflictError, otherwise true
+func IsVCNNameConflictError(err error) (ok bool) {
+	_, ok = err.(*VCNNameConflictError)
+	return ok
+}
+
+// CompartmentQuotaExceededError is returned when the requested resources exceed a quota or limit of the compartment
+type CompartmentQuotaExceededError struct {
+	Service            string
+	Quota              string
+	AvailabilityDomain string
+	Required           int64
+	Available          int64
+}
+
+func (e *CompartmentQuotaExceededError) Error() string {
+	return fmt.Sprintf("%s quota %s is exceeded in %s: %d required, %d available", e.Service, e.Quota, e.AvailabilityDomain, e.Required, e.Available)
+}
+
+// IsCompartmentQuotaExceededError returns false if the error is not CompartmentQuotaExceededError, otherwise true
+func IsCompartmentQuotaExceededError(err error) (ok bool) {
+	_, ok = err.(*CompartmentQuotaExceededError)
+	return ok
+}
+
+// NotEnoughWorkerSubnetsError is returned when a VCN has fewer worker node subnets than the node pools are spread across
+type NotEnoughWorkerSubnetsError struct {
+	Required  int
+	Available int
+}
+
+func (e *NotEnoughWorkerSubnetsError) Error() string {
+	return fmt.Sprintf("not enough worker node subnets in the VCN: %d required, %d available", e.Required, e.Available)
+}
+
+// IsNotEnoughWorkerSubnetsError returns false if the error is not NotEnoughWorkerSubnetsError, otherwise true
+func IsNotEnoughWorkerSubnetsError(err error) (ok bool) {
+	_, ok = err.(*NotEnoughWorkerSubnetsError)
+	return ok
+}
+
+// ShapeNotAvailableError is returned when a node shape is not offered in the region or in the availability domain of
+// a node pool subnet
+type ShapeNotAvailableError struct {
+	NodePool           string
+	Shape              string
+	Region             string
+	AvailabilityDomain string
+}
+
+func (e *ShapeNotAvailableError) Error() string {
+	if e.AvailabilityDomain == "" {
+		return fmt.Sprintf("shape %s of node pool %s is not available in region %s", e.Shape, e.NodePool, e.Region)
+	}
+	return fmt.Sprintf("shape %s of node pool %s is not available in %s", e.Shape, e.NodePool, e.AvailabilityDomain)
+}
+
+// IsShapeNotAvailableError returns false if the error is not ShapeNotAvailableError, otherwise true
+func IsShapeNotAvailableError(err error) (ok bool) {
+	_, ok = err.(*ShapeNotAvailableError)
+	return ok
+}
+
+// IsNotAuthorizedError returns true if the error is an OCI service error caused by a missing authorization
+func IsNotAuthorizedError(err error) bool {
+
+	failure, ok := common.IsServiceError(err)
+	if !ok {
+		return false
+	}
+
+	switch failure.GetCode() {
+	case "NotAuthorized", "NotAuthorizedOrNotFound", "NotAuthorizedOrResourceAlreadyExists":
+		return true
+	}
+
+	return false
+}
+
+// APIEndpointNotReadyError is returned when the Kubernetes API endpoint of a cluster is not published yet, Waited
+// is set if it is not published in time
+type APIEndpointNotReadyError struct {
+	ClusterOCID string
+	Waited      time.Duration
+}
+
+func (e *APIEndpointNotReadyError) Error() string {
+	if e.Waited == 0 {
+		return fmt.Sprintf("Kubernetes API endpoint of cluster %s is not ready yet", e.ClusterOCID)
+	}
+	return fmt.Sprintf("Kubernetes API endpoint of cluster %s is not ready after %s", e.ClusterOCID, e.Waited)
+}
+
+// IsAPIEndpointNotReadyError returns false if the error is not APIEndpointNotReadyError, otherwise true
+func IsAPIEndpointNotReadyError(err error) (ok bool) {
+	_, ok = err.(*APIEndpointNotReadyError)
+	return ok
+}
+
+// APIEndpointNotAvailableError is returned when an ACTIVE cluster has neither a public nor a private Kubernetes
+// API endpoint
+type APIEndpointNotAvailableError struct {
+	ClusterOCID string
+}
+
+func (e *APIEndpointNotAvailableError) Error() string {
+	return fmt.Sprintf("cluster %s is ACTIVE but has neither public nor private Kubernetes API endpoint", e.ClusterOCID)
+}
+
+// IsAPIEndpointNotAvailableError returns false if the error is not APIEndpointNotAvailableError, otherwise true
+func IsAPIEndpointNotAvailableError(err error) (ok bool) {
+	_, ok = err.(*APIEndpointNotAvailableError)
+	return ok
+}
+
+// RegionNotSubscribedError is returned when the region is not valid or the tenancy is not subscribed to it
+type RegionNotSubscribedError struct {
+	Region            string
+	SubscribedRegions []string
+}
+
+func (e *RegionNotSubscribedError) Error() string {
+	return fmt.Sprintf("region '%s' is not available, the tenancy is subscribed to: %s", e.Region, strings.Join(e.SubscribedRegions, ", "))
+}
+
+// IsInvalid marks the error as caused by an invalid request
+func (e *RegionNotSubscribedError) IsInvalid() bool {
+	return true
+}
+
+// IsRegionNotSubscribedError returns false if the error is not RegionNotSubscribedError, otherwise true
+func IsRegionNotSubscribedError(err error) (ok bool) {
+	_, ok = err.(*RegionNotSubscribedError)
+	return ok
+}
+
+// BucketAlreadyExistsError is returned when a bucket with the name of a new bucket already exists in the namespace,
+// Owned is set if it is in the compartment of the credential
+type BucketAlreadyExistsError struct {
+	Name  string
+	Owned bool
+}
+
+func (e *BucketAlreadyExistsError) Error() string {
+	if e.Owned {
+		return fmt.Sprintf("bucket %s already exists in the compartment", e.Name)
+	}
+	return fmt.Sprintf("bucket %s already exists in the namespace and is not owned by the compartment", e.Name)
+}
+
+// AlreadyExists marks the error as an already existing bucket error for the object store API
+func (e *BucketAlreadyExistsError) AlreadyExists() bool {
+	return true
+}
+
+// IsBucketAlreadyExistsError returns false if the error is not BucketAlreadyExistsError, otherwise true
+func IsBucketAlreadyExistsError(err error) (ok bool) {
+	_, ok = err.(*BucketAlreadyExistsError)
+	return ok
+}
+
+// CredentialError is returned when a field of an OCI credential is missing or wrong
+type CredentialError struct {
+	Field  string
+	Reason string
+}
+
+func (e *CredentialError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// IsCredentialError returns false if the error is not CredentialError, otherwise true
+func IsCredentialError(err error) (ok bool) {
+	_, ok = err.(*CredentialError)
+	return ok
+}
@@ -29,3 +29,13 @@ func (s Strings) Get() []string {
 
 	return s.strings
 }
+
+// stringValue returns the value of the string pointer or an empty string if it is nil
+func stringValue(s *string) string {
+
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -24,6 +24,8 @@ func (oci *OCI) NewIdentityClient() (client *Identity, err error) {
 		return client, err
 	}
 
+	oci.configureClient(&oClient.BaseClient)
+
 	client.client = &oClient
 	client.oci = oci
 
@@ -0,0 +1,125 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NodeImage describes an OKE node image
+type NodeImage struct {
+	Name                   string `json:"name"`
+	OperatingSystem        string `json:"operatingSystem"`
+	OperatingSystemVersion string `json:"operatingSystemVersion"`
+	OCID                   string `json:"ocid"`
+}
+
+// ListNodePoolImages gives back the available OKE node images for the given k8s version in the current region
+func (oci *OCI) ListNodePoolImages(k8sVersion string) (images []NodeImage, err error) {
+
+	ce, err := oci.NewContainerEngineClient()
+	if err != nil {
+		return images, err
+	}
+
+	options, err := ce.GetDefaultNodePoolOptions(context.Background())
+	if err != nil {
+		return images, err
+	}
+
+	if k8sVersion != "" && !options.KubernetesVersions.Has(k8sVersion) {
+		return images, fmt.Errorf("Invalid k8s version: %s", k8sVersion)
+	}
+
+	c, err := oci.NewComputeClient()
+	if err != nil {
+		return images, err
+	}
+
+	computeImages, err := c.GetImages(context.Background())
+	if err != nil {
+		return images, err
+	}
+
+	for _, name := range options.Images.Get() {
+		image := NodeImage{
+			Name: name,
+		}
+		// compute images are ordered by creation time, the first match is the latest one
+		for _, ci := range computeImages {
+			if getNodeImageName(*ci.OperatingSystem, *ci.OperatingSystemVersion) == name {
+				image.OperatingSystem = *ci.OperatingSystem
+				image.OperatingSystemVersion = *ci.OperatingSystemVersion
+				image.OCID = *ci.Id
+				break
+			}
+		}
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+// ResolveNodeImage gives back the OKE node image matching the given identifier,
+// which can be an image name, an image OCID or an '<operating system> <version>' pair
+func ResolveNodeImage(images []NodeImage, identifier string) (image NodeImage, err error) {
+
+	for _, image := range images {
+		if image.Name == identifier || (image.OCID != "" && image.OCID == identifier) {
+			return image, nil
+		}
+		if image.OperatingSystem != "" && fmt.Sprintf("%s %s", image.OperatingSystem, image.OperatingSystemVersion) == identifier {
+			return image, nil
+		}
+	}
+
+	return image, &EntityNotFoundError{
+		Type: "Node Image",
+		Id:   identifier,
+	}
+}
+
+// LatestNodeImage gives back the image with the highest version of the operating system of the given OKE image name,
+// the given image itself does not have to be available anymore
+func LatestNodeImage(images []NodeImage, imageName string) (latest NodeImage, found bool) {
+
+	for _, image := range images {
+		if image.OperatingSystem == "" || !strings.HasPrefix(imageName, getNodeImageName(image.OperatingSystem, "")) {
+			continue
+		}
+		if !found || compareOSVersions(image.OperatingSystemVersion, latest.OperatingSystemVersion) > 0 {
+			latest = image
+			found = true
+		}
+	}
+
+	return latest, found
+}
+
+// compareOSVersions compares dot separated numeric versions, it gives back a negative number if a is lower,
+// a positive number if a is higher and 0 if they are equal
+func compareOSVersions(a, b string) int {
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+
+	return 0
+}
+
+// getNodeImageName creates an OKE image name (e.g. Oracle-Linux-7.4) from operating system name and version
+func getNodeImageName(operatingSystem, version string) string {
+
+	return fmt.Sprintf("%s-%s", strings.Replace(operatingSystem, " ", "-", -1), version)
+}
@@ -0,0 +1,33 @@
+package oci
+
+import "testing"
+
+func TestLatestNodeImage(t *testing.T) {
+
+	images := []NodeImage{
+		{Name: "Oracle-Linux-7.4", OperatingSystem: "Oracle Linux", OperatingSystemVersion: "7.4", OCID: "ocid1.image.oc1..a"},
+		{Name: "Oracle-Linux-7.10", OperatingSystem: "Oracle Linux", OperatingSystemVersion: "7.10", OCID: "ocid1.image.oc1..c"},
+		{Name: "Oracle-Linux-7.5", OperatingSystem: "Oracle Linux", OperatingSystemVersion: "7.5", OCID: "ocid1.image.oc1..b"},
+		{Name: "Canonical-Ubuntu-16.04", OperatingSystem: "Canonical Ubuntu", OperatingSystemVersion: "16.04", OCID: "ocid1.image.oc1..d"},
+	}
+
+	cases := []struct {
+		image string
+		name  string
+		found bool
+	}{
+		{"Oracle-Linux-7.4", "Oracle-Linux-7.10", true},
+		{"Oracle-Linux-7.3", "Oracle-Linux-7.10", true},
+		{"Canonical-Ubuntu-16.04", "Canonical-Ubuntu-16.04", true},
+		{"Windows-2016", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			image, found := LatestNodeImage(images, tc.image)
+			if found != tc.found || image.Name != tc.name {
+				t.Errorf("Expected: %s %v, got: %s %v", tc.name, tc.found, image.Name, found)
+			}
+		})
+	}
+}
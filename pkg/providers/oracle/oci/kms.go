@@ -0,0 +1,69 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// KMS is for managing the Key Management calls of a vault which are not covered by the SDK, the calls are
+// made to the management endpoint of the vault
+type KMS struct {
+	CompartmentOCID string
+
+	oci    *OCI
+	client common.BaseClient
+}
+
+// KMSKey describes a master encryption key of a vault
+type KMSKey struct {
+	ID             string `json:"id"`
+	CompartmentID  string `json:"compartmentId"`
+	DisplayName    string `json:"displayName"`
+	LifecycleState string `json:"lifecycleState"`
+}
+
+// KMSKeyLifecycleStateEnabled is the lifecycle state of the keys which can be used for encryption
+const KMSKeyLifecycleStateEnabled = "ENABLED"
+
+const kmsBasePath = "20180608"
+
+// NewKMSClient creates a new KMS for the vault with the given management endpoint
+func (oci *OCI) NewKMSClient(managementEndpoint string) (client *KMS, err error) {
+
+	client = &KMS{}
+
+	oClient, err := common.NewClientWithConfig(oci.config)
+	if err != nil {
+		return client, err
+	}
+
+	oClient.Host = managementEndpoint
+	oClient.BasePath = kmsBasePath
+
+	oci.configureClient(&oClient)
+
+	client.client = oClient
+	client.oci = oci
+	client.CompartmentOCID = oci.CompartmentOCID
+
+	return client, nil
+}
+
+// GetKey gets a key of the vault by id
+func (k *KMS) GetKey(id string) (key KMSKey, err error) {
+
+	request := common.MakeDefaultHTTPRequest("GET", fmt.Sprintf("/keys/%s", id))
+
+	response, err := k.client.Call(context.Background(), &request)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return key, err
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&key)
+
+	return key, err
+}
@@ -0,0 +1,118 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// Limits is for managing the Limits service calls of OCI which is not covered by the SDK. The resource availability
+// of a compartment reflects both the service limits and the quotas of the compartment.
+type Limits struct {
+	CompartmentOCID string
+
+	oci    *OCI
+	client common.BaseClient
+}
+
+// ResourceAvailability describes the usage and availability of a limited resource in a compartment,
+// Available is nil if the resource is not limited
+type ResourceAvailability struct {
+	Used      *int64 `json:"used"`
+	Available *int64 `json:"available"`
+}
+
+// Service names and limit names of the Limits service
+const (
+	LimitsServiceCompute      = "compute"
+	LimitsServiceBlockStorage = "block-storage"
+
+	LimitTotalStorageGB = "total-storage-gb"
+)
+
+const (
+	limitsHostTemplate = "https://limits.%s.oci.oraclecloud.com"
+	limitsBasePath     = "20190729"
+)
+
+var shapeRegexp = regexp.MustCompile(`^(?:VM|BM)\.(Standard1|Standard2|Standard\.E2|DenseIO1|DenseIO2|HighIO1)\.(\d+)$`)
+
+var shapeCoreLimitNames = map[string]string{
+	"Standard1":   "standard1-core-count",
+	"Standard2":   "standard2-core-count",
+	"Standard.E2": "standard-e2-core-count",
+	"DenseIO1":    "dense-io1-core-count",
+	"DenseIO2":    "dense-io2-core-count",
+	"HighIO1":     "high-io1-core-count",
+}
+
+// NewLimitsClient creates a new Limits
+func (oci *OCI) NewLimitsClient() (client *Limits, err error) {
+
+	client = &Limits{}
+
+	oClient, err := common.NewClientWithConfig(oci.config)
+	if err != nil {
+		return client, err
+	}
+
+	region, err := oci.config.Region()
+	if err != nil {
+		return client, err
+	}
+
+	oClient.Host = fmt.Sprintf(limitsHostTemplate, region)
+	oClient.BasePath = limitsBasePath
+
+	oci.configureClient(&oClient)
+
+	client.client = oClient
+	client.oci = oci
+	client.CompartmentOCID = oci.CompartmentOCID
+
+	return client, nil
+}
+
+// GetResourceAvailability gets the availability of the given limited resource in the Compartment,
+// availabilityDomain must be set for AD scoped limits
+func (l *Limits) GetResourceAvailability(serviceName, limitName, availabilityDomain string) (availability ResourceAvailability, err error) {
+
+	request := common.MakeDefaultHTTPRequest("GET", fmt.Sprintf("/services/%s/limits/%s/resourceAvailability", serviceName, limitName))
+
+	query := request.URL.Query()
+	query.Set("compartmentId", l.CompartmentOCID)
+	if availabilityDomain != "" {
+		query.Set("availabilityDomain", availabilityDomain)
+	}
+	request.URL.RawQuery = query.Encode()
+
+	response, err := l.client.Call(context.Background(), &request)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		return availability, err
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&availability)
+
+	return availability, err
+}
+
+// GetShapeCoreLimit gives back the name of the compute limit of the shape's cores and the number of cores of the shape
+func GetShapeCoreLimit(shape string) (limitName string, cores int64, ok bool) {
+
+	matches := shapeRegexp.FindStringSubmatch(shape)
+	if matches == nil {
+		return "", 0, false
+	}
+
+	cores, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return shapeCoreLimitNames[matches[1]], cores, true
+}
@@ -0,0 +1,30 @@
+package oci
+
+import "testing"
+
+func TestGetShapeCoreLimit(t *testing.T) {
+
+	cases := []struct {
+		shape     string
+		limitName string
+		cores     int64
+		ok        bool
+	}{
+		{"VM.Standard1.1", "standard1-core-count", 1, true},
+		{"VM.Standard2.8", "standard2-core-count", 8, true},
+		{"BM.Standard2.52", "standard2-core-count", 52, true},
+		{"VM.Standard.E2.4", "standard-e2-core-count", 4, true},
+		{"VM.DenseIO2.16", "dense-io2-core-count", 16, true},
+		{"VM.GPU2.1", "", 0, false},
+		{"invalid", "", 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.shape, func(t *testing.T) {
+			limitName, cores, ok := GetShapeCoreLimit(tc.shape)
+			if ok != tc.ok || limitName != tc.limitName || cores != tc.cores {
+				t.Errorf("Expected: %s %d %v, got: %s %d %v", tc.limitName, tc.cores, tc.ok, limitName, cores, ok)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package oci
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/objectstorage"
@@ -17,6 +18,13 @@ type ObjectStorage struct {
 	client *objectstorage.ObjectStorageClient
 }
 
+// Statuses of a bucket name in the namespace of the tenancy
+const (
+	BucketAvailable = "Available" // there is no bucket with the name
+	BucketOwned     = "Owned"     // the bucket exists in the compartment of the credential
+	BucketNotOwned  = "NotOwned"  // the bucket exists in another compartment
+)
+
 // NewObjectStorageClient creates new ObjectStorage
 func (oci *OCI) NewObjectStorageClient() (client *ObjectStorage, err error) {
 
@@ -27,6 +35,8 @@ func (oci *OCI) NewObjectStorageClient() (client *ObjectStorage, err error) {
 		return client, err
 	}
 
+	oci.configureClient(&oClient.BaseClient)
+
 	client.client = &oClient
 
 	r, err := oClient.GetNamespace(context.Background(), objectstorage.GetNamespaceRequest{})
@@ -53,6 +63,9 @@ func (os *ObjectStorage) CreateBucket(name string) (bucket objectstorage.Bucket,
 		},
 	})
 	if err != nil {
+		if isServiceErrorStatus(err, http.StatusConflict) {
+			return bucket, &BucketAlreadyExistsError{Name: name}
+		}
 		return bucket, err
 	}
 
@@ -90,6 +103,36 @@ func (os *ObjectStorage) GetBucket(name string) (bucket objectstorage.Bucket, er
 	return response.Bucket, nil
 }
 
+// CheckBucket gives back whether a bucket with the given name can be created, exists in the compartment or exists
+// in another compartment of the namespace, bucket names are unique in the namespace
+func (os *ObjectStorage) CheckBucket(name string) (status string, err error) {
+
+	response, err := os.client.GetBucket(context.Background(), objectstorage.GetBucketRequest{
+		NamespaceName: &os.Namespace,
+		BucketName:    &name,
+	})
+	if err != nil {
+		// buckets which the user is not authorized to read are reported as missing too
+		if isServiceErrorStatus(err, http.StatusNotFound) {
+			return BucketAvailable, nil
+		}
+		return "", err
+	}
+
+	if response.CompartmentId != nil && *response.CompartmentId == os.CompartmentOCID {
+		return BucketOwned, nil
+	}
+
+	return BucketNotOwned, nil
+}
+
+func isServiceErrorStatus(err error, statusCode int) bool {
+
+	serviceErr, ok := common.IsServiceError(err)
+
+	return ok && serviceErr.GetHTTPStatusCode() == statusCode
+}
+
 // GetBuckets gets an Object Storage buckets
 func (os *ObjectStorage) GetBuckets() (buckets []objectstorage.BucketSummary, err error) {
 
@@ -0,0 +1,137 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// Actions of the object lifecycle rules
+const (
+	ObjectLifecycleActionArchive = "ARCHIVE"
+	ObjectLifecycleActionDelete  = "DELETE"
+)
+
+// Time units of the object lifecycle rules
+const (
+	ObjectLifecycleTimeUnitDays  = "DAYS"
+	ObjectLifecycleTimeUnitYears = "YEARS"
+)
+
+// ObjectLifecycleRule describes a rule of the lifecycle policy of a bucket: the objects matching the prefixes are
+// archived or deleted after the given time, the SDK in use does not cover object lifecycle policies
+type ObjectLifecycleRule struct {
+	Name             string                 `json:"name"`
+	Action           string                 `json:"action"`
+	TimeAmount       int64                  `json:"timeAmount"`
+	TimeUnit         string                 `json:"timeUnit"`
+	IsEnabled        bool                   `json:"isEnabled"`
+	ObjectNameFilter *ObjectLifecycleFilter `json:"objectNameFilter,omitempty"`
+}
+
+// ObjectLifecycleFilter limits a lifecycle rule to the objects with the given name prefixes
+type ObjectLifecycleFilter struct {
+	InclusionPrefixes []string `json:"inclusionPrefixes,omitempty"`
+}
+
+type objectLifecyclePolicy struct {
+	Items []ObjectLifecycleRule `json:"items"`
+}
+
+// ValidateObjectLifecycleRules checks the names, actions and time periods of the lifecycle rules
+func ValidateObjectLifecycleRules(rules []ObjectLifecycleRule) error {
+
+	names := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("lifecycle rule name must be specified")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicated lifecycle rule: %s", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Action != ObjectLifecycleActionArchive && rule.Action != ObjectLifecycleActionDelete {
+			return fmt.Errorf("invalid action of lifecycle rule %s: %s", rule.Name, rule.Action)
+		}
+		if rule.TimeUnit != ObjectLifecycleTimeUnitDays && rule.TimeUnit != ObjectLifecycleTimeUnitYears {
+			return fmt.Errorf("invalid time unit of lifecycle rule %s: %s", rule.Name, rule.TimeUnit)
+		}
+		if rule.TimeAmount <= 0 {
+			return fmt.Errorf("time amount of lifecycle rule %s must be positive", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+// GetObjectLifecycleRules gets the rules of the lifecycle policy of the bucket, no rules are given back if the
+// bucket has no lifecycle policy
+func (os *ObjectStorage) GetObjectLifecycleRules(bucketName string) (rules []ObjectLifecycleRule, err error) {
+
+	request := common.MakeDefaultHTTPRequest("GET", os.getLifecyclePolicyPath(bucketName))
+
+	response, err := os.client.Call(context.Background(), &request)
+	defer common.CloseBodyIfValid(response)
+	if err != nil {
+		if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == http.StatusNotFound {
+			return []ObjectLifecycleRule{}, nil
+		}
+		return nil, err
+	}
+
+	var policy objectLifecyclePolicy
+	if err := json.NewDecoder(response.Body).Decode(&policy); err != nil {
+		return nil, err
+	}
+
+	return policy.Items, nil
+}
+
+// PutObjectLifecycleRules replaces the lifecycle policy of the bucket with the given rules, the policy is deleted
+// if there are no rules. Archiving requires a policy which lets the Object Storage service manage the objects.
+func (os *ObjectStorage) PutObjectLifecycleRules(bucketName string, rules []ObjectLifecycleRule) error {
+
+	if len(rules) == 0 {
+		return os.DeleteObjectLifecyclePolicy(bucketName)
+	}
+
+	raw, err := json.Marshal(objectLifecyclePolicy{Items: rules})
+	if err != nil {
+		return err
+	}
+
+	request := common.MakeDefaultHTTPRequest("PUT", os.getLifecyclePolicyPath(bucketName))
+	request.Header.Set("Content-Type", "application/json")
+	request.ContentLength = int64(len(raw))
+	request.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+	response, err := os.client.Call(context.Background(), &request)
+	defer common.CloseBodyIfValid(response)
+
+	return err
+}
+
+// DeleteObjectLifecyclePolicy deletes the lifecycle policy of the bucket
+func (os *ObjectStorage) DeleteObjectLifecyclePolicy(bucketName string) error {
+
+	request := common.MakeDefaultHTTPRequest("DELETE", os.getLifecyclePolicyPath(bucketName))
+
+	response, err := os.client.Call(context.Background(), &request)
+	defer common.CloseBodyIfValid(response)
+	if serviceErr, ok := common.IsServiceError(err); ok && serviceErr.GetHTTPStatusCode() == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (os *ObjectStorage) getLifecyclePolicyPath(bucketName string) string {
+
+	return fmt.Sprintf("/n/%s/b/%s/l", os.Namespace, bucketName)
+}
@@ -0,0 +1,35 @@
+package oci
+
+import "testing"
+
+func TestValidateObjectLifecycleRules(t *testing.T) {
+
+	archive := ObjectLifecycleRule{Name: "archive", Action: ObjectLifecycleActionArchive, TimeAmount: 30, TimeUnit: ObjectLifecycleTimeUnitDays, IsEnabled: true}
+	expire := ObjectLifecycleRule{Name: "expire", Action: ObjectLifecycleActionDelete, TimeAmount: 1, TimeUnit: ObjectLifecycleTimeUnitYears}
+
+	cases := []struct {
+		name  string
+		rules []ObjectLifecycleRule
+		valid bool
+	}{
+		{"no rules", nil, true},
+		{"archive and expire", []ObjectLifecycleRule{archive, expire}, true},
+		{"missing name", []ObjectLifecycleRule{{Action: ObjectLifecycleActionDelete, TimeAmount: 1, TimeUnit: ObjectLifecycleTimeUnitDays}}, false},
+		{"duplicated name", []ObjectLifecycleRule{archive, archive}, false},
+		{"invalid action", []ObjectLifecycleRule{{Name: "move", Action: "MOVE", TimeAmount: 1, TimeUnit: ObjectLifecycleTimeUnitDays}}, false},
+		{"invalid time unit", []ObjectLifecycleRule{{Name: "expire", Action: ObjectLifecycleActionDelete, TimeAmount: 1, TimeUnit: "MONTHS"}}, false},
+		{"zero time amount", []ObjectLifecycleRule{{Name: "expire", Action: ObjectLifecycleActionDelete, TimeUnit: ObjectLifecycleTimeUnitDays}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateObjectLifecycleRules(tc.rules)
+			if tc.valid && err != nil {
+				t.Errorf("Expected valid rules, got: %s", err.Error())
+			}
+			if !tc.valid && err == nil {
+				t.Error("Expected invalid rules")
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package oci
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/identity"
@@ -13,8 +16,21 @@ type OCI struct {
 	config          common.ConfigurationProvider
 	logger          logrus.FieldLogger
 	credential      *Credential
+	retryPolicy     RetryPolicy
 	Tenancy         identity.Tenancy
 	CompartmentOCID string
+
+	subscribedRegions *regionCache
+}
+
+// subscribedRegionsTTL is the time the subscribed regions of the tenancy are cached for
+const subscribedRegionsTTL = 10 * time.Minute
+
+// regionCache holds the names of the regions the tenancy is subscribed to, it is shared by the copies of an OCI
+type regionCache struct {
+	sync.Mutex
+	names   []string
+	expires time.Time
 }
 
 // Credential describe OCI credentials for access
@@ -36,9 +52,12 @@ func NewOCI(credential *Credential) (oci *OCI, err error) {
 	oci = &OCI{
 		CompartmentOCID: credential.CompartmentOCID,
 
-		config:     config,
-		logger:     logrus.New(),
-		credential: credential,
+		config:      config,
+		logger:      logrus.New(),
+		credential:  credential,
+		retryPolicy: DefaultRetryPolicy(),
+
+		subscribedRegions: &regionCache{},
 	}
 
 	_, err = oci.GetTenancy()
@@ -46,15 +65,64 @@ func NewOCI(credential *Credential) (oci *OCI, err error) {
 	return oci, err
 }
 
-// ChangeRegion changes region in the config to the specified one
-func (oci *OCI) ChangeRegion(regionName string) (err error) {
+// GetSubscribedRegions gives back the sorted names of the regions the tenancy is subscribed to,
+// the list is cached by the OCI for a short time
+func (oci *OCI) GetSubscribedRegions() ([]string, error) {
+
+	if oci.subscribedRegions != nil {
+		oci.subscribedRegions.Lock()
+		defer oci.subscribedRegions.Unlock()
+
+		if oci.subscribedRegions.names != nil && time.Now().Before(oci.subscribedRegions.expires) {
+			return oci.subscribedRegions.names, nil
+		}
+	}
 
 	i, err := oci.NewIdentityClient()
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := i.GetSubscribedRegionNames()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(regions))
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if oci.subscribedRegions != nil {
+		oci.subscribedRegions.names = names
+		oci.subscribedRegions.expires = time.Now().Add(subscribedRegionsTTL)
+	}
+
+	return names, nil
+}
+
+// ValidateRegion checks that the tenancy is subscribed to the region, RegionNotSubscribedError is returned otherwise
+func (oci *OCI) ValidateRegion(regionName string) error {
+
+	regions, err := oci.GetSubscribedRegions()
 	if err != nil {
 		return err
 	}
 
-	err = i.IsRegionAvailable(regionName)
+	for _, name := range regions {
+		if name == regionName {
+			return nil
+		}
+	}
+
+	return &RegionNotSubscribedError{Region: regionName, SubscribedRegions: regions}
+}
+
+// ChangeRegion changes region in the config to the specified one, the tenancy must be subscribed to the region
+func (oci *OCI) ChangeRegion(regionName string) (err error) {
+
+	err = oci.ValidateRegion(regionName)
 	if err != nil {
 		return err
 	}
@@ -73,6 +141,16 @@ func (oci *OCI) SetLogger(logger logrus.FieldLogger) {
 	oci.logger = logger
 }
 
+// WithLogger gives back a copy of the OCI which logs with the given logger, the copy shares the credentials,
+// the region config and the subscribed regions of the OCI
+func (oci *OCI) WithLogger(logger logrus.FieldLogger) *OCI {
+
+	c := *oci
+	c.logger = logger
+
+	return &c
+}
+
 // GetLogger gets the previously set logrus logger
 func (oci *OCI) GetLogger() logrus.FieldLogger {
 
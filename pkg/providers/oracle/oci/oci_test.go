@@ -0,0 +1,63 @@
+package oci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestWithLogger(t *testing.T) {
+
+	OCI := &OCI{
+		logger:            logrus.New(),
+		CompartmentOCID:   "ocid1.compartment.oc1..a",
+		subscribedRegions: &regionCache{},
+	}
+
+	logger := logrus.New().WithField("cluster", "a")
+	c := OCI.WithLogger(logger)
+
+	if c.GetLogger() != logger {
+		t.Error("Expected the copy to use the given logger")
+	}
+	if OCI.GetLogger() == logrus.FieldLogger(logger) {
+		t.Error("Expected the original logger to be kept")
+	}
+	if c.CompartmentOCID != OCI.CompartmentOCID || c.subscribedRegions != OCI.subscribedRegions {
+		t.Error("Expected the copy to share the config of the OCI")
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+
+	OCI := &OCI{
+		subscribedRegions: &regionCache{
+			names:   []string{"eu-frankfurt-1", "us-ashburn-1"},
+			expires: time.Now().Add(time.Hour),
+		},
+	}
+
+	cases := []struct {
+		region string
+		valid  bool
+	}{
+		{"eu-frankfurt-1", true},
+		{"us-ashburn-1", true},
+		{"us-phoenix-1", false},
+		{"eu-frankfrut-1", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.region, func(t *testing.T) {
+			err := OCI.ValidateRegion(tc.region)
+			if tc.valid && err != nil {
+				t.Errorf("Expected valid region, got: %s", err.Error())
+			}
+			if !tc.valid && !IsRegionNotSubscribedError(err) {
+				t.Errorf("Expected RegionNotSubscribedError, got: %v", err)
+			}
+		})
+	}
+}
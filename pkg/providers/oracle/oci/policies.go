@@ -0,0 +1,222 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/common"
+	"github.com/oracle/oci-go-sdk/identity"
+)
+
+// policyGroupPlaceholder and policyCompartmentPlaceholder stand for the group of the user and the compartment of the
+// credential in the required policy statements
+const (
+	policyGroupPlaceholder       = "<group>"
+	policyCompartmentPlaceholder = "<compartment>"
+)
+
+// okeServicePolicy lets the Container Engine service create the resources of the clusters
+const okeServicePolicy = "Allow service OKE to manage all-resources in tenancy"
+
+// requiredPolicyResources are the resource families pipeline manages in the compartment of the credential
+var requiredPolicyResources = []string{"cluster-family", "virtual-network-family", "instance-family", "volume-family"}
+
+// policyStatementRegexp matches the simple form of the policy statements, conditions are accepted but not evaluated
+var policyStatementRegexp = regexp.MustCompile(`^allow (group|service) (\S+) to manage (\S+) in (tenancy|compartment (\S+))( where .*)?$`)
+
+// GetRequiredOCIPolicies gives back the IAM policy statements pipeline needs to manage clusters, <group> is a group of
+// the user of the secret and <compartment> is the compartment of the secret
+func GetRequiredOCIPolicies() []string {
+
+	return getRequiredPolicies(policyGroupPlaceholder, policyCompartmentPlaceholder)
+}
+
+func getRequiredPolicies(group, compartment string) []string {
+
+	policies := []string{okeServicePolicy}
+	for _, resource := range requiredPolicyResources {
+		policies = append(policies, fmt.Sprintf("Allow group %s to manage %s in compartment %s", group, resource, compartment))
+	}
+
+	return policies
+}
+
+// GetMissingPolicies gives back the required policy statements which are not granted by the given statements to any
+// of the groups in the compartment, a statement granting all-resources or the whole tenancy covers every requirement
+func GetMissingPolicies(statements []string, groups []string, compartment string) []string {
+
+	serviceGranted := false
+	granted := make(map[string]bool)
+
+	for _, statement := range statements {
+		match := policyStatementRegexp.FindStringSubmatch(strings.ToLower(strings.Join(strings.Fields(statement), " ")))
+		if match == nil {
+			continue
+		}
+
+		subjectType, subject, resource, location := match[1], match[2], match[3], match[5]
+		if location != "" && !isPolicyCompartment(location, compartment) {
+			continue
+		}
+
+		if subjectType == "service" {
+			if subject == "oke" && resource == "all-resources" && location == "" {
+				serviceGranted = true
+			}
+			continue
+		}
+
+		if !containsFold(groups, subject) {
+			continue
+		}
+
+		if resource == "all-resources" {
+			for _, r := range requiredPolicyResources {
+				granted[r] = true
+			}
+		}
+		granted[resource] = true
+	}
+
+	group := policyGroupPlaceholder
+	if len(groups) > 0 {
+		group = groups[0]
+	}
+
+	missing := make([]string, 0)
+	for i, policy := range getRequiredPolicies(group, compartment) {
+		if i == 0 {
+			if !serviceGranted {
+				missing = append(missing, policy)
+			}
+			continue
+		}
+		if !granted[requiredPolicyResources[i-1]] {
+			missing = append(missing, policy)
+		}
+	}
+
+	return missing
+}
+
+// isPolicyCompartment checks whether the compartment of a policy statement is the given compartment,
+// compartment paths are compared by their last element
+func isPolicyCompartment(location, compartment string) bool {
+
+	path := strings.Split(location, ":")
+
+	return strings.EqualFold(path[len(path)-1], compartment)
+}
+
+func containsFold(values []string, value string) bool {
+
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckRequiredPolicies gives back the required policy statements which are missing for the groups of the user of
+// the credential, only the policies attached to the tenancy and to the compartment of the credential are checked
+func (oci *OCI) CheckRequiredPolicies() (missing []string, err error) {
+
+	i, err := oci.NewIdentityClient()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := i.GetUserGroupNames(oci.credential.UserOCID)
+	if err != nil {
+		return nil, err
+	}
+
+	compartmentName := "tenancy"
+	compartments := []string{*oci.Tenancy.Id}
+	if oci.CompartmentOCID != *oci.Tenancy.Id {
+		compartment, err := i.GetCompartment(&oci.CompartmentOCID)
+		if err != nil {
+			return nil, err
+		}
+		compartmentName = *compartment.Name
+		compartments = append(compartments, oci.CompartmentOCID)
+	}
+
+	statements := make([]string, 0)
+	for _, compartmentID := range compartments {
+		policies, err := i.GetPolicies(compartmentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies {
+			statements = append(statements, policy.Statements...)
+		}
+	}
+
+	return GetMissingPolicies(statements, groups, compartmentName), nil
+}
+
+// GetUserGroupNames gets the names of the groups of the user
+func (i *Identity) GetUserGroupNames(userID string) (names []string, err error) {
+
+	names = make([]string, 0)
+
+	request := identity.ListUserGroupMembershipsRequest{
+		CompartmentId: i.oci.Tenancy.Id,
+		UserId:        common.String(userID),
+	}
+
+	for {
+		response, err := i.client.ListUserGroupMemberships(context.Background(), request)
+		if err != nil {
+			return names, err
+		}
+
+		for _, membership := range response.Items {
+			group, err := i.client.GetGroup(context.Background(), identity.GetGroupRequest{
+				GroupId: membership.GroupId,
+			})
+			if err != nil {
+				return names, err
+			}
+			names = append(names, *group.Name)
+		}
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	return names, nil
+}
+
+// GetPolicies gets the policies attached to the compartment
+func (i *Identity) GetPolicies(compartmentID string) (policies []identity.Policy, err error) {
+
+	policies = make([]identity.Policy, 0)
+
+	request := identity.ListPoliciesRequest{
+		CompartmentId: common.String(compartmentID),
+	}
+
+	for {
+		response, err := i.client.ListPolicies(context.Background(), request)
+		if err != nil {
+			return policies, err
+		}
+
+		policies = append(policies, response.Items...)
+
+		if response.OpcNextPage == nil {
+			break
+		}
+		request.Page = response.OpcNextPage
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,35 @@
+package oci
+
+import "testing"
+
+func TestGetMissingPolicies(t *testing.T) {
+
+	service := "Allow service OKE to manage all-resources in tenancy"
+
+	cases := []struct {
+		name       string
+		statements []string
+		missing    int
+	}{
+		{"no policies", nil, 5},
+		{"administrators", []string{service, "Allow group Administrators to manage all-resources in tenancy"}, 0},
+		{"compartment policies", []string{
+			service,
+			"allow group pipeline to manage cluster-family in compartment dev",
+			"Allow group Pipeline to manage virtual-network-family in compartment root:dev",
+			"Allow  group pipeline  to manage instance-family in compartment dev where request.region = 'phx'",
+		}, 1},
+		{"other compartment", []string{service, "Allow group pipeline to manage all-resources in compartment prod"}, 4},
+		{"other group", []string{service, "Allow group other to manage all-resources in tenancy"}, 4},
+		{"read only", []string{service, "Allow group pipeline to read all-resources in tenancy"}, 4},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			missing := GetMissingPolicies(tc.statements, []string{"Administrators", "pipeline"}, "dev")
+			if len(missing) != tc.missing {
+				t.Errorf("Expected %d missing policies, got: %v", tc.missing, missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package oci
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// RetryPolicy describes how the failed OCI API calls are retried, it applies to every call made
+// by the clients of an OCI
+type RetryPolicy struct {
+	MaxAttempts uint
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	StatusCodes []int
+}
+
+// DefaultRetryPolicy retries throttled and temporarily unavailable calls with exponential backoff
+func DefaultRetryPolicy() RetryPolicy {
+
+	return RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  30 * time.Second,
+		StatusCodes: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// NoRetryPolicy disables retrying, every call is made only once
+func NoRetryPolicy() RetryPolicy {
+
+	return RetryPolicy{
+		MaxAttempts: 1,
+	}
+}
+
+// Enabled returns true if failed calls are retried
+func (p RetryPolicy) Enabled() bool {
+
+	return p.MaxAttempts > 1
+}
+
+// shouldRetry returns true if the request can be resent after the given response status code, requests which are
+// not idempotent are only resent when they were throttled since OCI hasn't processed those; the opc-retry-token set
+// by the SDK on every request is not honoured by all the endpoints, so it doesn't make a retry safe
+func (p RetryPolicy) shouldRetry(method string, statusCode int) bool {
+
+	if !isIdempotent(method) && statusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	for _, code := range p.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		return false
+	default:
+		return true
+	}
+}
+
+// getBackoff gives back the time to wait before the given (zero based) attempt
+func (p RetryPolicy) getBackoff(attempt uint) time.Duration {
+
+	backoff := p.Backoff
+	for i := uint(1); i < attempt; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// retryingDispatcher retries the HTTP requests of an OCI SDK client according to the retry policy
+type retryingDispatcher struct {
+	dispatcher common.HTTPRequestDispatcher
+	policy     RetryPolicy
+	after      func(time.Duration) <-chan time.Time
+}
+
+func newRetryingDispatcher(dispatcher common.HTTPRequestDispatcher, policy RetryPolicy) *retryingDispatcher {
+
+	return &retryingDispatcher{
+		dispatcher: dispatcher,
+		policy:     policy,
+		after:      time.After,
+	}
+}
+
+// Do sends the request and retries it while the response status code is retryable and attempts are left
+func (d *retryingDispatcher) Do(req *http.Request) (*http.Response, error) {
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := uint(1); ; attempt++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		response, err := d.dispatcher.Do(req)
+		if err != nil || attempt >= d.policy.MaxAttempts || !d.policy.shouldRetry(req.Method, response.StatusCode) || req.Context().Err() != nil {
+			return response, err
+		}
+
+		// the body of a response which is not returned must be closed
+		response.Body.Close()
+
+		// the backoff is cut short when the request is cancelled
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-d.after(d.policy.getBackoff(attempt)):
+		}
+	}
+}
+
+// SetRetryPolicy sets the retry policy of the OCI API calls made by the clients created afterwards
+func (oci *OCI) SetRetryPolicy(policy RetryPolicy) {
+
+	oci.retryPolicy = policy
+}
+
+// configureClient applies the retry policy to an OCI SDK client
+func (oci *OCI) configureClient(client *common.BaseClient) {
+
+	if oci.retryPolicy.Enabled() {
+		client.HTTPClient = newRetryingDispatcher(client.HTTPClient, oci.retryPolicy)
+	}
+}
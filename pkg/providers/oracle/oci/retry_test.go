@@ -0,0 +1,112 @@
+package oci
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeDispatcher struct {
+	statusCodes []int
+	bodies      []string
+}
+
+func (d *fakeDispatcher) Do(req *http.Request) (*http.Response, error) {
+
+	body, _ := ioutil.ReadAll(req.Body)
+	d.bodies = append(d.bodies, string(body))
+
+	code := d.statusCodes[0]
+	if len(d.statusCodes) > 1 {
+		d.statusCodes = d.statusCodes[1:]
+	}
+
+	return &http.Response{StatusCode: code, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestRetryingDispatcher(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		method      string
+		policy      RetryPolicy
+		statusCodes []int
+		attempts    int
+		statusCode  int
+	}{
+		{"success", "PUT", DefaultRetryPolicy(), []int{200}, 1, 200},
+		{"throttled", "PUT", DefaultRetryPolicy(), []int{429, 429, 200}, 3, 200},
+		{"max attempts", "PUT", DefaultRetryPolicy(), []int{503}, 5, 503},
+		{"not retryable", "PUT", DefaultRetryPolicy(), []int{404}, 1, 404},
+		{"disabled", "PUT", NoRetryPolicy(), []int{429, 200}, 1, 429},
+		{"post throttled", "POST", DefaultRetryPolicy(), []int{429, 200}, 2, 200},
+		{"post unavailable", "POST", DefaultRetryPolicy(), []int{503, 200}, 1, 503},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeDispatcher{statusCodes: tc.statusCodes}
+			d := newRetryingDispatcher(fake, tc.policy)
+			d.after = func(time.Duration) <-chan time.Time {
+				c := make(chan time.Time, 1)
+				c <- time.Now()
+				return c
+			}
+
+			req, _ := http.NewRequest(tc.method, "https://example.com", strings.NewReader("body"))
+			response, err := d.Do(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+			if response.StatusCode != tc.statusCode {
+				t.Errorf("Expected status code: %d, got: %d", tc.statusCode, response.StatusCode)
+			}
+			if len(fake.bodies) != tc.attempts {
+				t.Errorf("Expected attempts: %d, got: %d", tc.attempts, len(fake.bodies))
+			}
+			for _, body := range fake.bodies {
+				if body != "body" {
+					t.Errorf("Expected request body to be resent, got: %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryingDispatcherCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := &fakeDispatcher{statusCodes: []int{429, 200}}
+	d := newRetryingDispatcher(fake, DefaultRetryPolicy())
+	d.after = func(time.Duration) <-chan time.Time {
+		// the request is cancelled during the backoff, which never ends
+		cancel()
+		return nil
+	}
+
+	req, _ := http.NewRequest("PUT", "https://example.com", strings.NewReader("body"))
+	_, err := d.Do(req.WithContext(ctx))
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+	if len(fake.bodies) != 1 {
+		t.Errorf("Expected attempts: 1, got: %d", len(fake.bodies))
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+
+	policy := RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, backoff := range expected {
+		if got := policy.getBackoff(uint(i + 1)); got != backoff {
+			t.Errorf("Attempt %d: expected backoff: %s, got: %s", i+1, backoff, got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package oci
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// shapeCacheTTL is the time the compute shapes of a region are cached for, validating the node pools of a request
+// lists the shapes once
+const shapeCacheTTL = 5 * time.Minute
+
+type cachedShapeNames struct {
+	names   []string
+	expires time.Time
+}
+
+// shapeCache holds the compute shape names by compartment and region
+var shapeCache = struct {
+	sync.Mutex
+	entries map[string]cachedShapeNames
+}{
+	entries: make(map[string]cachedShapeNames),
+}
+
+// GetRegionShapeNames gives back the names of the compute shapes offered in the region of the OCI, the list is
+// cached per compartment and region for a short time
+func (oci *OCI) GetRegionShapeNames() (names []string, err error) {
+
+	region, err := oci.config.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	key := oci.CompartmentOCID + "/" + region
+
+	shapeCache.Lock()
+	cached, ok := shapeCache.entries[key]
+	shapeCache.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.names, nil
+	}
+
+	compute, err := oci.NewComputeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err = compute.GetShapeNames(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	shapeCache.Lock()
+	shapeCache.entries[key] = cachedShapeNames{
+		names:   names,
+		expires: time.Now().Add(shapeCacheTTL),
+	}
+	shapeCache.Unlock()
+
+	return names, nil
+}
@@ -1,5 +1,7 @@
 package oci
 
+import "context"
+
 // GetSupportedShapes gives back supported node shapes in all subscribed regions
 func (oci *OCI) GetSupportedShapes() (shapes map[string][]string, err error) {
 
@@ -38,7 +40,7 @@ func (oci *OCI) GetSupportedShapesInARegion(region string) (shapes []string, err
 		return nil, err
 	}
 
-	options, err := ce.GetDefaultNodePoolOptions()
+	options, err := ce.GetDefaultNodePoolOptions(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +86,7 @@ func (oci *OCI) GetSupportedImagesInARegion(region string) (images []string, err
 		return nil, err
 	}
 
-	options, err := ce.GetDefaultNodePoolOptions()
+	options, err := ce.GetDefaultNodePoolOptions(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +132,7 @@ func (oci *OCI) GetSupportedK8SVersionsInARegion(region string) (k8sversions []s
 		return nil, err
 	}
 
-	options, err := ce.GetDefaultNodePoolOptions()
+	options, err := ce.GetDefaultNodePoolOptions(context.Background())
 	if err != nil {
 		return nil, err
 	}
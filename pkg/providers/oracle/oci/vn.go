@@ -26,6 +26,8 @@ func (oci *OCI) NewVirtualNetworkClient() (client *VirtualNetwork, err error) {
 		return client, err
 	}
 
+	oci.configureClient(&oClient.BaseClient)
+
 	client.client = &oClient
 	client.oci = oci
 	client.CompartmentOCID = oci.CompartmentOCID
@@ -95,6 +97,29 @@ func (vn *VirtualNetwork) GetVCNByName(name string) (vcn core.Vcn, err error) {
 	return response.Items[0], err
 }
 
+// GetVCNsByName gets the not terminated VCNs with the given name within the Compartment
+func (vn *VirtualNetwork) GetVCNsByName(name string) (vcns []core.Vcn, err error) {
+
+	vcns = make([]core.Vcn, 0)
+
+	items, err := vn.GetVCNs()
+	if err != nil {
+		return vcns, err
+	}
+
+	for _, vcn := range items {
+		if vcn.DisplayName == nil || *vcn.DisplayName != name {
+			continue
+		}
+		if vcn.LifecycleState == core.VcnLifecycleStateTerminating || vcn.LifecycleState == core.VcnLifecycleStateTerminated {
+			continue
+		}
+		vcns = append(vcns, vcn)
+	}
+
+	return vcns, nil
+}
+
 // GetVCNs gets all VCNs within the Compartment
 func (vn *VirtualNetwork) GetVCNs() (vcns []core.Vcn, err error) {
 
@@ -1,6 +1,8 @@
 package secret
 
 import (
+	"fmt"
+
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
 )
 
@@ -14,6 +16,44 @@ const (
 	CompartmentOCID   = "compartment_ocid"
 )
 
+// Optional Oracle keys of the subject which gets cluster admin rights instead of the user of the secret
+const (
+	AdminSubjectKind = "admin_subject_kind"
+	AdminSubjectName = "admin_subject_name"
+)
+
+// MissingValueError is returned when a required value of an Oracle secret is missing or empty
+type MissingValueError struct {
+	SecretID string
+	Key      string
+}
+
+func (e *MissingValueError) Error() string {
+	return fmt.Sprintf("secret %s has no %s", e.SecretID, e.Key)
+}
+
+// IsInvalid marks the error as a validation error of the request
+func (e *MissingValueError) IsInvalid() bool {
+	return true
+}
+
+// IsMissingValueError returns false if the error is not MissingValueError, otherwise true
+func IsMissingValueError(err error) (ok bool) {
+	_, ok = err.(*MissingValueError)
+	return ok
+}
+
+// ValidateUserOCID checks that the secret has the OCID of its user, which gets the cluster admin rights unless
+// the admin subject is set
+func ValidateUserOCID(secretID string, values map[string]string) error {
+
+	if values[UserOCID] == "" {
+		return &MissingValueError{SecretID: secretID, Key: UserOCID}
+	}
+
+	return nil
+}
+
 // OCIVerify for validation OCI credentials
 type OCIVerify struct {
 	credential *oci.Credential
@@ -38,9 +78,14 @@ func CreateOCICredential(values map[string]string) *oci.Credential {
 	}
 }
 
-// VerifySecret validates OCI credentials
+// VerifySecret validates OCI credentials, the errors of the API key, the fingerprint and the region name the wrong
+// field
 func (a *OCIVerify) VerifySecret() (err error) {
 
+	if err := oci.ValidateOCICredential(a.credential); err != nil {
+		return err
+	}
+
 	client, err := oci.NewOCI(a.credential)
 	if err != nil {
 		return err
@@ -174,6 +174,8 @@ var DefaultRules = map[string]Meta{
 			{Name: oracle.APIKeyFingerprint, Required: true},
 			{Name: oracle.Region, Required: true},
 			{Name: oracle.CompartmentOCID, Required: true},
+			{Name: oracle.AdminSubjectKind, Required: false},
+			{Name: oracle.AdminSubjectName, Required: false},
 		},
 	},
 	SSHSecretType: {
@@ -11,6 +11,8 @@ import (
 
 	"github.com/banzaicloud/bank-vaults/pkg/tls"
 	"github.com/banzaicloud/bank-vaults/vault"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	secretOracle "github.com/banzaicloud/pipeline/pkg/providers/oracle/secret"
 	secretTypes "github.com/banzaicloud/pipeline/pkg/secret"
 	"github.com/banzaicloud/pipeline/secret/verify"
 	vaultapi "github.com/hashicorp/vault/api"
@@ -141,6 +143,12 @@ func (r *CreateSecretRequest) Validate(verifier verify.Verifier) error {
 		}
 	}
 
+	if r.Type == pkgCluster.Oracle {
+		if err := secretOracle.ValidateUserOCID(GenerateSecretID(r), r.Values); err != nil {
+			return err
+		}
+	}
+
 	if verifier != nil {
 		return verifier.VerifySecret()
 	}
@@ -53,6 +53,7 @@ func TestCreateSecretValidate(t *testing.T) {
 		{name: "gke missing key", request: gkeMissingKey, isError: true, verifier: nil},
 		{name: "ssh missing key", request: sshMissingKey, isError: true, verifier: nil},
 		{name: "oracle missing key", request: okeMissingKey, isError: true, verifier: nil},
+		{name: "oracle empty user OCID", request: okeEmptyUserOCID, isError: true, verifier: nil},
 	}
 
 	for _, tc := range cases {
@@ -229,6 +230,19 @@ var (
 			oracle.Region:            oracle.Region,
 		},
 	}
+
+	okeEmptyUserOCID = secret.CreateSecretRequest{
+		Name: secretDesc,
+		Type: pkgCluster.Oracle,
+		Values: map[string]string{
+			oracle.UserOCID:          "",
+			oracle.TenancyOCID:       oracle.TenancyOCID,
+			oracle.APIKey:            oracle.APIKey,
+			oracle.APIKeyFingerprint: oracle.APIKeyFingerprint,
+			oracle.Region:            oracle.Region,
+			oracle.CompartmentOCID:   oracle.CompartmentOCID,
+		},
+	}
 )
 
 var (
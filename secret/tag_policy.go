@@ -0,0 +1,92 @@
+package secret
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	pipConfig "github.com/banzaicloud/pipeline/config"
+)
+
+// banzaiTagPrefix is the prefix of the tags used by Pipeline itself, these are not subject to the tag policies
+const banzaiTagPrefix = "banzai:"
+
+// TagPolicy describes the tag conventions of the secrets of an organization
+type TagPolicy struct {
+	// RequiredTagPrefixes must each be the prefix of at least one tag of the secret, e.g. "team:"
+	RequiredTagPrefixes []string `mapstructure:"requiredTagPrefixes"`
+	// AllowedTagPrefixes restricts the tags of the secret to the ones having one of the prefixes, no restriction if empty
+	AllowedTagPrefixes []string `mapstructure:"allowedTagPrefixes"`
+}
+
+// TagPolicyError describes a secret error where the tags don't conform to the tag policy of the organization
+type TagPolicyError struct {
+	Tag    string
+	Reason string
+}
+
+func (e TagPolicyError) Error() string {
+	if e.Tag == "" {
+		return fmt.Sprintf("secret tags violate the tag policy: %s", e.Reason)
+	}
+	return fmt.Sprintf("secret tag %q violates the tag policy: %s", e.Tag, e.Reason)
+}
+
+// GetTagPolicy gives back the configured tag policy of the organization, nil if there is none
+func GetTagPolicy(organizationName string) (*TagPolicy, error) {
+
+	var policies map[string]*TagPolicy
+	if err := viper.UnmarshalKey(pipConfig.SecretTagPolicies, &policies); err != nil {
+		return nil, errors.Wrap(err, "error parsing secret tag policies")
+	}
+
+	return policies[organizationName], nil
+}
+
+// Validate checks the tags against the policy
+func (p *TagPolicy) Validate(tags []string) error {
+
+	if p == nil {
+		return nil
+	}
+
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, banzaiTagPrefix) || len(p.AllowedTagPrefixes) == 0 {
+			continue
+		}
+		if !hasAnyPrefix(tag, p.AllowedTagPrefixes) {
+			return TagPolicyError{
+				Tag:    tag,
+				Reason: fmt.Sprintf("allowed prefixes are [%s]", strings.Join(p.AllowedTagPrefixes, ", ")),
+			}
+		}
+	}
+
+	for _, prefix := range p.RequiredTagPrefixes {
+		found := false
+		for _, tag := range tags {
+			if strings.HasPrefix(tag, prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return TagPolicyError{
+				Reason: fmt.Sprintf("a tag with prefix %q is required", prefix),
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
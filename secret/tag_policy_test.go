@@ -0,0 +1,44 @@
+package secret_test
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/pipeline/secret"
+)
+
+func TestTagPolicyValidate(t *testing.T) {
+
+	policy := &secret.TagPolicy{
+		RequiredTagPrefixes: []string{"team:"},
+		AllowedTagPrefixes:  []string{"team:", "env:"},
+	}
+
+	cases := []struct {
+		name   string
+		policy *secret.TagPolicy
+		tags   []string
+		valid  bool
+	}{
+		{"no policy", nil, []string{"anything"}, true},
+		{"conforming", policy, []string{"team:platform", "env:prod"}, true},
+		{"banzai tag", policy, []string{"team:platform", "banzai:hidden"}, true},
+		{"missing required", policy, []string{"env:prod"}, false},
+		{"not allowed", policy, []string{"team:platform", "owner:joe"}, false},
+		{"no tags", policy, nil, false},
+		{"only required", &secret.TagPolicy{RequiredTagPrefixes: []string{"team:"}}, []string{"team:a", "other"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate(tc.tags)
+			if tc.valid && err != nil {
+				t.Errorf("Expected no error, got: %s", err.Error())
+			}
+			if !tc.valid {
+				if _, ok := err.(secret.TagPolicyError); !ok {
+					t.Errorf("Expected TagPolicyError, got: %v", err)
+				}
+			}
+		})
+	}
+}